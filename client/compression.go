@@ -0,0 +1,83 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package client
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const gzipEncoding = "gzip"
+
+//gzipTransport asks server for gzip compressed response and decompresses it transparently.
+//Request body is not compressed here, since it has to be compressed before request is signed
+type gzipTransport struct {
+	next http.RoundTripper
+}
+
+//EnableCompression wraps client's transport to send "Accept-Encoding: gzip" and decompress gzip responses
+func EnableCompression(c *Client) {
+	if _, ok := c.HTTPClient.HTTPClient.Transport.(*gzipTransport); ok {
+		return
+	}
+	next := c.HTTPClient.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.HTTPClient.HTTPClient.Transport = &gzipTransport{next: next}
+}
+
+//RoundTrip implements http.RoundTripper
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip should not modify request, work on a copy instead
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", gzipEncoding)
+	response, err := t.next.RoundTrip(req)
+	if err != nil || response == nil {
+		return response, err
+	}
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), gzipEncoding) {
+		response.Body = &gzipReadCloser{body: response.Body}
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+		response.ContentLength = -1
+		response.Uncompressed = true
+	}
+	return response, nil
+}
+
+//gzipReadCloser decompresses body lazily, so that empty body doesn't fail before it is read
+type gzipReadCloser struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+}
+
+func (g *gzipReadCloser) Read(p []byte) (int, error) {
+	if g.reader == nil {
+		reader, err := gzip.NewReader(g.body)
+		if err != nil {
+			return 0, err
+		}
+		g.reader = reader
+	}
+	return g.reader.Read(p)
+}
+
+//Close closes both gzip reader and original body
+func (g *gzipReadCloser) Close() error {
+	if g.reader != nil {
+		_ = g.reader.Close()
+	}
+	return g.body.Close()
+}
@@ -0,0 +1,107 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return compressed.Bytes()
+}
+
+func TestEnableCompression(t *testing.T) {
+	payload := []byte(`{"name":"detector"}`)
+	t.Run("decompress response", func(t *testing.T) {
+		c, err := New(roundTripFunc(func(req *http.Request) *http.Response {
+			assert.EqualValues(t, "gzip", req.Header.Get("Accept-Encoding"))
+			assert.Empty(t, req.Header.Get("Content-Encoding"))
+			body, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.EqualValues(t, payload, body)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(gzipBytes(t, []byte("response")))),
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Request:    req,
+			}
+		}))
+		assert.NoError(t, err)
+		EnableCompression(c)
+		response, err := c.HTTPClient.Post("http://localhost:9200", "application/json", payload)
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "response", string(body))
+		assert.Empty(t, response.Header.Get("Content-Encoding"))
+	})
+	t.Run("enable twice", func(t *testing.T) {
+		c, err := New(roundTripFunc(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(gzipBytes(t, []byte("response")))),
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Request:    req,
+			}
+		}))
+		assert.NoError(t, err)
+		EnableCompression(c)
+		// enabling compression twice should not wrap transport twice
+		EnableCompression(c)
+		transport, ok := c.HTTPClient.HTTPClient.Transport.(*gzipTransport)
+		assert.True(t, ok)
+		_, ok = transport.next.(*gzipTransport)
+		assert.False(t, ok)
+		response, err := c.HTTPClient.Get("http://localhost:9200")
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "response", string(body))
+	})
+	t.Run("empty compressed response", func(t *testing.T) {
+		c, err := New(roundTripFunc(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Request:    req,
+			}
+		}))
+		assert.NoError(t, err)
+		EnableCompression(c)
+		response, err := c.HTTPClient.Get("http://localhost:9200")
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		assert.NoError(t, err)
+		assert.Empty(t, body)
+	})
+}
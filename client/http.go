@@ -27,17 +27,40 @@ package client
 
 import (
 	"crypto/tls"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
-const defaultTimeout = 10
+const (
+	defaultTimeout = 10
+	//DefaultMaxIdleConns is maximum number of idle connections kept open across all hosts
+	DefaultMaxIdleConns = 100
+	//DefaultMaxIdleConnsPerHost is maximum number of idle connections kept open for a host
+	DefaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+	//DefaultMaxResponseSize is maximum size of response body read into memory, in bytes
+	DefaultMaxResponseSize = 100 << 20
+)
 
 //Client is an Abstraction for actual client
 type Client struct {
 	HTTPClient *retryablehttp.Client
+	// Debug receives requests and responses, with credentials redacted, if it is not nil
+	Debug io.Writer
+	// DryRun receives requests, with credentials redacted, instead of sending them to cluster if it is not nil
+	DryRun io.Writer
+	// MaxResponseSize is maximum size of response body read into memory, in bytes. Zero means unlimited
+	MaxResponseSize int64
+	// OpaqueID is sent as X-Opaque-Id header with every request if it is not empty, so that requests can be
+	// correlated with OpenSearch logs
+	OpaqueID string
+	// RetryPolicy decides which failed requests are retried, non idempotent requests are not retried by default
+	RetryPolicy RetryPolicy
+	// transport is client's own copy of the transport it was created with, see CloneTransport
+	transport *http.Transport
 }
 
 //NewDefaultClient return new instance of client
@@ -47,17 +70,67 @@ func NewDefaultClient(tripper http.RoundTripper) (*Client, error) {
 	client.HTTPClient.Transport = tripper
 	client.HTTPClient.Timeout = defaultTimeout * time.Second
 	client.Logger = nil
-	return &Client{
-		HTTPClient: client,
-	}, nil
+	c := &Client{
+		HTTPClient:      client,
+		MaxResponseSize: DefaultMaxResponseSize,
+	}
+	if err := SetRedirectPolicy(c, RedirectSameHost); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+//NewTransport returns transport which uses tlsConfig to connect to cluster, cluster's certificate
+//is verified unless InsecureSkipVerify is set. Proxy is picked from HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+//environment variables
+func NewTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     defaultIdleConnTimeout,
+	}
+}
+
+//GetTransport returns client's transport, so that it can be configured and reused instead of creating new one.
+//It returns false if client's transport is not *http.Transport
+func GetTransport(c *Client) (*http.Transport, bool) {
+	tripper := c.HTTPClient.HTTPClient.Transport
+	if t, ok := tripper.(*gzipTransport); ok {
+		tripper = t.next
+	}
+	transport, ok := tripper.(*http.Transport)
+	return transport, ok
+}
+
+//CloneTransport replaces client's transport with its clone, so that it can be configured without changing
+//transport which client was created with, and which may be shared with other clients. Transport is cloned
+//only once, later calls return same clone, so that gateways created from same client share connections.
+//If client's transport is not *http.Transport, it is replaced with new transport
+func CloneTransport(c *Client) *http.Transport {
+	transport, ok := GetTransport(c)
+	if ok && transport == c.transport {
+		return transport
+	}
+	if ok {
+		transport = transport.Clone()
+	} else {
+		transport = NewTransport(&tls.Config{})
+	}
+	if t, ok := c.HTTPClient.HTTPClient.Transport.(*gzipTransport); ok {
+		t.next = transport
+	} else {
+		c.HTTPClient.HTTPClient.Transport = transport
+	}
+	c.transport = transport
+	return transport
 }
 
 //New takes transport and uses accordingly
 func New(tripper http.RoundTripper) (*Client, error) {
 	if tripper == nil {
-		tripper = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
+		tripper = NewTransport(&tls.Config{})
 	}
 	return NewDefaultClient(tripper)
 }
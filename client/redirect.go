@@ -0,0 +1,67 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+//RedirectPolicy controls which redirects from cluster are followed
+type RedirectPolicy string
+
+const (
+	//RedirectSameHost follows redirects only to the host of original request, it is default policy
+	RedirectSameHost RedirectPolicy = "same-host"
+	//RedirectAll follows redirects to any host, authorization header is not sent to other hosts
+	RedirectAll RedirectPolicy = "all"
+	//RedirectNone doesn't follow any redirect
+	RedirectNone RedirectPolicy = "none"
+	maxRedirects                = 10
+)
+
+//ErrRedirectNotFollowed is returned if cluster redirected request and redirect policy doesn't allow to follow it
+var ErrRedirectNotFollowed = errors.New("redirect not followed")
+
+//SetRedirectPolicy configures client to follow redirects according to policy. Authorization header is dropped
+//if request is redirected to another host, or from https to http
+func SetRedirectPolicy(c *Client, policy RedirectPolicy) error {
+	switch policy {
+	case RedirectSameHost, RedirectAll, RedirectNone:
+	default:
+		return fmt.Errorf("invalid redirect policy: %s, supported policies are: %s, %s, %s",
+			policy, RedirectSameHost, RedirectAll, RedirectNone)
+	}
+	c.HTTPClient.HTTPClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return checkRedirect(policy, req, via)
+	}
+	return nil
+}
+
+//checkRedirect decides whether req, which is redirected from via, can be followed
+func checkRedirect(policy RedirectPolicy, req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		// same message as http client, so that it is not retried
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	original := via[0].URL
+	sameHost := req.URL.Host == original.Host
+	if policy == RedirectNone || (policy == RedirectSameHost && !sameHost) {
+		return fmt.Errorf("%w: %s redirected to %s, redirect policy is %s",
+			ErrRedirectNotFollowed, original.Redacted(), req.URL.Redacted(), policy)
+	}
+	if !sameHost || (original.Scheme == "https" && req.URL.Scheme == "http") {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
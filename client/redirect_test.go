@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package client
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRedirectPolicy(t *testing.T) {
+	// target answers with authorization header it received
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("target:" + r.Header.Get("Authorization")))
+	}))
+	defer target.Close()
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/final":
+			_, _ = w.Write([]byte("source:" + r.Header.Get("Authorization")))
+		case "/same-host":
+			http.Redirect(w, r, "/final", http.StatusTemporaryRedirect)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusTemporaryRedirect)
+		default:
+			http.Redirect(w, r, target.URL+"/final", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer source.Close()
+	get := func(t *testing.T, policy RedirectPolicy, path string) (string, error) {
+		c, err := New(nil)
+		assert.NoError(t, err)
+		c.HTTPClient.RetryMax = 0
+		if len(policy) > 0 {
+			assert.NoError(t, SetRedirectPolicy(c, policy))
+		}
+		req, err := http.NewRequest(http.MethodGet, source.URL+path, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		response, err := c.HTTPClient.StandardClient().Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		assert.NoError(t, err)
+		return string(body), nil
+	}
+	t.Run("default follows same host with authorization", func(t *testing.T) {
+		body, err := get(t, "", "/same-host")
+		assert.NoError(t, err)
+		assert.Equal(t, "source:Bearer token", body)
+	})
+	t.Run("default doesn't follow other host", func(t *testing.T) {
+		_, err := get(t, "", "/other-host")
+		assert.True(t, errors.Is(err, ErrRedirectNotFollowed))
+	})
+	t.Run("same host doesn't follow other host", func(t *testing.T) {
+		_, err := get(t, RedirectSameHost, "/other-host")
+		assert.True(t, errors.Is(err, ErrRedirectNotFollowed))
+		assert.Contains(t, err.Error(), "redirect policy is same-host")
+	})
+	t.Run("all follows other host without authorization", func(t *testing.T) {
+		body, err := get(t, RedirectAll, "/other-host")
+		assert.NoError(t, err)
+		assert.Equal(t, "target:", body)
+	})
+	t.Run("all follows same host with authorization", func(t *testing.T) {
+		body, err := get(t, RedirectAll, "/same-host")
+		assert.NoError(t, err)
+		assert.Equal(t, "source:Bearer token", body)
+	})
+	t.Run("none doesn't follow same host", func(t *testing.T) {
+		_, err := get(t, RedirectNone, "/same-host")
+		assert.True(t, errors.Is(err, ErrRedirectNotFollowed))
+		assert.Contains(t, err.Error(), "redirect policy is none")
+	})
+	t.Run("too many redirects", func(t *testing.T) {
+		_, err := get(t, RedirectAll, "/loop")
+		assert.Contains(t, err.Error(), "stopped after 10 redirects")
+	})
+	t.Run("invalid policy", func(t *testing.T) {
+		c, err := New(nil)
+		assert.NoError(t, err)
+		assert.EqualError(t, SetRedirectPolicy(c, "sometimes"),
+			"invalid redirect policy: sometimes, supported policies are: same-host, all, none")
+	})
+}
@@ -0,0 +1,55 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package client
+
+import (
+	"net/http"
+	"strings"
+)
+
+//RetryPredicate decides whether failed attempt of request is retried, attempt starts from 1.
+//Either resp or err is nil
+type RetryPredicate func(resp *http.Response, err error, attempt int) bool
+
+//RetryPolicy decides which failed requests are retried, in addition to max retry of the profile
+type RetryPolicy struct {
+	// Predicate replaces default policy, which retries connection errors and server errors, if it is not nil.
+	// It is not consulted for requests which cannot be retried safely
+	Predicate RetryPredicate
+	// RetryNonIdempotent retries requests which might not be safe to send again, like POST which creates detector
+	RetryNonIdempotent bool
+}
+
+//readOnlyPostAPIs are APIs which use POST to send query, sending them again has same effect
+var readOnlyPostAPIs = []string{"_search", "_msearch", "_count", "_explain", "_validate", "_field_caps", "_preview", "_mget", "_analyze"}
+
+//IsIdempotent returns true if sending request again has same effect as sending it once. GET, HEAD, OPTIONS,
+//PUT and DELETE are idempotent, POST is idempotent only for read-only APIs like _search
+func IsIdempotent(method string, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		api := path[strings.LastIndex(path, "/")+1:]
+		for _, readOnly := range readOnlyPostAPIs {
+			if api == readOnly {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//CanRetry returns true if request with given method and path can be retried under this policy
+func (p RetryPolicy) CanRetry(method string, path string) bool {
+	return p.RetryNonIdempotent || IsIdempotent(method, path)
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	assert.True(t, IsIdempotent(http.MethodGet, "/_plugins/_anomaly_detection/detectors/id"))
+	assert.True(t, IsIdempotent(http.MethodPut, "/_plugins/_anomaly_detection/detectors/id"))
+	assert.True(t, IsIdempotent(http.MethodDelete, "/_plugins/_anomaly_detection/detectors/id"))
+	assert.True(t, IsIdempotent(http.MethodPost, "/_plugins/_anomaly_detection/detectors/_search"))
+	assert.False(t, IsIdempotent(http.MethodPost, "/_plugins/_anomaly_detection/detectors"))
+	assert.False(t, IsIdempotent(http.MethodPost, "/_plugins/_anomaly_detection/detectors/id/_start"))
+	assert.False(t, IsIdempotent(http.MethodPatch, "/index/_doc/1"))
+}
+
+func TestRetryPolicy_CanRetry(t *testing.T) {
+	assert.False(t, RetryPolicy{}.CanRetry(http.MethodPost, "/_plugins/_anomaly_detection/detectors"))
+	assert.True(t, RetryPolicy{RetryNonIdempotent: true}.CanRetry(http.MethodPost, "/_plugins/_anomaly_detection/detectors"))
+	assert.True(t, RetryPolicy{}.CanRetry(http.MethodGet, "/_plugins/_anomaly_detection/detectors/id"))
+}
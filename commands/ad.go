@@ -26,19 +26,21 @@
 package commands
 
 import (
-	"opensearch-cli/client"
+	"io"
 	adctrl "opensearch-cli/controller/ad"
 	ctrl "opensearch-cli/controller/platform"
 	adgateway "opensearch-cli/gateway/ad"
 	gateway "opensearch-cli/gateway/platform"
 	handler "opensearch-cli/handler/ad"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 const (
 	adCommandName = "ad"
+	flagYes       = "yes"
 )
 
 //adCommand is base command for Anomaly Detection plugin.
@@ -50,6 +52,13 @@ var adCommand = &cobra.Command{
 
 func init() {
 	adCommand.Flags().BoolP("help", "h", false, "Help for Anomaly Detection")
+	adCommand.PersistentFlags().StringP(outputFlagName, "o", jsonOutputFormat,
+		"Output format, one of: "+strings.Join(outputFormats, ", "))
+	adCommand.PersistentFlags().StringP(queryFlagName, "q", "",
+		"Print only fields selected by dotted path, for example: features[].feature_name. Alias: --"+filterFlagName)
+	adCommand.PersistentFlags().BoolP(flagYes, "y", false,
+		"Skip confirmation before starting, stopping or deleting detectors. Confirmation is skipped if input is not a terminal as well")
+	adCommand.SetGlobalNormalizationFunc(normalizeQueryFlag)
 	GetRoot().AddCommand(adCommand)
 }
 
@@ -61,7 +70,7 @@ func GetADCommand() *cobra.Command {
 
 //GetADHandler returns handler by wiring the dependency manually
 func GetADHandler() (*handler.Handler, error) {
-	c, err := client.New(nil)
+	c, err := GetClient()
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +87,15 @@ func GetADHandler() (*handler.Handler, error) {
 		return nil, err
 	}
 	esc := ctrl.New(esg)
-	ctr := adctrl.New(os.Stdin, esc, g)
+	ctr := adctrl.New(confirmationReader(), esc, g)
 	return handler.New(ctr), nil
 }
+
+//confirmationReader returns standard input to confirm destructive operations with user, or nil to skip
+//confirmation if --yes flag is set or standard input is not a terminal, like in scripts
+func confirmationReader() io.Reader {
+	if yes, _ := adCommand.PersistentFlags().GetBool(flagYes); yes || !isTerminal(os.Stdin) {
+		return nil
+	}
+	return os.Stdin
+}
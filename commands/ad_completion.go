@@ -0,0 +1,86 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	entity "opensearch-cli/entity/ad"
+	adgateway "opensearch-cli/gateway/ad"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	//detectorCompletionTimeout limits time spent on querying cluster, so that completion never hangs
+	detectorCompletionTimeout = 2 * time.Second
+	//detectorCompletionSize is maximum number of detectors suggested
+	detectorCompletionSize = 100
+)
+
+//completeDetectors suggests names of existing detectors, or their IDs if --id flag is set.
+//No suggestions are returned if cluster cannot be reached
+func completeDetectors(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c, err := GetClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	profile, err := GetProfile()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	g, err := adgateway.New(c, profile)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	useID, _ := cmd.Flags().GetBool(idFlagName)
+	ctx, cancel := context.WithTimeout(context.Background(), detectorCompletionTimeout)
+	defer cancel()
+	candidates, err := getDetectorCandidates(ctx, g, useID, toComplete)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+//getDetectorCandidates searches all detectors and returns their names, or IDs if useID is true,
+//which start with prefix
+func getDetectorCandidates(ctx context.Context, g adgateway.Gateway, useID bool, prefix string) ([]string, error) {
+	payload := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+		"_source": []string{"name"},
+		"size":    detectorCompletionSize,
+	}
+	response, err := g.SearchDetector(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	var data entity.SearchResponse
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, hit := range data.Hits.Hits {
+		candidate := hit.Source.Name
+		if useID {
+			candidate = hit.ID
+		}
+		if len(candidate) > 0 && strings.HasPrefix(candidate, prefix) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,105 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package commands
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"opensearch-cli/gateway/ad/mocks"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDetectorCandidates(t *testing.T) {
+	response := []byte(`{"hits":{"hits":[
+		{"_id":"id-1","_source":{"name":"orders"}},
+		{"_id":"id-2","_source":{"name":"orders-eu"}},
+		{"_id":"id-3","_source":{"name":"sales"}}
+	]}}`)
+	matchAll := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+		"_source": []string{"name"},
+		"size":    detectorCompletionSize,
+	}
+	t.Run("names", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		g := mocks.NewMockGateway(mockCtrl)
+		g.EXPECT().SearchDetector(gomock.Any(), matchAll).Return(response, nil)
+		candidates, err := getDetectorCandidates(context.Background(), g, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orders", "orders-eu", "sales"}, candidates)
+	})
+	t.Run("names with prefix", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		g := mocks.NewMockGateway(mockCtrl)
+		g.EXPECT().SearchDetector(gomock.Any(), matchAll).Return(response, nil)
+		candidates, err := getDetectorCandidates(context.Background(), g, false, "ord")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orders", "orders-eu"}, candidates)
+	})
+	t.Run("ids", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		g := mocks.NewMockGateway(mockCtrl)
+		g.EXPECT().SearchDetector(gomock.Any(), matchAll).Return(response, nil)
+		candidates, err := getDetectorCandidates(context.Background(), g, true, "id-3")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"id-3"}, candidates)
+	})
+	t.Run("search failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		g := mocks.NewMockGateway(mockCtrl)
+		g.EXPECT().SearchDetector(gomock.Any(), matchAll).Return(nil, errors.New("connection refused"))
+		_, err := getDetectorCandidates(context.Background(), g, false, "")
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestCompleteDetectors(t *testing.T) {
+	t.Run("cluster is unreachable", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "completion")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, os.Remove(f.Name()))
+		}()
+		config := "profiles:\n  - name: default\n    endpoint: http://127.0.0.1:1\n    max_retry: 0\n"
+		assert.NoError(t, ioutil.WriteFile(f.Name(), []byte(config), 0644))
+		start := time.Now()
+		output, err := executeCommand(GetRoot(), "__complete", "--config", f.Name(), adCommandName, getDetectorsCommandName, "")
+		assert.NoError(t, err)
+		assert.Equal(t, ":4\n", output[:3])
+		assert.Less(t, int64(time.Since(start)), int64(detectorCompletionTimeout+time.Second))
+	})
+}
@@ -43,7 +43,8 @@ var createCmd = &cobra.Command{
 	Use:   createDetectorsCommandName + " json-file-path ...",
 	Short: "Create detectors based on JSON files",
 	Long: "Create detectors based on a local JSON file\n" +
-		"To begin, use `opensearch-cli ad create --generate-template` to generate a sample configuration. Save this template locally and update it for your use case. Then use `opensearch-cli ad create file-path` to create detector.",
+		"To begin, use `opensearch-cli ad create --generate-template` to generate a sample configuration. Save this template locally and update it for your use case. Then use `opensearch-cli ad create file-path` to create detector.\n" +
+		"File path can be prefixed with '@', or use '-' to read configuration from standard input.",
 	Run: func(cmd *cobra.Command, args []string) {
 		generate, _ := cmd.Flags().GetBool(generate)
 		if generate {
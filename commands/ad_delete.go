@@ -45,7 +45,8 @@ var deleteDetectorsCmd = &cobra.Command{
 	Long: "Delete detectors based on list of IDs, names, or name regex patterns.\n" +
 		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\nThe default input is detector name. Use the `--id` flag if input is detector ID instead of name",
 
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeDetectors,
 	Run: func(cmd *cobra.Command, args []string) {
 		force, _ := cmd.Flags().GetBool(detectorForceDeletionFlagName)
 		detectorID, _ := cmd.Flags().GetBool(deleteDetectorIDFlagName)
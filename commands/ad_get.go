@@ -48,7 +48,8 @@ var getDetectorsCmd = &cobra.Command{
 	Short: "Get detectors based on a list of IDs, names, or name regex patterns",
 	Long: "Get detectors based on a list of IDs, names, or name regex patterns.\n" +
 		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\nThe default input is detector name. Use the `--id` flag if input is detector ID instead of name",
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeDetectors,
 	Run: func(cmd *cobra.Command, args []string) {
 		err := printDetectors(Println, cmd, args)
 		if err != nil {
@@ -71,11 +72,21 @@ func printDetectors(display Display, cmd *cobra.Command, detectors []string) err
 	if idStatus {
 		action = getDetectorsByID
 	}
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
 	results, err := getDetectors(commandHandler, detectors, action)
 	if err != nil {
 		return err
 	}
-	return fprint(cmd, display, results)
+	if query, _ := cmd.Flags().GetString(queryFlagName); len(query) > 0 {
+		return renderQuery(os.Stdout, format, results, query)
+	}
+	if format == jsonOutputFormat {
+		return fprint(cmd, display, results)
+	}
+	return renderDetectors(os.Stdout, format, results, commandHandler.GetAnomalyDetectorState, getTableStyle(os.Stdout))
 }
 
 //getDetectors fetch detector from controller
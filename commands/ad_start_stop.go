@@ -46,7 +46,8 @@ var startDetectorsCmd = &cobra.Command{
 	Long: "Start detectors based on a list of IDs, names, or name regex patterns.\n" +
 		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
 		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name",
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeDetectors,
 	Run: func(cmd *cobra.Command, args []string) {
 		idStatus, _ := cmd.Flags().GetBool(idFlagName)
 		action := ad.StartAnomalyDetectorByNamePattern
@@ -66,6 +67,7 @@ var stopDetectorsCmd = &cobra.Command{
 	Long: "Stop detectors based on a list of IDs, names, or name regex patterns.\n" +
 		"Wrap regex patterns in quotation marks to prevent the terminal from matching patterns against the files in the current directory.\n" +
 		"The default input is detector name. Use the `--id` flag if input is detector ID instead of name",
+	ValidArgsFunction: completeDetectors,
 	Run: func(cmd *cobra.Command, args []string) {
 		//If no args, display usage
 		if len(args) < 1 {
@@ -0,0 +1,118 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmationReader(t *testing.T) {
+	defer func(terminal func(*os.File) bool) {
+		isTerminal = terminal
+	}(isTerminal)
+	defer func() {
+		_ = adCommand.PersistentFlags().Set(flagYes, "false")
+	}()
+	t.Run("terminal asks for confirmation", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return true }
+		assert.Equal(t, os.Stdin, confirmationReader())
+	})
+	t.Run("yes flag skips confirmation", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return true }
+		assert.NoError(t, adCommand.PersistentFlags().Set(flagYes, "true"))
+		defer func() {
+			_ = adCommand.PersistentFlags().Set(flagYes, "false")
+		}()
+		assert.Nil(t, confirmationReader())
+	})
+	t.Run("yes shorthand is parsed", func(t *testing.T) {
+		assert.NoError(t, adCommand.PersistentFlags().Parse([]string{"-y"}))
+		yes, err := adCommand.PersistentFlags().GetBool(flagYes)
+		assert.NoError(t, err)
+		assert.True(t, yes)
+		assert.NoError(t, adCommand.PersistentFlags().Set(flagYes, "false"))
+	})
+	t.Run("non terminal input skips confirmation", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return false }
+		assert.Nil(t, confirmationReader())
+	})
+}
+
+func TestValidateFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate-file")
+	assert.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+	valid := writeFile("valid.json", `{"name":"d","time_field":"ts","indices":["i"],
+		"feature_attributes":[{"feature_name":"f","aggregation_query":{"f":{"max":{"field":"v"}}}}],
+		"detection_interval":{"period":{"interval":1,"unit":"Minutes"}}}`)
+	invalid := writeFile("invalid.json", `{"name":"d","indices":[]}`)
+	t.Run("valid file", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, validateFiles(&out, strings.NewReader(""), []string{"@" + valid}))
+		assert.Equal(t, "@"+valid+": valid\n", out.String())
+	})
+	t.Run("every problem is reported", func(t *testing.T) {
+		var out bytes.Buffer
+		err := validateFiles(&out, strings.NewReader(""), []string{valid, invalid})
+		assert.EqualError(t, err, "1 of 2 file(s) are invalid")
+		assert.Equal(t, valid+": valid\n"+
+			invalid+": 4 problem(s) found\n"+
+			"  - time_field is required\n"+
+			"  - indices must have at least one element\n"+
+			"  - feature_attributes is required\n"+
+			"  - detection_interval is required\n", out.String())
+	})
+	t.Run("standard input", func(t *testing.T) {
+		var out bytes.Buffer
+		err := validateFiles(&out, strings.NewReader(`{"name":`), []string{"-"})
+		assert.EqualError(t, err, "1 of 1 file(s) are invalid")
+		assert.Contains(t, out.String(), "-: 1 problem(s) found\n  - stdin cannot be accepted")
+	})
+	t.Run("missing file", func(t *testing.T) {
+		var out bytes.Buffer
+		err := validateFiles(&out, strings.NewReader(""), []string{filepath.Join(dir, "missing.json")})
+		assert.EqualError(t, err, "1 of 1 file(s) are invalid")
+		assert.Contains(t, out.String(), "failed to open file")
+	})
+	t.Run("command does not need profile", func(t *testing.T) {
+		output, err := executeCommand(GetRoot(), adCommandName, validateFileCommandName, valid)
+		assert.NoError(t, err)
+		assert.Equal(t, valid+": valid\n", output)
+	})
+}
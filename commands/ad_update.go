@@ -43,7 +43,8 @@ var updateDetectorsCmd = &cobra.Command{
 	Short: "Update detectors based on JSON files",
 	Long: "Update detectors based on JSON files.\n" +
 		"To begin, use `opensearch-cli ad get detector-name > detector_to_be_updated.json` to download the detector. " +
-		"Modify the file, and then use `opensearch-cli ad update file-path` to update the detector.",
+		"Modify the file, and then use `opensearch-cli ad update file-path` to update the detector.\n" +
+		"File path can be prefixed with '@', or use '-' to read configuration from standard input.",
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		force, _ := cmd.Flags().GetBool(forceFlagName)
@@ -0,0 +1,72 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	handler "opensearch-cli/handler/ad"
+	admapper "opensearch-cli/mapper/ad"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const validateFileCommandName = "validate-file"
+
+//validateFileCmd checks detector configuration files locally, without connecting to cluster
+var validateFileCmd = &cobra.Command{
+	Use:   validateFileCommandName + " json-file-path ...",
+	Short: "Validate detector configuration files without connecting to cluster",
+	Long: "Validate detector configuration files in Anomaly Detection API format without connecting to cluster.\n" +
+		"Required fields name, time_field, indices, feature_attributes and detection_interval are checked " +
+		"along with their types, and every problem found is reported.\n" +
+		"File path can be prefixed with '@', or use '-' to read configuration from standard input.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := validateFiles(cmd.OutOrStdout(), os.Stdin, args)
+		DisplayError(err, validateFileCommandName)
+	},
+}
+
+func init() {
+	GetADCommand().AddCommand(validateFileCmd)
+	validateFileCmd.Flags().BoolP("help", "h", false, "Help for "+validateFileCommandName)
+}
+
+//validateFiles prints problems found in every file, and returns error if any file is invalid
+func validateFiles(out io.Writer, stdin io.Reader, fileNames []string) error {
+	var invalid int
+	for _, name := range fileNames {
+		var document json.RawMessage
+		var problems []error
+		if err := handler.ReadPayload(name, stdin, &document); err != nil {
+			problems = []error{err}
+		} else {
+			problems = admapper.ValidateDetectorDocument(document)
+		}
+		if len(problems) == 0 {
+			_, _ = fmt.Fprintf(out, "%s: valid\n", name)
+			continue
+		}
+		invalid++
+		_, _ = fmt.Fprintf(out, "%s: %d problem(s) found\n", name, len(problems))
+		for _, problem := range problems {
+			_, _ = fmt.Fprintf(out, "  - %v\n", problem)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d file(s) are invalid", invalid, len(fileNames))
+	}
+	return nil
+}
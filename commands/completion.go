@@ -26,7 +26,6 @@ package commands
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -88,15 +87,16 @@ var completionCmd = &cobra.Command{
 	Args:                  cobra.ExactValidArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
+		out := cmd.OutOrStdout()
 		switch args[0] {
 		case BashShell:
-			err = cmd.Root().GenBashCompletion(os.Stdout)
+			err = cmd.Root().GenBashCompletion(out)
 		case ZshShell:
-			err = cmd.Root().GenZshCompletion(os.Stdout)
+			err = cmd.Root().GenZshCompletion(out)
 		case FishShell:
-			err = cmd.Root().GenFishCompletion(os.Stdout, true)
+			err = cmd.Root().GenFishCompletion(out, true)
 		case PowerShell:
-			err = cmd.Root().GenPowerShellCompletion(os.Stdout)
+			err = cmd.Root().GenPowerShellCompletion(out)
 		}
 		DisplayError(err, CompletionCommandName)
 	},
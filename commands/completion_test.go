@@ -0,0 +1,70 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	generate := func(t *testing.T, shell string) string {
+		var buffer bytes.Buffer
+		root := GetRoot()
+		root.SetOut(&buffer)
+		defer root.SetOut(nil)
+		root.SetArgs([]string{CompletionCommandName, shell})
+		_, err := root.ExecuteC()
+		assert.NoError(t, err)
+		return buffer.String()
+	}
+	t.Run("bash", func(t *testing.T) {
+		output := generate(t, BashShell)
+		assert.Contains(t, output, "# bash completion for opensearch-cli")
+		assert.Contains(t, output, "_opensearch-cli_profile()")
+		assert.Contains(t, output, "--profile")
+	})
+	t.Run("zsh", func(t *testing.T) {
+		output := generate(t, ZshShell)
+		assert.Contains(t, output, "#compdef _opensearch-cli opensearch-cli")
+	})
+	t.Run("fish", func(t *testing.T) {
+		output := generate(t, FishShell)
+		assert.Contains(t, output, "# fish completion for opensearch-cli")
+		assert.Contains(t, output, "complete -c opensearch-cli")
+	})
+	t.Run("powershell", func(t *testing.T) {
+		output := generate(t, PowerShell)
+		assert.Contains(t, output, "Register-ArgumentCompleter")
+	})
+	t.Run("unsupported shell", func(t *testing.T) {
+		root := GetRoot()
+		root.SetArgs([]string{CompletionCommandName, "tcsh"})
+		_, err := root.ExecuteC()
+		assert.Error(t, err)
+	})
+}
@@ -27,11 +27,11 @@ package commands
 
 import (
 	"fmt"
-	"opensearch-cli/client"
 	ctrl "opensearch-cli/controller/platform"
 	entity "opensearch-cli/entity/platform"
 	gateway "opensearch-cli/gateway/platform"
 	handler "opensearch-cli/handler/platform"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -61,6 +61,8 @@ func init() {
 		"Output format if supported by cluster, else, default format by OpenSearch. Example json, yaml")
 	curlCommand.PersistentFlags().StringP(curlOutputFilterPathFlagName, "f", "",
 		"Filter output fields returned by OpenSearch. Use comma ',' to separate list of filters")
+	curlCommand.PersistentFlags().String(queryFlagName, "",
+		"Print only fields selected by dotted path from json response, for example: hits.hits[]._id")
 	GetRoot().AddCommand(curlCommand)
 }
 
@@ -72,7 +74,7 @@ func GetCurlCommand() *cobra.Command {
 
 //getCurlHandler returns handler by wiring the dependency manually
 func getCurlHandler() (*handler.Handler, error) {
-	c, err := client.New(nil)
+	c, err := GetClient()
 	if err != nil {
 		return nil, err
 	}
@@ -97,11 +99,14 @@ func CurlActionExecute(input entity.CurlCommandRequest) error {
 	}
 	response, err := handler.Curl(commandHandler, input)
 	if err == nil {
-		fmt.Println(string(response))
-		return nil
+		if query := GetUserInputAsStringForFlag(queryFlagName); len(query) > 0 {
+			return printSelected(os.Stdout, jsonOutputFormat, response, query)
+		}
+		return printJSON(os.Stdout, response)
 	}
 	if requestError, ok := err.(*entity.RequestError); ok {
 		fmt.Println(requestError.GetResponse())
+		recordError(err)
 		return nil
 	}
 	return err
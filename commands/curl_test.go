@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//captureStdout returns what fn printed to standard output
+func captureStdout(t *testing.T, fn func()) string {
+	reader, writer, err := os.Pipe()
+	assert.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() {
+		os.Stdout = stdout
+	}()
+	fn()
+	assert.NoError(t, writer.Close())
+	output, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	return string(output)
+}
+
+func TestCurlGet(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/_cluster/health", r.URL.Path)
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"cluster_name":"opensearch-cluster","status":"green"}`))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "curl")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}()
+	config := filepath.Join(dir, "config.yaml")
+	contents := fmt.Sprintf("profiles:\n  - name: test\n    endpoint: %s\n    user: admin\n    password: admin\n", server.URL)
+	assert.NoError(t, ioutil.WriteFile(config, []byte(contents), 0600))
+	defer func() {
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagConfig, ""))
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagProfileName, ""))
+		assert.NoError(t, curlCommand.PersistentFlags().Set(queryFlagName, ""))
+		assert.NoError(t, curlGetCmd.Flags().Set(curlQueryParamsFlagName, ""))
+	}()
+
+	output := captureStdout(t, func() {
+		_, err = executeCommand(GetRoot(), curlCommandName, curlGetCommandName, "--config", config, "--profile", "test",
+			"--path", "_cluster/health", "-q", "level=indices", "--"+queryFlagName, "status")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "level=indices", query)
+	assert.Equal(t, "green\n", output)
+}
@@ -0,0 +1,95 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"opensearch-cli/entity/platform"
+	gw "opensearch-cli/gateway"
+)
+
+//Exit codes of opensearch-cli, so that scripts can distinguish why command failed
+const (
+	ExitCodeSuccess    = 0
+	ExitCodeFailure    = 1
+	ExitCodeUsage      = 2
+	ExitCodeConnection = 3
+	ExitCodeAuth       = 4
+	ExitCodeNotFound   = 5
+	ExitCodeServer     = 6
+)
+
+//UsageError is returned by Execute if command was invoked with invalid arguments or flags
+type UsageError struct {
+	Err error
+}
+
+func (u *UsageError) Error() string {
+	return u.Err.Error()
+}
+
+func (u *UsageError) Unwrap() error {
+	return u.Err
+}
+
+//commandError is the first error displayed while executing command, it decides exit code
+var commandError error
+
+//recordError remembers error which failed command, since commands display errors instead of returning them
+func recordError(err error) {
+	if err != nil && commandError == nil {
+		commandError = err
+	}
+}
+
+//ExitCode maps error returned by Execute to exit code of the process
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitCodeUsage
+	}
+	if statusCode, ok := getStatusCode(err); ok {
+		switch {
+		case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+			return ExitCodeAuth
+		case statusCode == http.StatusNotFound:
+			return ExitCodeNotFound
+		case statusCode >= http.StatusInternalServerError:
+			return ExitCodeServer
+		}
+		return ExitCodeFailure
+	}
+	var netErr net.Error
+	if errors.Is(err, gw.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return ExitCodeConnection
+	}
+	return ExitCodeFailure
+}
+
+//getStatusCode returns status code of response if request failed because of cluster's response
+func getStatusCode(err error) (int, bool) {
+	var responseErr *gw.ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.StatusCode, true
+	}
+	var requestErr *platform.RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.StatusCode(), true
+	}
+	return 0, false
+}
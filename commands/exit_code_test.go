@@ -0,0 +1,72 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"opensearch-cli/entity/platform"
+	gw "opensearch-cli/gateway"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	responseError := func(statusCode int) error {
+		return fmt.Errorf("failed to connect: %w", &gw.ResponseError{StatusCode: statusCode, Body: []byte("{}")})
+	}
+	requestError := func(statusCode int) error {
+		return platform.NewRequestError(statusCode, ioutil.NopCloser(strings.NewReader("{}")), errors.New("failed"))
+	}
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "success", err: nil, expected: ExitCodeSuccess},
+		{name: "generic failure", err: errors.New("detector name cannot be empty"), expected: ExitCodeFailure},
+		{name: "usage", err: &UsageError{Err: errors.New(`unknown flag: --foo`)}, expected: ExitCodeUsage},
+		{name: "timeout", err: fmt.Errorf("%w: no response", gw.ErrTimeout), expected: ExitCodeConnection},
+		{name: "deadline", err: context.DeadlineExceeded, expected: ExitCodeConnection},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, expected: ExitCodeConnection},
+		{name: "unauthorized", err: responseError(http.StatusUnauthorized), expected: ExitCodeAuth},
+		{name: "forbidden", err: requestError(http.StatusForbidden), expected: ExitCodeAuth},
+		{name: "not found", err: responseError(http.StatusNotFound), expected: ExitCodeNotFound},
+		{name: "reason of failure", err: &gw.ReasonError{Reason: "no such index", Err: requestError(http.StatusNotFound)}, expected: ExitCodeNotFound},
+		{name: "server error", err: requestError(http.StatusServiceUnavailable), expected: ExitCodeServer},
+		{name: "bad request", err: responseError(http.StatusBadRequest), expected: ExitCodeFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExitCode(tt.err))
+		})
+	}
+}
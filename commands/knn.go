@@ -27,10 +27,11 @@ package commands
 
 import (
 	"fmt"
-	"opensearch-cli/client"
 	ctrl "opensearch-cli/controller/knn"
 	gateway "opensearch-cli/gateway/knn"
 	handler "opensearch-cli/handler/knn"
+	"opensearch-cli/logger"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -127,8 +128,7 @@ func getStatistics(h *handler.Handler, nodes string, names string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(stats))
-	return nil
+	return printJSON(os.Stdout, stats)
 }
 
 func warmupIndices(h *handler.Handler, index []string) error {
@@ -139,13 +139,13 @@ func warmupIndices(h *handler.Handler, index []string) error {
 	if shards.Failed > 0 {
 		return fmt.Errorf("%d/%d shards were failed to load into memory", shards.Failed, shards.Total)
 	}
-	fmt.Printf("successfully loaded %d shards into memory\n", shards.Total)
+	logger.Infof("successfully loaded %d shards into memory", shards.Total)
 	return nil
 }
 
 //GetKNNHandler returns handler by wiring the dependency manually
 func GetKNNHandler() (*handler.Handler, error) {
-	c, err := client.New(nil)
+	c, err := GetClient()
 	if err != nil {
 		return nil, err
 	}
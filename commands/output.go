@@ -0,0 +1,304 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/mapper"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+const (
+	outputFlagName     = "output"
+	queryFlagName      = "query"
+	filterFlagName     = "filter"
+	jsonOutputFormat   = "json"
+	yamlOutputFormat   = "yaml"
+	tableOutputFormat  = "table"
+	unknownStateOutput = "UNKNOWN"
+	columnPadding      = 3
+	minColumnWidth     = 8
+	ellipsis           = "…"
+	colorReset         = "\x1b[0m"
+	colorRed           = "\x1b[31m"
+	colorGreen         = "\x1b[32m"
+)
+
+//stateColors maps detector state to color of state cell in table
+var stateColors = map[string]string{
+	"RUNNING": colorGreen,
+	"FAILED":  colorRed,
+}
+
+//tableStyle controls how table is rendered
+type tableStyle struct {
+	//Color enables colored state cells
+	Color bool
+	//Width is maximum width of table, long names and ids are truncated to fit it. Zero means unlimited
+	Width int
+}
+
+//isTerminal returns true if file is terminal
+var isTerminal = func(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
+
+//getTableStyle returns table style for writer, colors and width are used only if writer is terminal
+func getTableStyle(writer io.Writer) tableStyle {
+	file, ok := writer.(*os.File)
+	if !ok || !isTerminal(file) {
+		return tableStyle{}
+	}
+	noColor, _ := rootCommand.PersistentFlags().GetBool(flagNoColor)
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil {
+		width = 0
+	}
+	return tableStyle{Color: !noColor, Width: width}
+}
+
+var outputFormats = []string{jsonOutputFormat, yamlOutputFormat, tableOutputFormat}
+
+//DetectorState returns current state of detector for given detector id
+type DetectorState func(string) (string, error)
+
+//getOutputFormat returns output format from --output flag, default is json
+func getOutputFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString(outputFlagName)
+	if err != nil {
+		return "", err
+	}
+	if len(format) == 0 {
+		return jsonOutputFormat, nil
+	}
+	format = strings.ToLower(format)
+	for _, f := range outputFormats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format: %s, supported formats are: %s", format, strings.Join(outputFormats, ", "))
+}
+
+//printJSON prints response on writer, it is indented if it is valid json
+func printJSON(writer io.Writer, response []byte) error {
+	formattedOutput, err := mapper.PrettyJSON(response, "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(writer, string(formattedOutput))
+	return err
+}
+
+//normalizeQueryFlag accepts --filter as an alias of --query
+func normalizeQueryFlag(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == filterFlagName {
+		name = queryFlagName
+	}
+	return pflag.NormalizedName(name)
+}
+
+//renderDetectors prints detectors on writer based on output format
+func renderDetectors(writer io.Writer, format string, detectors []*entity.DetectorOutput, state DetectorState, style tableStyle) error {
+	switch format {
+	case yamlOutputFormat:
+		return renderYAML(writer, detectors)
+	case tableOutputFormat:
+		return renderTable(writer, detectors, state, style)
+	default:
+		return renderJSON(writer, detectors)
+	}
+}
+
+//renderJSON prints every detector as indented json
+func renderJSON(writer io.Writer, detectors []*entity.DetectorOutput) error {
+	for _, d := range detectors {
+		if err := FPrint(writer, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//renderYAML prints every detector as yaml document
+func renderYAML(writer io.Writer, detectors []*entity.DetectorOutput) error {
+	for i, d := range detectors {
+		data, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		formattedOutput, err := mapper.JSONToYAML(data)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = fmt.Fprintln(writer, "---"); err != nil {
+				return err
+			}
+		}
+		if _, err = writer.Write(formattedOutput); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//renderTable prints name, id, state and last update time of detectors in columns
+func renderTable(writer io.Writer, detectors []*entity.DetectorOutput, state DetectorState, style tableStyle) error {
+	rows := [][]string{{"NAME", "ID", "STATE", "LAST UPDATED"}}
+	for _, d := range detectors {
+		detectorState := unknownStateOutput
+		if state != nil {
+			s, err := state(d.ID)
+			if err != nil {
+				return err
+			}
+			if len(s) > 0 {
+				detectorState = s
+			}
+		}
+		rows = append(rows, []string{d.Name, d.ID, detectorState, formatLastUpdated(d.LastUpdatedAt)})
+	}
+	widths := fitColumns(columnWidths(rows), style.Width, 0, 1)
+	for i, row := range rows {
+		var line strings.Builder
+		for column, cell := range row {
+			cell = truncate(cell, widths[column])
+			padding := widths[column] - utf8.RuneCountInString(cell) + columnPadding
+			if color, ok := stateColors[cell]; ok && style.Color && column == 2 && i > 0 {
+				cell = color + cell + colorReset
+			}
+			line.WriteString(cell)
+			if column < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", padding))
+			}
+		}
+		if _, err := fmt.Fprintln(writer, line.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//columnWidths returns width of widest cell in every column
+func columnWidths(rows [][]string) []int {
+	var widths []int
+	for _, row := range rows {
+		for column, cell := range row {
+			if column == len(widths) {
+				widths = append(widths, 0)
+			}
+			if length := utf8.RuneCountInString(cell); length > widths[column] {
+				widths[column] = length
+			}
+		}
+	}
+	return widths
+}
+
+//fitColumns shrinks widest of given truncatable columns until table fits in maxWidth,
+//columns are not shrunk below minColumnWidth. Widths are not changed if maxWidth is zero
+func fitColumns(widths []int, maxWidth int, truncatable ...int) []int {
+	if maxWidth <= 0 {
+		return widths
+	}
+	total := columnPadding * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+	for ; total > maxWidth; total-- {
+		widest := -1
+		for _, column := range truncatable {
+			if widths[column] > minColumnWidth && (widest < 0 || widths[column] > widths[widest]) {
+				widest = column
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+//truncate shortens value to width runes, ending with ellipsis if it was truncated
+func truncate(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	return string(runes[:width-1]) + ellipsis
+}
+
+//formatLastUpdated converts epoch milliseconds to RFC3339 time in UTC
+func formatLastUpdated(millis uint64) string {
+	return time.Unix(0, int64(millis)*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+//renderQuery prints values selected by query from every detector
+func renderQuery(writer io.Writer, format string, detectors []*entity.DetectorOutput, query string) error {
+	if format == tableOutputFormat {
+		return fmt.Errorf("--%s cannot be used with %s output format", queryFlagName, tableOutputFormat)
+	}
+	for _, d := range detectors {
+		data, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		if err = printSelected(writer, format, data, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//printSelected prints every value selected by query from data, one per line.
+//Strings are printed without quotes, other values are printed as json or yaml based on format
+func printSelected(writer io.Writer, format string, data []byte, query string) error {
+	values, err := mapper.SelectJSON(data, query)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if text, ok := value.(string); ok {
+			if _, err = fmt.Fprintln(writer, text); err != nil {
+				return err
+			}
+			continue
+		}
+		formattedOutput, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		if format == yamlOutputFormat {
+			if formattedOutput, err = mapper.JSONToYAML(formattedOutput); err != nil {
+				return err
+			}
+			formattedOutput = bytes.TrimSuffix(formattedOutput, []byte("\n"))
+		}
+		if _, err = fmt.Fprintln(writer, string(formattedOutput)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	entity "opensearch-cli/entity/ad"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func getSampleDetectorOutput() *entity.DetectorOutput {
+	return &entity.DetectorOutput{
+		ID:          "detectorID",
+		Name:        "detector",
+		Description: "Test detector",
+		TimeField:   "timestamp",
+		Index:       []string{"order*"},
+		Features: []entity.Feature{
+			{
+				Name:             "total_order",
+				Enabled:          true,
+				AggregationQuery: []byte(`{"total_order":{"sum":{"field":"value"}}}`),
+			},
+		},
+		Filter:        []byte(`{"match_all":{}}`),
+		Interval:      "5m",
+		Delay:         "1m",
+		LastUpdatedAt: 1589441737319,
+		SchemaVersion: 0,
+	}
+}
+
+func TestGetOutputFormat(t *testing.T) {
+	newCommand := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringP(outputFlagName, "o", jsonOutputFormat, "")
+		return cmd
+	}
+	t.Run("default format", func(t *testing.T) {
+		format, err := getOutputFormat(newCommand())
+		assert.NoError(t, err)
+		assert.Equal(t, jsonOutputFormat, format)
+	})
+	t.Run("valid format", func(t *testing.T) {
+		cmd := newCommand()
+		assert.NoError(t, cmd.Flags().Set(outputFlagName, "YAML"))
+		format, err := getOutputFormat(cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, yamlOutputFormat, format)
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		cmd := newCommand()
+		assert.NoError(t, cmd.Flags().Set(outputFlagName, "xml"))
+		_, err := getOutputFormat(cmd)
+		assert.EqualError(t, err, "invalid output format: xml, supported formats are: json, yaml, table")
+	})
+}
+
+func TestRenderDetectors(t *testing.T) {
+	detectors := []*entity.DetectorOutput{getSampleDetectorOutput()}
+	t.Run("json", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, renderDetectors(&buffer, jsonOutputFormat, detectors, nil, tableStyle{}))
+		assert.Equal(t, `{
+  "ID": "detectorID",
+  "name": "detector",
+  "description": "Test detector",
+  "time_field": "timestamp",
+  "indices": [
+    "order*"
+  ],
+  "features": [
+    {
+      "feature_name": "total_order",
+      "feature_enabled": true,
+      "aggregation_query": {
+        "total_order": {
+          "sum": {
+            "field": "value"
+          }
+        }
+      }
+    }
+  ],
+  "filter_query": {
+    "match_all": {}
+  },
+  "detection_interval": "5m",
+  "window_delay": "1m",
+  "last_update_time": 1589441737319,
+  "schema_version": 0
+}
+`, buffer.String())
+	})
+	t.Run("yaml", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, renderDetectors(&buffer, yamlOutputFormat, append(detectors, detectors...), nil, tableStyle{}))
+		document := `ID: detectorID
+name: detector
+description: Test detector
+time_field: timestamp
+indices:
+  - order*
+features:
+  - feature_name: total_order
+    feature_enabled: true
+    aggregation_query:
+      total_order:
+        sum:
+          field: value
+filter_query:
+  match_all: {}
+detection_interval: 5m
+window_delay: 1m
+last_update_time: 1589441737319
+schema_version: 0
+`
+		assert.Equal(t, document+"---\n"+document, buffer.String())
+	})
+	t.Run("table", func(t *testing.T) {
+		var buffer bytes.Buffer
+		state := func(ID string) (string, error) {
+			assert.Equal(t, "detectorID", ID)
+			return "RUNNING", nil
+		}
+		assert.NoError(t, renderDetectors(&buffer, tableOutputFormat, detectors, state, tableStyle{}))
+		assert.Equal(t, "NAME       ID           STATE     LAST UPDATED\n"+
+			"detector   detectorID   RUNNING   2020-05-14T07:35:37Z\n", buffer.String())
+	})
+	t.Run("table with colors", func(t *testing.T) {
+		var buffer bytes.Buffer
+		state := func(string) (string, error) {
+			return "FAILED", nil
+		}
+		assert.NoError(t, renderDetectors(&buffer, tableOutputFormat, detectors, state, tableStyle{Color: true}))
+		assert.Equal(t, "NAME       ID           STATE    LAST UPDATED\n"+
+			"detector   detectorID   "+colorRed+"FAILED"+colorReset+"   2020-05-14T07:35:37Z\n", buffer.String())
+	})
+	t.Run("table without colors", func(t *testing.T) {
+		var buffer bytes.Buffer
+		state := func(string) (string, error) {
+			return "RUNNING", nil
+		}
+		assert.NoError(t, renderDetectors(&buffer, tableOutputFormat, detectors, state, tableStyle{}))
+		assert.NotContains(t, buffer.String(), "\x1b[")
+	})
+	t.Run("table truncated to width", func(t *testing.T) {
+		var buffer bytes.Buffer
+		detector := getSampleDetectorOutput()
+		detector.Name = "detector-with-very-long-name"
+		detector.ID = "very-long-detector-id"
+		state := func(string) (string, error) {
+			return "RUNNING", nil
+		}
+		assert.NoError(t, renderDetectors(&buffer, tableOutputFormat, []*entity.DetectorOutput{detector}, state, tableStyle{Width: 60}))
+		assert.Equal(t, "NAME           ID             STATE     LAST UPDATED\n"+
+			"detector-wi…   very-long-d…   RUNNING   2020-05-14T07:35:37Z\n", buffer.String())
+	})
+	t.Run("table without state", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, renderDetectors(&buffer, tableOutputFormat, detectors, nil, tableStyle{}))
+		assert.Contains(t, buffer.String(), "UNKNOWN")
+	})
+	t.Run("table state failed", func(t *testing.T) {
+		var buffer bytes.Buffer
+		state := func(string) (string, error) {
+			return "", errors.New("no connection")
+		}
+		assert.EqualError(t, renderDetectors(&buffer, tableOutputFormat, detectors, state, tableStyle{}), "no connection")
+	})
+}
+
+func TestQueryFlagAlias(t *testing.T) {
+	var query string
+	parent := &cobra.Command{Use: "parent"}
+	parent.PersistentFlags().StringP(queryFlagName, "q", "", "")
+	parent.SetGlobalNormalizationFunc(normalizeQueryFlag)
+	child := &cobra.Command{
+		Use: "child",
+		Run: func(cmd *cobra.Command, args []string) {
+			query, _ = cmd.Flags().GetString(queryFlagName)
+		},
+	}
+	parent.AddCommand(child)
+	_, err := executeCommand(parent, "child", "--filter", "features[].feature_name")
+	assert.NoError(t, err)
+	assert.Equal(t, "features[].feature_name", query)
+}
+
+func TestRenderQuery(t *testing.T) {
+	detectors := []*entity.DetectorOutput{getSampleDetectorOutput(), getSampleDetectorOutput()}
+	t.Run("select string field", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, renderQuery(&buffer, jsonOutputFormat, detectors, "ID"))
+		assert.Equal(t, "detectorID\ndetectorID\n", buffer.String())
+	})
+	t.Run("select nested array field", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, renderQuery(&buffer, jsonOutputFormat, detectors[:1], "features[].aggregation_query.total_order"))
+		assert.Equal(t, "{\n  \"sum\": {\n    \"field\": \"value\"\n  }\n}\n", buffer.String())
+	})
+	t.Run("select as yaml", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, renderQuery(&buffer, yamlOutputFormat, detectors[:1], "features[].aggregation_query"))
+		assert.Equal(t, "total_order:\n  sum:\n    field: value\n", buffer.String())
+	})
+	t.Run("table is not supported", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.Error(t, renderQuery(&buffer, tableOutputFormat, detectors, "ID"))
+	})
+}
+
+func TestPrintJSON(t *testing.T) {
+	t.Run("json is indented", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, printJSON(&buffer, []byte(`{"nodes":{"total":1}}`)))
+		assert.Equal(t, "{\n  \"nodes\": {\n    \"total\": 1\n  }\n}\n", buffer.String())
+	})
+	t.Run("other formats are printed as is", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, printJSON(&buffer, []byte("nodes:\n  total: 1")))
+		assert.Equal(t, "nodes:\n  total: 1\n", buffer.String())
+	})
+}
+
+func TestGetTableStyle(t *testing.T) {
+	defer func(terminal func(*os.File) bool) {
+		isTerminal = terminal
+	}(isTerminal)
+	t.Run("not a file", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return true }
+		assert.Equal(t, tableStyle{}, getTableStyle(&bytes.Buffer{}))
+	})
+	t.Run("not a terminal", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return false }
+		assert.False(t, getTableStyle(os.Stdout).Color)
+	})
+	t.Run("terminal", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return true }
+		assert.True(t, getTableStyle(os.Stdout).Color)
+	})
+	t.Run("no color flag", func(t *testing.T) {
+		isTerminal = func(*os.File) bool { return true }
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagNoColor, "true"))
+		defer func() {
+			_ = GetRoot().PersistentFlags().Set(flagNoColor, "false")
+		}()
+		assert.False(t, getTableStyle(os.Stdout).Color)
+	})
+}
@@ -26,15 +26,19 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"opensearch-cli/environment"
+	"opensearch-cli/logger"
 
 	"golang.org/x/term"
 
 	"opensearch-cli/controller/config"
 	"opensearch-cli/controller/profile"
 	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"opensearch-cli/secret"
 	"os"
 	"strings"
 	"text/tabwriter"
@@ -43,19 +47,24 @@ import (
 )
 
 const (
-	CreateNewProfileCommandName = "create"
-	DeleteProfilesCommandName   = "delete"
-	FlagProfileVerbose          = "verbose"
-	ListProfilesCommandName     = "list"
-	ProfileCommandName          = "profile"
-	padding                     = 3
-	alignLeft                   = 0
-	FlagProfileCreateName       = "name"
-	FlagProfileCreateEndpoint   = "endpoint"
-	FlagProfileCreateAuthType   = "auth-type"
-	FlagProfileMaxRetry         = "max-retry"
-	FlagProfileTimeout          = "timeout"
-	FlagProfileHelp             = "help"
+	CreateNewProfileCommandName  = "create"
+	CloneProfileCommandName      = "clone"
+	SetDefaultProfileCommandName = "set-default"
+	DeleteProfilesCommandName    = "delete"
+	FlagProfileVerbose           = "verbose"
+	ListProfilesCommandName      = "list"
+	TestProfileCommandName       = "test"
+	ProfileCommandName           = "profile"
+	padding                      = 3
+	alignLeft                    = 0
+	FlagProfileCreateName        = "name"
+	FlagProfileCreateEndpoint    = "endpoint"
+	FlagProfileCreateAuthType    = "auth-type"
+	FlagProfileMaxRetry          = "max-retry"
+	FlagProfileTimeout           = "timeout"
+	FlagProfileInsecure          = "insecure"
+	FlagProfileEncryptPassword   = "encrypt-password"
+	FlagProfileHelp              = "help"
 )
 
 //GetProfileController gets controller based on config file
@@ -75,7 +84,8 @@ var profileCommand = &cobra.Command{
 		"When you specify a profile for a command (e.g. `opensearch-cli <command> --profile <profile_name>`), opensearch-cli uses " +
 		"the profile's settings and credentials to run the given command.\n" +
 		"To configure a default profile for commands, either specify the default profile name in an environment " +
-		"variable (`" + environment.OPENSEARCH_PROFILE + "`) or create a profile named `default`.",
+		"variable (`" + environment.OPENSEARCH_PROFILE + "`), set it using `opensearch-cli profile " + SetDefaultProfileCommandName +
+		" <profile_name>` or create a profile named `default`.",
 }
 
 //createProfileCmd creates profile interactively by prompting for name (distinct), user, endpoint, password.
@@ -97,11 +107,13 @@ var createProfileCmd = &cobra.Command{
 		endpoint, _ := cmd.Flags().GetString(FlagProfileCreateEndpoint)
 		maxAttempt, _ := cmd.Flags().GetInt(FlagProfileMaxRetry)
 		timeout, _ := cmd.Flags().GetInt64(FlagProfileTimeout)
+		insecure, _ := cmd.Flags().GetBool(FlagProfileInsecure)
 		newProfile := entity.Profile{
 			Name:     name,
 			Endpoint: endpoint,
 			MaxRetry: &maxAttempt,
 			Timeout:  &timeout,
+			Insecure: insecure,
 		}
 		switch authType, _ := cmd.Flags().GetString(FlagProfileCreateAuthType); authType {
 		case "disabled":
@@ -112,16 +124,26 @@ var createProfileCmd = &cobra.Command{
 			getAWSIAMAuthDetails(&newProfile)
 		case "cert":
 			getCertificateAuthDetails(&newProfile)
+		case "token":
+			getTokenAuthDetails(&newProfile)
+		case "api-key":
+			getAPIKeyAuthDetails(&newProfile)
 		default:
 			DisplayError(errors.New("invalid value for auth-type. Use --help -h command to see permitted values"), CreateNewProfileCommandName)
 			return
 		}
+		if encrypt, _ := cmd.Flags().GetBool(FlagProfileEncryptPassword); encrypt {
+			if err = encryptPassword(&newProfile); err != nil {
+				DisplayError(err, CreateNewProfileCommandName)
+				return
+			}
+		}
 		err = CreateProfile(profileController, newProfile)
 		if err != nil {
 			DisplayError(err, CreateNewProfileCommandName)
 			return
 		}
-		fmt.Println("Profile created successfully.")
+		logger.Infof("Profile created successfully.")
 	},
 }
 
@@ -144,7 +166,48 @@ var deleteProfilesCmd = &cobra.Command{
 			DisplayError(err, DeleteProfilesCommandName)
 			return
 		}
-		fmt.Println("Profile deleted successfully.")
+		logger.Infof("Profile deleted successfully.")
+	},
+}
+
+//cloneProfileCmd copies existing profile into new profile
+var cloneProfileCmd = &cobra.Command{
+	Use:   CloneProfileCommandName + " source_profile_name new_profile_name",
+	Short: "Clone profile",
+	Long:  "Create new profile with the same settings and credentials as an existing profile.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileController, err := GetProfileController()
+		if err != nil {
+			DisplayError(err, CloneProfileCommandName)
+			return
+		}
+		if err = profileController.CloneProfile(args[0], args[1]); err != nil {
+			DisplayError(err, CloneProfileCommandName)
+			return
+		}
+		logger.Infof("Profile cloned successfully.")
+	},
+}
+
+//setDefaultProfileCmd sets profile which is used if profile is neither provided by flag nor by environment variable
+var setDefaultProfileCmd = &cobra.Command{
+	Use:   SetDefaultProfileCommandName + " profile_name",
+	Short: "Set default profile",
+	Long: "Set profile which is used when --profile flag is not provided and " + environment.OPENSEARCH_PROFILE +
+		" environment variable is not set.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profileController, err := GetProfileController()
+		if err != nil {
+			DisplayError(err, SetDefaultProfileCommandName)
+			return
+		}
+		if err = profileController.SetDefaultProfile(args[0]); err != nil {
+			DisplayError(err, SetDefaultProfileCommandName)
+			return
+		}
+		logger.Infof("Default profile set successfully.")
 	},
 }
 
@@ -161,6 +224,53 @@ var listProfileCmd = &cobra.Command{
 	},
 }
 
+//testProfileCmd checks whether cluster can be reached using profile
+var testProfileCmd = &cobra.Command{
+	Use:   TestProfileCommandName + " [profile_name]",
+	Short: "Test connection to the cluster using a profile",
+	Long: "Test whether the cluster can be reached with the endpoint, credentials and certificates of the profile.\n" +
+		"If profile name is not provided, the profile that would be used by other commands is tested.",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := testProfile(args)
+		if err != nil {
+			DisplayError(err, TestProfileCommandName)
+			return
+		}
+		logger.Infof("Successfully connected to %s using profile %s.", p.Endpoint, p.Name)
+	},
+}
+
+//testProfile pings cluster using profile by name, or profile for execution if name is not provided
+func testProfile(args []string) (*entity.Profile, error) {
+	var p *entity.Profile
+	if len(args) > 0 {
+		profileController, err := GetProfileController()
+		if err != nil {
+			return nil, err
+		}
+		value, _, err := profileController.GetProfileForExecution(args[0])
+		if err != nil {
+			return nil, err
+		}
+		p = &value
+	} else {
+		var err error
+		if p, err = GetProfile(); err != nil {
+			return nil, err
+		}
+	}
+	c, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, g.Ping(context.Background())
+}
+
 //deleteProfiles deletes profiles based on names
 func deleteProfiles(profiles []string) error {
 	profileController, err := GetProfileController()
@@ -174,7 +284,10 @@ func deleteProfiles(profiles []string) error {
 func init() {
 	profileCommand.AddCommand(createProfileCmd)
 	profileCommand.AddCommand(deleteProfilesCmd)
+	profileCommand.AddCommand(cloneProfileCmd)
+	profileCommand.AddCommand(setDefaultProfileCmd)
 	profileCommand.AddCommand(listProfileCmd)
+	profileCommand.AddCommand(testProfileCmd)
 
 	//profile flags
 	profileCommand.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+ProfileCommandName)
@@ -188,17 +301,32 @@ func init() {
 	_ = createProfileCmd.MarkFlagRequired(FlagProfileCreateName)
 	createProfileCmd.Flags().StringP(FlagProfileCreateEndpoint, "e", "", "Create profile with this endpoint or host")
 	_ = createProfileCmd.MarkFlagRequired(FlagProfileCreateEndpoint)
-	createProfileCmd.Flags().StringP(FlagProfileCreateAuthType, "a", "", "Authentication type. Options are disabled, basic and aws-iam."+
+	createProfileCmd.Flags().StringP(FlagProfileCreateAuthType, "a", "", "Authentication type. Options are disabled, basic, cert, token, api-key and aws-iam."+
 		"\nIf security is disabled, provide --auth-type='disabled'.\nIf security uses HTTP basic authentication, provide --auth-type='basic'.\n"+
 		"If security uses client certificate authentication, provide --auth-type='cert'.\n"+
+		"If security uses bearer token authentication, provide --auth-type='token'.\n"+
+		"If security uses API key authentication, provide --auth-type='api-key'.\n"+
 		"If security uses AWS IAM ARNs as users, provide --auth-type='aws-iam'.\nopensearch-cli asks for additional information based on your choice of authentication type.")
 	_ = createProfileCmd.MarkFlagRequired(FlagProfileCreateAuthType)
 	createProfileCmd.Flags().IntP(FlagProfileMaxRetry, "m", 3, "Maximum retry attempts allowed if transient problems occur.\n"+
 		"You can override this value by using the "+environment.OPENSEARCH_MAX_RETRY+" environment variable.")
 	createProfileCmd.Flags().Int64P(FlagProfileTimeout, "t", 10, "Maximum time allowed for connection in seconds.\n"+
 		"You can override this value by using the "+environment.OPENSEARCH_TIMEOUT+" environment variable.")
+	createProfileCmd.Flags().Bool(FlagProfileInsecure, false, "Skip verification of cluster's certificate. Use it only for testing,"+
+		" provide CA certificate using --auth-type='cert' to connect to cluster with self-signed certificate instead.")
+	createProfileCmd.Flags().Bool(FlagProfileEncryptPassword, false, "Store password encrypted with a passphrase.\n"+
+		"The passphrase is read from the "+environment.OPENSEARCH_PASSPHRASE+" environment variable, or prompted if not set.")
 	createProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+CreateNewProfileCommandName)
 
+	//profile test flags
+	testProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+TestProfileCommandName)
+
+	//profile clone flags
+	cloneProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+CloneProfileCommandName)
+
+	//profile set-default flags
+	setDefaultProfileCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+SetDefaultProfileCommandName)
+
 	//profile delete flags
 	deleteProfilesCmd.Flags().BoolP(FlagProfileHelp, "h", false, "Help for "+DeleteProfilesCommandName)
 
@@ -212,7 +340,7 @@ func getProfileController(cfgFlagValue string) (profile.Controller, error) {
 		return nil, fmt.Errorf("failed to get config file due to: %w", err)
 	}
 	configController := config.New(configFilePath)
-	profileController := profile.New(configController)
+	profileController := profile.NewWithPassphrase(configController, getPassphrase)
 	return profileController, nil
 }
 
@@ -235,6 +363,39 @@ func validateProfileName(name string, controller profile.Controller) error {
 	return fmt.Errorf("profile %s already exists", name)
 }
 
+// getPassphrase gets passphrase to decrypt password from environment variable, or from user if
+// environment variable is not set and input is terminal
+func getPassphrase() (string, error) {
+	passphrase, err := profile.EnvironmentPassphrase()
+	if err == nil || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return passphrase, err
+	}
+	fmt.Fprintf(os.Stderr, "Passphrase: ")
+	return getUserInputAsMaskedText(checkInputIsNotEmpty), nil
+}
+
+// encryptPassword encrypts password of new profile with passphrase from environment variable or user
+func encryptPassword(newProfile *entity.Profile) error {
+	if len(newProfile.Password) < 1 {
+		return fmt.Errorf("--%s requires a password, use --%s='basic'", FlagProfileEncryptPassword, FlagProfileCreateAuthType)
+	}
+	passphrase, err := profile.EnvironmentPassphrase()
+	if err != nil {
+		fmt.Printf("Passphrase: ")
+		passphrase = getUserInputAsMaskedText(checkInputIsNotEmpty)
+		fmt.Printf("Confirm passphrase: ")
+		if getUserInputAsMaskedText(checkInputIsNotEmpty) != passphrase {
+			return errors.New("passphrases do not match")
+		}
+	}
+	encrypted, err := secret.Encrypt(newProfile.Password, passphrase)
+	if err != nil {
+		return err
+	}
+	newProfile.Password = encrypted
+	return nil
+}
+
 // getBasicAuthDetails gets new basic HTTP Auth profile information from user using command line
 func getBasicAuthDetails(newProfile *entity.Profile) {
 	fmt.Printf("Username: ")
@@ -243,6 +404,18 @@ func getBasicAuthDetails(newProfile *entity.Profile) {
 	newProfile.Password = getUserInputAsMaskedText(checkInputIsNotEmpty)
 }
 
+// getTokenAuthDetails gets new bearer token Auth profile information from user using command line
+func getTokenAuthDetails(newProfile *entity.Profile) {
+	fmt.Printf("Token: ")
+	newProfile.Token = getUserInputAsMaskedText(checkInputIsNotEmpty)
+}
+
+// getAPIKeyAuthDetails gets new API key Auth profile information from user using command line
+func getAPIKeyAuthDetails(newProfile *entity.Profile) {
+	fmt.Printf("API key: ")
+	newProfile.APIKey = getUserInputAsMaskedText(checkInputIsNotEmpty)
+}
+
 // getAWSIAMAuthDetails gets new AWS IAM Auth profile information from user using command line
 func getAWSIAMAuthDetails(newProfile *entity.Profile) {
 	fmt.Printf("AWS profile name (leave blank if you want to provide credentials using environment variables): ")
@@ -332,7 +505,7 @@ dev        test      	https://127.0.0.1:9200
 */
 func displayCompleteProfiles(p profile.Controller) (err error) {
 	var profiles []entity.Profile
-	if profiles, err = p.GetProfiles(); err != nil {
+	if profiles, err = p.ListProfiles(); err != nil {
 		return
 	}
 	if len(profiles) < 1 {
@@ -26,11 +26,18 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"opensearch-cli/client"
+	profilectrl "opensearch-cli/controller/profile"
 	"opensearch-cli/entity"
+	"opensearch-cli/environment"
+	"opensearch-cli/logger"
+	"opensearch-cli/version"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
@@ -40,15 +47,24 @@ const (
 	defaultConfigFileName = "config"
 	flagConfig            = "config"
 	flagProfileName       = "profile"
+	flagDebug             = "debug"
+	flagDryRun            = "dry-run"
+	flagQuiet             = "quiet"
+	flagVerbose           = "verbose"
+	flagNoColor           = "no-color"
+	flagOpaqueID          = "opaque-id"
+	flagEndpoint          = "endpoint"
+	flagUserName          = "username"
+	flagPassword          = "password"
+	ephemeralProfileName  = "ephemeral"
 	folderPermission      = 0755 // only owner can write, while everyone can read and execute
 	ConfigEnvVarName      = "OPENSEARCH_CLI_CONFIG"
 	RootCommandName       = "opensearch-cli"
-	version               = "1.0.0"
 )
 
 func buildVersionString() string {
 
-	return fmt.Sprintf("%s %s/%s", version, runtime.GOOS, runtime.GOARCH)
+	return fmt.Sprintf("%s %s/%s", version.Version, runtime.GOOS, runtime.GOARCH)
 }
 
 var rootCommand = &cobra.Command{
@@ -61,10 +77,14 @@ func GetRoot() *cobra.Command {
 	return rootCommand
 }
 
-// Execute executes the root command.
+// Execute executes the root command. It returns UsageError if arguments or flags are invalid,
+// or error displayed by the command if it failed, use ExitCode to map it to exit code
 func Execute() error {
-	err := rootCommand.Execute()
-	return err
+	commandError = nil
+	if err := rootCommand.Execute(); err != nil {
+		return &UsageError{Err: err}
+	}
+	return commandError
 }
 
 func GetDefaultConfigFilePath() string {
@@ -88,13 +108,46 @@ func getDefaultConfigFolderRootPath() string {
 func init() {
 	cobra.OnInitialize()
 	configFilePath := GetDefaultConfigFilePath()
-	rootCommand.PersistentFlags().StringP(flagConfig, "c", "", fmt.Sprintf("Configuration file for opensearch-cli, default is %s", configFilePath))
+	rootCommand.PersistentFlags().StringP(flagConfig, "c", "", fmt.Sprintf("Configuration file for opensearch-cli, default is %s.\n"+
+		"You can set it by using the %s environment variable as well.", configFilePath, ConfigEnvVarName))
 	rootCommand.PersistentFlags().StringP(flagProfileName, "p", "", "Use a specific profile from your configuration file")
+	rootCommand.PersistentFlags().Bool(flagDebug, false, "Print requests sent to cluster and responses, credentials are redacted.\n"+
+		"You can enable it by setting the "+environment.OPENSEARCH_DEBUG+" environment variable to true as well.")
+	rootCommand.PersistentFlags().Bool(flagDryRun, false, "Print requests that would be sent to cluster, credentials are redacted, without sending them")
+	rootCommand.PersistentFlags().Bool(flagQuiet, false, "Print only data, informational messages and warnings are suppressed")
+	rootCommand.PersistentFlags().Bool(flagVerbose, false, "Print details of execution, including requests sent to cluster and responses like --"+flagDebug)
+	rootCommand.PersistentFlags().Bool(flagNoColor, false, "Disable colors in table output, colors are disabled if output is not a terminal as well")
+	rootCommand.PersistentFlags().String(flagOpaqueID, "", "Send value as X-Opaque-Id header with every request to find them in OpenSearch logs.\n"+
+		"Profiles with opaque_id enabled send generated id unless this flag is set")
+	rootCommand.PersistentFlags().String(flagEndpoint, "", "Connect to this endpoint without using profiles from configuration file")
+	rootCommand.PersistentFlags().String(flagUserName, "", "User name to connect to endpoint given by --"+flagEndpoint)
+	rootCommand.PersistentFlags().String(flagPassword, "", "Password to connect to endpoint given by --"+flagEndpoint+
+		".\nYou can set it by using the "+environment.OPENSEARCH_PASSWORD+" environment variable as well, to keep it out of shell history")
+	rootCommand.PersistentPreRunE = configureLogger
 	rootCommand.Flags().BoolP("version", "v", false, "Version for opensearch-cli")
 	rootCommand.Flags().BoolP("help", "h", false, "Help for opensearch-cli")
 }
 
-// GetConfigFilePath gets config file path for execution
+//configureLogger sets verbosity of messages based on --quiet and --verbose flags
+func configureLogger(cmd *cobra.Command, args []string) error {
+	quiet, _ := rootCommand.PersistentFlags().GetBool(flagQuiet)
+	verbose, _ := rootCommand.PersistentFlags().GetBool(flagVerbose)
+	switch {
+	case quiet && verbose:
+		return fmt.Errorf("--%s and --%s cannot be used together", flagQuiet, flagVerbose)
+	case quiet:
+		logger.SetLevel(logger.QuietLevel)
+	case verbose:
+		logger.SetLevel(logger.VerboseLevel)
+	default:
+		logger.SetLevel(logger.NormalLevel)
+	}
+	return nil
+}
+
+// GetConfigFilePath gets config file path for execution, in order of precedence from
+// --config flag, OPENSEARCH_CLI_CONFIG environment variable, or default path
+// which is created if it doesn't exist
 func GetConfigFilePath(configFlagValue string) (string, error) {
 
 	if configFlagValue != "" {
@@ -142,13 +195,77 @@ func isExists(path string) bool {
 // DisplayError prints command name and error on console and exists as well.
 func DisplayError(err error, cmdName string) {
 	if err != nil {
+		recordError(err)
 		fmt.Println(cmdName, "Command failed.")
 		fmt.Println("Reason:", err)
 	}
 }
 
-// GetProfile gets profile details for current execution
+// GetClient creates client for current execution, which prints requests and responses if debug is enabled,
+// and prints requests instead of sending them if dry run is enabled
+func GetClient() (*client.Client, error) {
+	c, err := client.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	if isDebugEnabled() {
+		c.Debug = os.Stderr
+	}
+	if dryRun, err := rootCommand.PersistentFlags().GetBool(flagDryRun); err == nil && dryRun {
+		c.DryRun = os.Stdout
+	}
+	if opaqueID, err := rootCommand.PersistentFlags().GetString(flagOpaqueID); err == nil {
+		c.OpaqueID = opaqueID
+	}
+	return c, nil
+}
+
+//getEphemeralProfile builds profile from --endpoint, --username and --password flags.
+//It returns false if --endpoint is not set
+func getEphemeralProfile() (*entity.Profile, bool, error) {
+	flags := rootCommand.PersistentFlags()
+	endpoint, _ := flags.GetString(flagEndpoint)
+	userName, _ := flags.GetString(flagUserName)
+	password, _ := flags.GetString(flagPassword)
+	if len(endpoint) == 0 {
+		if len(userName) > 0 || len(password) > 0 {
+			return nil, false, fmt.Errorf("--%s and --%s can only be used with --%s", flagUserName, flagPassword, flagEndpoint)
+		}
+		return nil, false, nil
+	}
+	if len(userName) > 0 && len(password) == 0 {
+		password = os.Getenv(environment.OPENSEARCH_PASSWORD)
+	}
+	profile := &entity.Profile{
+		Name:     ephemeralProfileName,
+		Endpoint: endpoint,
+		UserName: userName,
+		Password: password,
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, true, err
+	}
+	return profile, true, nil
+}
+
+//isDebugEnabled checks whether debug is enabled either by flag, environment variable or verbose output
+func isDebugEnabled() bool {
+	if logger.IsVerbose() {
+		return true
+	}
+	if debug, err := rootCommand.PersistentFlags().GetBool(flagDebug); err == nil && debug {
+		return true
+	}
+	debug, err := strconv.ParseBool(os.Getenv(environment.OPENSEARCH_DEBUG))
+	return err == nil && debug
+}
+
+// GetProfile gets profile details for current execution. If --endpoint is set, profile is built from flags
+// instead, and configuration file is not read
 func GetProfile() (*entity.Profile, error) {
+	if profile, ok, err := getEphemeralProfile(); ok || err != nil {
+		return profile, err
+	}
 	p, err := GetProfileController()
 	if err != nil {
 		return nil, err
@@ -157,12 +274,18 @@ func GetProfile() (*entity.Profile, error) {
 	if err != nil {
 		return nil, err
 	}
-	profile, ok, err := p.GetProfileForExecution(profileFlagValue)
+	profile, err := p.ResolveProfile(profileFlagValue)
+	if errors.Is(err, profilectrl.ErrNoDefaultProfile) {
+		return nil, fmt.Errorf("no profile found for execution. Try %s %s --help for more information", RootCommandName, ProfileCommandName)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if !ok {
-		return nil, fmt.Errorf("no profile found for execution. Try %s %s --help for more information", RootCommandName, ProfileCommandName)
+	if err = profile.Validate(); err != nil {
+		return nil, err
+	}
+	if profile.Insecure {
+		logger.Warnf("certificate verification is disabled for profile %s, connection is not secure", profile.Name)
 	}
-	return &profile, nil
+	return profile, nil
 }
@@ -26,7 +26,15 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"opensearch-cli/entity"
+	"opensearch-cli/environment"
+	"opensearch-cli/gateway"
+	"opensearch-cli/logger"
 	"os"
 	"runtime"
 	"testing"
@@ -34,6 +42,19 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+//setConfigEnvironment sets environment variable and returns function which restores its previous value
+func setConfigEnvironment(t *testing.T, name string, value string) func() {
+	oldValue, exists := os.LookupEnv(name)
+	assert.NoError(t, os.Setenv(name, value))
+	return func() {
+		if exists {
+			assert.NoError(t, os.Setenv(name, oldValue))
+			return
+		}
+		assert.NoError(t, os.Unsetenv(name))
+	}
+}
+
 func TestGetConfigFilePath(t *testing.T) {
 
 	t.Run("config file path from environment variable", func(t *testing.T) {
@@ -107,6 +128,27 @@ func TestGetProfile(t *testing.T) {
 		_, err = GetProfile()
 		assert.EqualErrorf(t, err, "profile 'test1' does not exist", "unexpected error")
 	})
+	t.Run("profile from config file in environment variable", func(t *testing.T) {
+		defer setConfigEnvironment(t, ConfigEnvVarName, "testdata/config.yaml")()
+		root := GetRoot()
+		assert.NotNil(t, root)
+		root.SetArgs([]string{"--config=", "--profile", "test"})
+		_, err := root.ExecuteC()
+		assert.NoError(t, err)
+		actual, err := GetProfile()
+		assert.NoError(t, err)
+		expectedProfile := entity.Profile{Name: "test", Endpoint: "https://localhost:9200", UserName: "admin", Password: "admin"}
+		assert.EqualValues(t, expectedProfile, *actual)
+	})
+	t.Run("invalid profile", func(t *testing.T) {
+		root := GetRoot()
+		assert.NotNil(t, root)
+		root.SetArgs([]string{"--config", "testdata/invalid.yaml", "--profile", "invalid"})
+		_, err := root.ExecuteC()
+		assert.NoError(t, err)
+		_, err = GetProfile()
+		assert.EqualError(t, err, "profile invalid has invalid endpoint: endpoint: localhost:9200 must start with http:// or https://")
+	})
 	t.Run("no config file found", func(t *testing.T) {
 		root := GetRoot()
 		assert.NotNil(t, root)
@@ -117,3 +159,130 @@ func TestGetProfile(t *testing.T) {
 		assert.EqualError(t, err, "open testdata/config1.yaml: no such file or directory", "unexpected error")
 	})
 }
+
+func TestOutputLevel(t *testing.T) {
+	resetFlags := func() {
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagQuiet, "false"))
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagVerbose, "false"))
+		logger.SetLevel(logger.NormalLevel)
+		logger.SetOutput(os.Stderr)
+	}
+	cloneProfile := func(t *testing.T, flags ...string) string {
+		f, err := ioutil.TempFile("", "profile")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, os.Remove(f.Name()))
+		}()
+		assert.NoError(t, ioutil.WriteFile(f.Name(), []byte("profiles:\n  - name: prod\n    endpoint: https://localhost:9200\n"), 0644))
+		var buffer bytes.Buffer
+		logger.SetOutput(&buffer)
+		args := append([]string{ProfileCommandName, CloneProfileCommandName, "prod", "staging", "--config", f.Name()}, flags...)
+		_, err = executeCommand(GetRoot(), args...)
+		assert.NoError(t, err)
+		return buffer.String()
+	}
+	t.Run("informational messages are printed by default", func(t *testing.T) {
+		defer resetFlags()
+		assert.Equal(t, "Profile cloned successfully.\n", cloneProfile(t))
+		assert.False(t, isDebugEnabled())
+	})
+	t.Run("quiet suppresses informational messages", func(t *testing.T) {
+		defer resetFlags()
+		assert.Empty(t, cloneProfile(t, "--"+flagQuiet))
+		assert.Equal(t, logger.QuietLevel, logger.GetLevel())
+	})
+	t.Run("verbose enables debug output", func(t *testing.T) {
+		defer resetFlags()
+		assert.Equal(t, "Profile cloned successfully.\n", cloneProfile(t, "--"+flagVerbose))
+		assert.True(t, isDebugEnabled())
+	})
+	t.Run("quiet and verbose cannot be used together", func(t *testing.T) {
+		defer resetFlags()
+		_, err := executeCommand(GetRoot(), VersionCommandName, "--"+flagQuiet, "--"+flagVerbose)
+		assert.EqualError(t, err, "--quiet and --verbose cannot be used together")
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	defer func() {
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagDryRun, "false"))
+	}()
+	_, err := executeCommand(GetRoot(), VersionCommandName)
+	assert.NoError(t, err)
+	c, err := GetClient()
+	assert.NoError(t, err)
+	assert.Nil(t, c.DryRun)
+
+	_, err = executeCommand(GetRoot(), VersionCommandName, "--"+flagDryRun)
+	assert.NoError(t, err)
+	c, err = GetClient()
+	assert.NoError(t, err)
+	assert.Equal(t, os.Stdout, c.DryRun)
+}
+
+func TestOpaqueIDFlag(t *testing.T) {
+	defer func() {
+		assert.NoError(t, GetRoot().PersistentFlags().Set(flagOpaqueID, ""))
+	}()
+	_, err := executeCommand(GetRoot(), VersionCommandName, "--"+flagOpaqueID, "nightly-job")
+	assert.NoError(t, err)
+	c, err := GetClient()
+	assert.NoError(t, err)
+	assert.Equal(t, "nightly-job", c.OpaqueID)
+}
+
+func TestEphemeralProfile(t *testing.T) {
+	resetFlags := func() {
+		for _, flag := range []string{flagEndpoint, flagUserName, flagPassword, flagConfig} {
+			assert.NoError(t, GetRoot().PersistentFlags().Set(flag, ""))
+		}
+	}
+	defer resetFlags()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":{"number":"1.0.0"}}`))
+	}))
+	defer server.Close()
+	t.Run("gateway from flags", func(t *testing.T) {
+		defer resetFlags()
+		// configuration file doesn't exist, it must not be read
+		_, err := executeCommand(GetRoot(), VersionCommandName, "--config", "testdata/missing.yaml",
+			"--endpoint", server.URL, "--username", "admin", "--password", "secret")
+		assert.NoError(t, err)
+		profile, err := GetProfile()
+		assert.NoError(t, err)
+		assert.EqualValues(t, entity.Profile{Name: ephemeralProfileName, Endpoint: server.URL, UserName: "admin", Password: "secret"}, *profile)
+		c, err := GetClient()
+		assert.NoError(t, err)
+		g, err := gateway.NewHTTPGateway(c, profile)
+		assert.NoError(t, err)
+		assert.NoError(t, g.Ping(context.Background()))
+	})
+	t.Run("password from environment", func(t *testing.T) {
+		defer resetFlags()
+		defer setConfigEnvironment(t, environment.OPENSEARCH_PASSWORD, "secret")()
+		_, err := executeCommand(GetRoot(), VersionCommandName, "--endpoint", server.URL, "--username", "admin")
+		assert.NoError(t, err)
+		profile, err := GetProfile()
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", profile.Password)
+	})
+	t.Run("invalid endpoint", func(t *testing.T) {
+		defer resetFlags()
+		_, err := executeCommand(GetRoot(), VersionCommandName, "--endpoint", "localhost:9200")
+		assert.NoError(t, err)
+		_, err = GetProfile()
+		assert.EqualError(t, err, "profile ephemeral has invalid endpoint: endpoint: localhost:9200 must start with http:// or https://")
+	})
+	t.Run("credentials without endpoint", func(t *testing.T) {
+		defer resetFlags()
+		_, err := executeCommand(GetRoot(), VersionCommandName, "--username", "admin")
+		assert.NoError(t, err)
+		_, err = GetProfile()
+		assert.EqualError(t, err, "--username and --password can only be used with --endpoint")
+	})
+}
@@ -0,0 +1,45 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"opensearch-cli/version"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	VersionCommandName = "version"
+)
+
+//versionCmd prints version of opensearch-cli along with build metadata
+var versionCmd = &cobra.Command{
+	Use:   VersionCommandName,
+	Short: "Print version of opensearch-cli",
+	Long:  "Print version, git commit, build date and Go version of opensearch-cli, include it when reporting issues.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Version:    %s\n", version.Version)
+		fmt.Fprintf(out, "Git commit: %s\n", version.GitCommit)
+		fmt.Fprintf(out, "Build date: %s\n", version.BuildDate)
+		fmt.Fprintf(out, "Go version: %s\n", version.GoVersion())
+		fmt.Fprintf(out, "OS/Arch:    %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolP("help", "h", false, "Help for "+VersionCommandName)
+	GetRoot().AddCommand(versionCmd)
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package commands
+
+import (
+	"opensearch-cli/version"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionCommand(t *testing.T) {
+	oldVersion, oldCommit, oldDate := version.Version, version.GitCommit, version.BuildDate
+	defer func() {
+		version.Version, version.GitCommit, version.BuildDate = oldVersion, oldCommit, oldDate
+	}()
+	version.Version, version.GitCommit, version.BuildDate = "1.2.3", "abc1234", "2021-06-01T00:00:00Z"
+	output, err := executeCommand(GetRoot(), VersionCommandName)
+	assert.NoError(t, err)
+	expected := "Version:    1.2.3\n" +
+		"Git commit: abc1234\n" +
+		"Build date: 2021-06-01T00:00:00Z\n" +
+		"Go version: " + runtime.Version() + "\n" +
+		"OS/Arch:    " + runtime.GOOS + "/" + runtime.GOARCH + "\n"
+	assert.Equal(t, expected, output)
+}
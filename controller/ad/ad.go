@@ -25,11 +25,11 @@ package ad
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"opensearch-cli/controller/platform"
 	entity "opensearch-cli/entity/ad"
+	gw "opensearch-cli/gateway"
 	"opensearch-cli/gateway/ad"
 	"opensearch-cli/mapper"
 	admapper "opensearch-cli/mapper/ad"
@@ -47,6 +47,7 @@ type Controller interface {
 	StopDetector(context.Context, string) error
 	DeleteDetector(context.Context, string, bool, bool) error
 	GetDetector(context.Context, string) (*entity.DetectorOutput, error)
+	GetDetectorState(context.Context, string) (string, error)
 	CreateAnomalyDetector(context.Context, entity.CreateDetectorRequest) (*string, error)
 	CreateMultiEntityAnomalyDetector(ctx context.Context, request entity.CreateDetectorRequest, interactive bool, display bool) ([]string, error)
 	SearchDetectorByName(context.Context, string) ([]entity.Detector, error)
@@ -63,7 +64,8 @@ type controller struct {
 	openSearch platform.Controller
 }
 
-//New returns new Controller instance
+//New returns new Controller instance, reader is used to confirm destructive operations with user.
+//If reader is nil, operations proceed without confirmation
 func New(reader io.Reader, openSearch platform.Controller, gateway ad.Gateway) Controller {
 	return &controller{
 		reader,
@@ -114,7 +116,7 @@ func (c controller) StopDetector(ctx context.Context, ID string) error {
 
 func (c controller) askForConfirmation(message *string) bool {
 
-	if message == nil {
+	if message == nil || c.reader == nil {
 		return true
 	}
 	if len(*message) > 0 {
@@ -163,7 +165,7 @@ func (c controller) DeleteDetector(ctx context.Context, id string, interactive b
 		if err != nil {
 			return err
 		}
-		if interactive {
+		if interactive && res != nil {
 			fmt.Println(*res)
 		}
 
@@ -192,6 +194,14 @@ func (c controller) GetDetector(ctx context.Context, ID string) (*entity.Detecto
 	return admapper.MapToDetectorOutput(data)
 }
 
+//GetDetectorState fetch detector's current state based on DetectorID
+func (c controller) GetDetectorState(ctx context.Context, ID string) (string, error) {
+	if len(ID) < 1 {
+		return "", fmt.Errorf("detector Id: %s cannot be empty", ID)
+	}
+	return c.gateway.GetDetectorState(ctx, ID)
+}
+
 func processEntityError(err error) error {
 	var c entity.CreateError
 	data := fmt.Sprintf("%v", err)
@@ -200,7 +210,7 @@ func processEntityError(err error) error {
 		return err
 	}
 	if len(c.Error.Reason) > 0 {
-		return errors.New(c.Error.Reason)
+		return &gw.ReasonError{Reason: c.Error.Reason, Err: err}
 	}
 	return err
 }
@@ -576,7 +586,7 @@ func (c controller) UpdateDetector(ctx context.Context, input entity.UpdateDetec
 	if err != nil {
 		return err
 	}
-	err = c.gateway.UpdateDetector(ctx, input.ID, payload)
+	err = c.gateway.UpdateDetector(ctx, input.ID, payload, nil)
 	if err != nil {
 		return err
 	}
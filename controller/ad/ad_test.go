@@ -188,6 +188,42 @@ func TestController_StartDetector(t *testing.T) {
 	})
 }
 
+func TestController_GetDetectorState(t *testing.T) {
+	t.Run("get state of empty detector", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctx := context.Background()
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetDetectorState(ctx, "")
+		assert.Error(t, err)
+	})
+	t.Run("get state gateway failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetectorState(ctx, "detectorID").Return("", errors.New("no connection"))
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		_, err := ctrl.GetDetectorState(ctx, "detectorID")
+		assert.EqualError(t, err, "no connection")
+	})
+	t.Run("get state", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockADGateway.EXPECT().GetDetectorState(ctx, "detectorID").Return("RUNNING", nil)
+		mockESController := mockController.NewMockController(mockCtrl)
+		ctrl := New(os.Stdin, mockESController, mockADGateway)
+		state, err := ctrl.GetDetectorState(ctx, "detectorID")
+		assert.NoError(t, err)
+		assert.Equal(t, "RUNNING", state)
+	})
+}
+
 func TestController_StopDetector(t *testing.T) {
 	t.Run("stop empty detector", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
@@ -375,6 +411,31 @@ func TestController_DeleteDetector(t *testing.T) {
 		err := ctrl.DeleteDetector(ctx, mockDetectorID, true, false)
 		assert.NoError(t, err)
 	})
+	t.Run("stop with empty response agreed by user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		var stdin bytes.Buffer
+		stdin.Write([]byte("yes\n"))
+		mockADGateway.EXPECT().StopDetector(ctx, mockDetectorID).Return(nil, nil)
+		mockADGateway.EXPECT().DeleteDetector(ctx, mockDetectorID).Return(nil)
+		ctrl := New(&stdin, mockESController, mockADGateway)
+		err := ctrl.DeleteDetector(ctx, mockDetectorID, true, true)
+		assert.NoError(t, err)
+	})
+	t.Run("confirmation skipped without reader", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ctx := context.Background()
+		mockADGateway := gateway.NewMockGateway(mockCtrl)
+		mockESController := mockController.NewMockController(mockCtrl)
+		mockADGateway.EXPECT().DeleteDetector(ctx, mockDetectorID).Return(nil)
+		ctrl := New(nil, mockESController, mockADGateway)
+		err := ctrl.DeleteDetector(ctx, mockDetectorID, true, false)
+		assert.NoError(t, err)
+	})
 }
 
 func TestController_CreateMultiEntityAnomalyDetector(t *testing.T) {
@@ -935,7 +996,7 @@ func TestController_UpdateDetector(t *testing.T) {
 		ctx := context.Background()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		mockADGateway.EXPECT().GetDetector(ctx, "m4ccEnIBTXsGi3mvMt9p").Return(helperLoadBytes(t, "get_response.json"), nil)
-		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request).Return(errors.New("failed to update"))
+		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request, nil).Return(errors.New("failed to update"))
 		mockESController := mockController.NewMockController(mockCtrl)
 		var stdin bytes.Buffer
 		stdin.Write([]byte("yes\n"))
@@ -949,7 +1010,7 @@ func TestController_UpdateDetector(t *testing.T) {
 		ctx := context.Background()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		mockADGateway.EXPECT().GetDetector(ctx, "m4ccEnIBTXsGi3mvMt9p").Return(helperLoadBytes(t, "get_response.json"), nil)
-		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request).Return(nil)
+		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request, nil).Return(nil)
 		mockADGateway.EXPECT().StartDetector(ctx, "m4ccEnIBTXsGi3mvMt9p").Return(errors.New("failed to start"))
 		mockESController := mockController.NewMockController(mockCtrl)
 		var stdin bytes.Buffer
@@ -967,7 +1028,7 @@ func TestController_UpdateDetector(t *testing.T) {
 		staleDetector := input
 		staleDetector.LastUpdatedAt = input.LastUpdatedAt - 100
 		mockADGateway.EXPECT().StopDetector(ctx, "m4ccEnIBTXsGi3mvMt9p").Return(nil, nil)
-		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request).Return(nil)
+		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request, nil).Return(nil)
 		var stdin bytes.Buffer
 		stdin.Write([]byte("yes\n"))
 		ctrl := New(&stdin, mockESController, mockADGateway)
@@ -980,7 +1041,7 @@ func TestController_UpdateDetector(t *testing.T) {
 		ctx := context.Background()
 		mockADGateway := gateway.NewMockGateway(mockCtrl)
 		mockADGateway.EXPECT().GetDetector(ctx, "m4ccEnIBTXsGi3mvMt9p").Return(helperLoadBytes(t, "get_response.json"), nil)
-		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request).Return(nil)
+		mockADGateway.EXPECT().UpdateDetector(ctx, "m4ccEnIBTXsGi3mvMt9p", &request, nil).Return(nil)
 		mockADGateway.EXPECT().StartDetector(ctx, "m4ccEnIBTXsGi3mvMt9p").Return(nil)
 		mockESController := mockController.NewMockController(mockCtrl)
 		var stdin bytes.Buffer
@@ -108,6 +108,21 @@ func (mr *MockControllerMockRecorder) GetDetector(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetector", reflect.TypeOf((*MockController)(nil).GetDetector), arg0, arg1)
 }
 
+// GetDetectorState mocks base method
+func (m *MockController) GetDetectorState(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorState", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorState indicates an expected call of GetDetectorState
+func (mr *MockControllerMockRecorder) GetDetectorState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorState", reflect.TypeOf((*MockController)(nil).GetDetectorState), arg0, arg1)
+}
+
 // GetDetectorsByName mocks base method
 func (m *MockController) GetDetectorsByName(arg0 context.Context, arg1 string, arg2 bool) ([]*ad.DetectorOutput, error) {
 	m.ctrl.T.Helper()
@@ -47,7 +47,15 @@ type controller struct {
 
 //GetStatistics gets stats data based on nodes and stat names
 func (c controller) GetStatistics(ctx context.Context, nodes string, names string) ([]byte, error) {
-	return c.gateway.GetStatistics(ctx, nodes, names)
+	return c.gateway.GetStatistics(ctx, splitList(nodes), splitList(names))
+}
+
+//splitList splits comma separated values, it returns nil if value is empty
+func splitList(value string) []string {
+	if len(value) == 0 {
+		return nil
+	}
+	return strings.Split(value, ",")
 }
 
 //New returns new Controller instance
@@ -60,8 +68,7 @@ func New(gateway gateway.Gateway) Controller {
 //WarmupIndices will load all the graphs for all of the shards (primaries and replicas)
 //of all the indices specified in the request into native memory
 func (c controller) WarmupIndices(ctx context.Context, index []string) (*entity.Shards, error) {
-	indices := strings.Join(index, ",")
-	response, err := c.gateway.WarmupIndices(ctx, indices)
+	response, err := c.gateway.WarmupIndices(ctx, index)
 	if err != nil {
 		return nil, err
 	}
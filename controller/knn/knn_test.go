@@ -44,7 +44,7 @@ func TestControllerGetStatistics(t *testing.T) {
 
 		mockGateway := gateway.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		mockGateway.EXPECT().GetStatistics(ctx, "", "").Return(nil, errors.New("gateway failed"))
+		mockGateway.EXPECT().GetStatistics(ctx, nil, nil).Return(nil, errors.New("gateway failed"))
 		ctrl := New(mockGateway)
 		_, err := ctrl.GetStatistics(ctx, "", "")
 		assert.Error(t, err)
@@ -54,7 +54,7 @@ func TestControllerGetStatistics(t *testing.T) {
 		defer mockCtrl.Finish()
 		mockGateway := gateway.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		mockGateway.EXPECT().GetStatistics(ctx, "node1", "stats").Return([]byte(`response succeeded`), nil)
+		mockGateway.EXPECT().GetStatistics(ctx, []string{"node1"}, []string{"stats"}).Return([]byte(`response succeeded`), nil)
 		ctrl := New(mockGateway)
 		result, err := ctrl.GetStatistics(ctx, "node1", "stats")
 		assert.NoError(t, err)
@@ -69,7 +69,7 @@ func TestControllerWarmupIndices(t *testing.T) {
 
 		mockGateway := gateway.NewMockGateway(mockCtrl)
 		ctx := context.Background()
-		mockGateway.EXPECT().WarmupIndices(ctx, "index1").Return(nil, errors.New("gateway failed"))
+		mockGateway.EXPECT().WarmupIndices(ctx, []string{"index1"}).Return(nil, errors.New("gateway failed"))
 		ctrl := New(mockGateway)
 		_, err := ctrl.WarmupIndices(ctx, []string{"index1"})
 		assert.Error(t, err)
@@ -88,7 +88,7 @@ func TestControllerWarmupIndices(t *testing.T) {
 		}
 		rawMessage, err := json.Marshal(expectedResponse)
 		assert.NoError(t, err)
-		mockGateway.EXPECT().WarmupIndices(ctx, "index1").Return(rawMessage, nil)
+		mockGateway.EXPECT().WarmupIndices(ctx, []string{"index1"}).Return(rawMessage, nil)
 		ctrl := New(mockGateway)
 		result, err := ctrl.WarmupIndices(ctx, []string{"index1"})
 		assert.NoError(t, err)
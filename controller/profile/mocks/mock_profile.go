@@ -34,6 +34,20 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// CloneProfile mocks base method
+func (m *MockController) CloneProfile(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloneProfile indicates an expected call of CloneProfile
+func (mr *MockControllerMockRecorder) CloneProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneProfile", reflect.TypeOf((*MockController)(nil).CloneProfile), arg0, arg1)
+}
+
 // CreateProfile mocks base method
 func (m *MockController) CreateProfile(arg0 entity.Profile) error {
 	m.ctrl.T.Helper()
@@ -122,3 +136,47 @@ func (mr *MockControllerMockRecorder) GetProfilesMap() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfilesMap", reflect.TypeOf((*MockController)(nil).GetProfilesMap))
 }
+
+// ListProfiles mocks base method
+func (m *MockController) ListProfiles() ([]entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProfiles")
+	ret0, _ := ret[0].([]entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProfiles indicates an expected call of ListProfiles
+func (mr *MockControllerMockRecorder) ListProfiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfiles", reflect.TypeOf((*MockController)(nil).ListProfiles))
+}
+
+// ResolveProfile mocks base method
+func (m *MockController) ResolveProfile(arg0 string) (*entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveProfile", arg0)
+	ret0, _ := ret[0].(*entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveProfile indicates an expected call of ResolveProfile
+func (mr *MockControllerMockRecorder) ResolveProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveProfile", reflect.TypeOf((*MockController)(nil).ResolveProfile), arg0)
+}
+
+// SetDefaultProfile mocks base method
+func (m *MockController) SetDefaultProfile(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultProfile", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefaultProfile indicates an expected call of SetDefaultProfile
+func (mr *MockControllerMockRecorder) SetDefaultProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultProfile", reflect.TypeOf((*MockController)(nil).SetDefaultProfile), arg0)
+}
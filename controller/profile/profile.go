@@ -26,10 +26,12 @@
 package profile
 
 import (
+	"errors"
 	"fmt"
 	"opensearch-cli/controller/config"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
+	"opensearch-cli/secret"
 	"os"
 	"strings"
 )
@@ -41,24 +43,52 @@ const (
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -destination=mocks/mock_profile.go -package=mocks . Controller
 type Controller interface {
 	CreateProfile(profile entity.Profile) error
+	CloneProfile(source string, target string) error
 	DeleteProfiles(names []string) error
 	GetProfiles() ([]entity.Profile, error)
+	ListProfiles() ([]entity.Profile, error)
 	GetProfileNames() ([]string, error)
 	GetProfilesMap() (map[string]entity.Profile, error)
 	GetProfileForExecution(name string) (entity.Profile, bool, error)
+	ResolveProfile(name string) (*entity.Profile, error)
+	SetDefaultProfile(name string) error
 }
 
+//ErrPassphraseRequired is returned if profile's password is encrypted but passphrase is not provided
+var ErrPassphraseRequired = fmt.Errorf("passphrase is required, set %s to decrypt it", environment.OPENSEARCH_PASSPHRASE)
+
+//ErrNoDefaultProfile is returned if profile is not selected and default profile is not set
+var ErrNoDefaultProfile = errors.New("no profile selected and no default profile set")
+
+//Passphrase returns passphrase to decrypt encrypted password of profile
+type Passphrase func() (string, error)
+
 type controller struct {
 	configCtrl config.Controller
+	passphrase Passphrase
 }
 
-//New returns new config controller instance
+//New returns new config controller instance, passphrase to decrypt passwords is read from environment variable
 func New(c config.Controller) Controller {
+	return NewWithPassphrase(c, EnvironmentPassphrase)
+}
+
+//NewWithPassphrase returns new config controller instance which uses passphrase to decrypt passwords
+func NewWithPassphrase(c config.Controller, passphrase Passphrase) Controller {
 	return &controller{
 		configCtrl: c,
+		passphrase: passphrase,
 	}
 }
 
+//EnvironmentPassphrase returns passphrase from OPENSEARCH_PASSPHRASE environment variable
+func EnvironmentPassphrase() (string, error) {
+	if value, ok := os.LookupEnv(environment.OPENSEARCH_PASSPHRASE); ok && len(value) > 0 {
+		return value, nil
+	}
+	return "", ErrPassphraseRequired
+}
+
 //GetProfiles gets list of profiles fom config file
 func (c controller) GetProfiles() ([]entity.Profile, error) {
 	data, err := c.configCtrl.Read()
@@ -68,6 +98,23 @@ func (c controller) GetProfiles() ([]entity.Profile, error) {
 	return data.Profiles, nil
 }
 
+//ListProfiles gets list of profiles from config file with secrets masked, so that they can be displayed.
+//It returns empty list if config file does not exist or is empty
+func (c controller) ListProfiles() ([]entity.Profile, error) {
+	data, err := c.configCtrl.Read()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []entity.Profile{}, nil
+		}
+		return nil, err
+	}
+	result := make([]entity.Profile, 0, len(data.Profiles))
+	for _, p := range data.Profiles {
+		result = append(result, *p.Masked())
+	}
+	return result, nil
+}
+
 //GetProfileNames gets list of profile names
 func (c controller) GetProfileNames() ([]string, error) {
 	profiles, err := c.GetProfiles()
@@ -105,6 +152,32 @@ func (c controller) CreateProfile(p entity.Profile) error {
 	return c.configCtrl.Write(data)
 }
 
+//CloneProfile copies profile named source into new profile named target and saves it in config file.
+//Profile is copied as stored in config file, hence encrypted password remains encrypted
+func (c controller) CloneProfile(source string, target string) error {
+	if len(target) < 1 {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	data, err := c.configCtrl.Read()
+	if err != nil {
+		return err
+	}
+	var sourceProfile *entity.Profile
+	for i := range data.Profiles {
+		if data.Profiles[i].Name == target {
+			return fmt.Errorf("profile %s already exists", target)
+		}
+		if data.Profiles[i].Name == source {
+			sourceProfile = &data.Profiles[i]
+		}
+	}
+	if sourceProfile == nil {
+		return fmt.Errorf("profile '%s' does not exist", source)
+	}
+	data.Profiles = append(data.Profiles, *sourceProfile.Clone(target))
+	return c.configCtrl.Write(data)
+}
+
 //DeleteProfiles loads all profile, deletes selected profiles, and saves rest in config file
 func (c controller) DeleteProfiles(names []string) error {
 	profilesMap, err := c.GetProfilesMap()
@@ -128,6 +201,9 @@ func (c controller) DeleteProfiles(names []string) error {
 
 	//empty existing profile
 	data.Profiles = nil
+	if _, ok := profilesMap[data.DefaultProfile]; !ok {
+		data.DefaultProfile = ""
+	}
 	for _, p := range profilesMap {
 		// add existing profiles to the list
 		data.Profiles = append(data.Profiles, p)
@@ -146,16 +222,105 @@ func (c controller) DeleteProfiles(names []string) error {
 	return nil
 }
 
+//SetDefaultProfile saves name of profile which is used if profile is not selected by flag or
+//environment variable in config file, empty name unsets default profile
+func (c controller) SetDefaultProfile(name string) error {
+	data, err := c.configCtrl.Read()
+	if err != nil {
+		return err
+	}
+	if len(name) > 0 && !hasProfile(data.Profiles, name) {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	data.DefaultProfile = name
+	return c.configCtrl.Write(data)
+}
+
+func hasProfile(profiles []entity.Profile, name string) bool {
+	for _, p := range profiles {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+//ResolveProfile returns profile for current command execution like GetProfileForExecution,
+//but returns ErrNoDefaultProfile instead of false if no profile is selected
+func (c controller) ResolveProfile(name string) (*entity.Profile, error) {
+	value, ok, err := c.GetProfileForExecution(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNoDefaultProfile
+	}
+	return &value, nil
+}
+
 // GetProfileForExecution returns profile information for current command execution
 // if profile name is provided as an argument, will return the profile,
 // if profile name is not provided as argument, we will check for environment variable
-// in session, then will check for profile named `default`
-// bool determines whether profile is valid or not
+// in session, then for default profile set in config, and finally for profile named `default`
+// bool determines whether profile is valid or not.
+// Endpoint, user name and password that are empty in the profile are read from environment variables
+// OPENSEARCH_ENDPOINT, OPENSEARCH_USER and OPENSEARCH_PASSWORD, values set in the profile take precedence.
+// Encrypted password is decrypted using passphrase of the controller.
 func (c controller) GetProfileForExecution(name string) (value entity.Profile, ok bool, err error) {
-	profiles, err := c.GetProfilesMap()
+	value, ok, err = c.getProfileForExecution(name)
+	if ok && err == nil {
+		applyEnvironment(&value)
+		err = c.decryptPassword(&value)
+	}
+	return
+}
+
+// decryptPassword decrypts profile's password if it was stored encrypted
+func (c controller) decryptPassword(profile *entity.Profile) error {
+	if !secret.IsEncrypted(profile.Password) {
+		return nil
+	}
+	passphrase, err := c.passphrase()
+	if err != nil {
+		return fmt.Errorf("password of profile '%s' is encrypted: %w", profile.Name, err)
+	}
+	password, err := secret.Decrypt(profile.Password, passphrase)
+	if err != nil {
+		return fmt.Errorf("password of profile '%s' is encrypted: %w", profile.Name, err)
+	}
+	profile.Password = password
+	return nil
+}
+
+// applyEnvironment populates fields which are empty in profile from environment variables.
+// Credentials are not populated for profiles using AWS IAM authentication
+func applyEnvironment(profile *entity.Profile) {
+	setFromEnvironment(&profile.Endpoint, environment.OPENSEARCH_ENDPOINT)
+	if profile.AWS != nil {
+		return
+	}
+	setFromEnvironment(&profile.UserName, environment.OPENSEARCH_USER)
+	setFromEnvironment(&profile.Password, environment.OPENSEARCH_PASSWORD)
+}
+
+func setFromEnvironment(field *string, envVariable string) {
+	if len(*field) > 0 {
+		return
+	}
+	if value, ok := os.LookupEnv(envVariable); ok {
+		*field = value
+	}
+}
+
+func (c controller) getProfileForExecution(name string) (value entity.Profile, ok bool, err error) {
+	data, err := c.configCtrl.Read()
 	if err != nil {
 		return
 	}
+	profiles := make(map[string]entity.Profile)
+	for _, p := range data.Profiles {
+		profiles[p.Name] = p
+	}
 	if name != "" {
 		if value, ok = profiles[name]; ok {
 			return
@@ -168,6 +333,12 @@ func (c controller) GetProfileForExecution(name string) (value entity.Profile, o
 		}
 		return value, ok, fmt.Errorf("profile '%s' does not exist", envProfileName)
 	}
+	if len(data.DefaultProfile) > 0 {
+		if value, ok = profiles[data.DefaultProfile]; ok {
+			return
+		}
+		return value, ok, fmt.Errorf("default profile '%s' does not exist", data.DefaultProfile)
+	}
 	value, ok = profiles[DefaultProfileName]
 	return
 }
@@ -27,10 +27,14 @@ package profile
 
 import (
 	"errors"
+	"io/ioutil"
+	configctrl "opensearch-cli/controller/config"
 	config "opensearch-cli/controller/config/mocks"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
+	"opensearch-cli/secret"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -155,6 +159,62 @@ func TestControllerGetProfiles(t *testing.T) {
 	})
 }
 
+func TestControllerListProfiles(t *testing.T) {
+	t.Run("secrets are masked", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{
+			Profiles: []entity.Profile{
+				{Name: "basic", Endpoint: "https://localhost:9200", UserName: "admin", Password: "admin"},
+				{Name: "token", Endpoint: "https://localhost:9200", Token: "secret-token"},
+				{Name: "api-key", Endpoint: "https://localhost:9200", APIKey: "secret-key"},
+				{Name: "disabled", Endpoint: "http://localhost:9200"},
+			}}, nil)
+		ctrl := New(mockConfigCtrl)
+		actual, err := ctrl.ListProfiles()
+		assert.NoError(t, err)
+		assert.EqualValues(t, []entity.Profile{
+			{Name: "basic", Endpoint: "https://localhost:9200", UserName: "admin", Password: entity.MaskedValue},
+			{Name: "token", Endpoint: "https://localhost:9200", Token: entity.MaskedValue},
+			{Name: "api-key", Endpoint: "https://localhost:9200", APIKey: entity.MaskedValue},
+			{Name: "disabled", Endpoint: "http://localhost:9200"},
+		}, actual)
+	})
+	t.Run("missing config file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "profile-list")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, os.RemoveAll(dir))
+		}()
+		actual, err := New(configctrl.New(filepath.Join(dir, "config.yaml"))).ListProfiles()
+		assert.NoError(t, err)
+		assert.NotNil(t, actual)
+		assert.Empty(t, actual)
+	})
+	t.Run("empty config file", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "profile-list")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, os.Remove(f.Name()))
+		}()
+		assert.NoError(t, f.Close())
+		actual, err := New(configctrl.New(f.Name())).ListProfiles()
+		assert.NoError(t, err)
+		assert.NotNil(t, actual)
+		assert.Empty(t, actual)
+	})
+	t.Run("config controller failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
+		ctrl := New(mockConfigCtrl)
+		_, err := ctrl.ListProfiles()
+		assert.EqualError(t, err, "failed to read")
+	})
+}
+
 func TestControllerGetProfileNames(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
@@ -252,6 +312,190 @@ func TestControllerGetProfileForExecution(t *testing.T) {
 		assert.False(t, ok)
 	})
 }
+
+func TestControllerResolveProfile(t *testing.T) {
+	//setProfileEnvironment sets OPENSEARCH_PROFILE to value, or unsets it if value is nil, and returns function to restore it
+	setProfileEnvironment := func(t *testing.T, value *string) func() {
+		oldValue, exists := os.LookupEnv(environment.OPENSEARCH_PROFILE)
+		if value == nil {
+			assert.NoError(t, os.Unsetenv(environment.OPENSEARCH_PROFILE))
+		} else {
+			assert.NoError(t, os.Setenv(environment.OPENSEARCH_PROFILE, *value))
+		}
+		return func() {
+			if exists {
+				assert.NoError(t, os.Setenv(environment.OPENSEARCH_PROFILE, oldValue))
+				return
+			}
+			assert.NoError(t, os.Unsetenv(environment.OPENSEARCH_PROFILE))
+		}
+	}
+	getController := func(mockCtrl *gomock.Controller, defaultProfile string) Controller {
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{
+			DefaultProfile: defaultProfile,
+			Profiles: []entity.Profile{
+				{Name: "flag", Endpoint: "https://flag:9200"},
+				{Name: "env", Endpoint: "https://env:9200"},
+				{Name: "configured", Endpoint: "https://configured:9200"},
+			},
+		}, nil)
+		return New(mockConfigCtrl)
+	}
+	envProfile := "env"
+	t.Run("flag takes precedence", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setProfileEnvironment(t, &envProfile)()
+		p, err := getController(mockCtrl, "configured").ResolveProfile("flag")
+		assert.NoError(t, err)
+		assert.Equal(t, "flag", p.Name)
+	})
+	t.Run("environment variable takes precedence over configured default", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setProfileEnvironment(t, &envProfile)()
+		p, err := getController(mockCtrl, "configured").ResolveProfile("")
+		assert.NoError(t, err)
+		assert.Equal(t, "env", p.Name)
+	})
+	t.Run("configured default", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setProfileEnvironment(t, nil)()
+		p, err := getController(mockCtrl, "configured").ResolveProfile("")
+		assert.NoError(t, err)
+		assert.Equal(t, "configured", p.Name)
+	})
+	t.Run("configured default does not exist", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setProfileEnvironment(t, nil)()
+		_, err := getController(mockCtrl, "deleted").ResolveProfile("")
+		assert.EqualError(t, err, "default profile 'deleted' does not exist")
+	})
+	t.Run("no default set", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setProfileEnvironment(t, nil)()
+		_, err := getController(mockCtrl, "").ResolveProfile("")
+		assert.True(t, errors.Is(err, ErrNoDefaultProfile))
+	})
+}
+
+func TestControllerSetDefaultProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		expectedConfig := getSampleConfig()
+		expectedConfig.DefaultProfile = "local"
+		mockConfigCtrl.EXPECT().Write(expectedConfig).Return(nil)
+		assert.NoError(t, New(mockConfigCtrl).SetDefaultProfile("local"))
+	})
+	t.Run("profile does not exist", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		err := New(mockConfigCtrl).SetDefaultProfile("invalid")
+		assert.EqualError(t, err, "profile 'invalid' does not exist")
+	})
+	t.Run("deleting default profile unsets it", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		sampleConfig := getSampleConfig()
+		sampleConfig.DefaultProfile = "local"
+		mockConfigCtrl.EXPECT().Read().Return(sampleConfig, nil).Times(2)
+		expectedConfig := getSampleConfig()
+		expectedConfig.Profiles = []entity.Profile{expectedConfig.Profiles[1]}
+		mockConfigCtrl.EXPECT().Write(expectedConfig).Return(nil)
+		assert.NoError(t, New(mockConfigCtrl).DeleteProfiles([]string{"local"}))
+	})
+}
+
+func TestControllerGetProfileForExecutionFromEnvironment(t *testing.T) {
+	setEnvironment := func(t *testing.T, values map[string]string) func() {
+		oldValues := map[string]*string{}
+		for key, value := range values {
+			if oldValue, ok := os.LookupEnv(key); ok {
+				oldValues[key] = &oldValue
+			} else {
+				oldValues[key] = nil
+			}
+			assert.NoError(t, os.Setenv(key, value))
+		}
+		return func() {
+			for key, oldValue := range oldValues {
+				if oldValue == nil {
+					assert.NoError(t, os.Unsetenv(key))
+					continue
+				}
+				assert.NoError(t, os.Setenv(key, *oldValue))
+			}
+		}
+	}
+	getController := func(mockCtrl *gomock.Controller, profiles ...entity.Profile) Controller {
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{Profiles: profiles}, nil)
+		return New(mockConfigCtrl)
+	}
+	t.Run("empty fields are read from environment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setEnvironment(t, map[string]string{
+			environment.OPENSEARCH_ENDPOINT: "https://env:9200",
+			environment.OPENSEARCH_USER:     "env-user",
+			environment.OPENSEARCH_PASSWORD: "env-password",
+		})()
+		ctrl := getController(mockCtrl, entity.Profile{Name: "ci"})
+		p, ok, err := ctrl.GetProfileForExecution("ci")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.EqualValues(t, entity.Profile{
+			Name:     "ci",
+			Endpoint: "https://env:9200",
+			UserName: "env-user",
+			Password: "env-password",
+		}, p)
+	})
+	t.Run("profile values take precedence", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setEnvironment(t, map[string]string{
+			environment.OPENSEARCH_ENDPOINT: "https://env:9200",
+			environment.OPENSEARCH_USER:     "env-user",
+			environment.OPENSEARCH_PASSWORD: "env-password",
+		})()
+		ctrl := getController(mockCtrl, entity.Profile{Name: "ci", Endpoint: "https://localhost:9200", UserName: "admin"})
+		p, ok, err := ctrl.GetProfileForExecution("ci")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.EqualValues(t, entity.Profile{
+			Name:     "ci",
+			Endpoint: "https://localhost:9200",
+			UserName: "admin",
+			Password: "env-password",
+		}, p)
+	})
+	t.Run("credentials are not read for aws iam profile", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		defer setEnvironment(t, map[string]string{
+			environment.OPENSEARCH_USER:     "env-user",
+			environment.OPENSEARCH_PASSWORD: "env-password",
+		})()
+		profile := entity.Profile{Name: "iam", Endpoint: "https://localhost:9200", AWS: &entity.AWSIAM{ServiceName: "es"}}
+		ctrl := getController(mockCtrl, profile)
+		p, ok, err := ctrl.GetProfileForExecution("iam")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.EqualValues(t, profile, p)
+	})
+}
+
 func TestControllerCreateProfile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
@@ -284,6 +528,49 @@ func TestControllerCreateProfile(t *testing.T) {
 	})
 }
 
+func TestControllerCloneProfile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		expectedConfig := getSampleConfig()
+		staging := expectedConfig.Profiles[0]
+		staging.Name = "staging"
+		expectedConfig.Profiles = append(expectedConfig.Profiles, staging)
+		mockConfigCtrl.EXPECT().Write(expectedConfig).Return(nil)
+		ctrl := New(mockConfigCtrl)
+		assert.NoError(t, ctrl.CloneProfile("local", "staging"))
+	})
+	t.Run("source profile does not exist", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		ctrl := New(mockConfigCtrl)
+		err := ctrl.CloneProfile("prod", "staging")
+		assert.EqualError(t, err, "profile 'prod' does not exist")
+	})
+	t.Run("target profile already exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(getSampleConfig(), nil)
+		ctrl := New(mockConfigCtrl)
+		err := ctrl.CloneProfile("local", "default")
+		assert.EqualError(t, err, "profile default already exists")
+	})
+	t.Run("config controller read failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockConfigCtrl := config.NewMockController(mockCtrl)
+		mockConfigCtrl.EXPECT().Read().Return(entity.Config{}, errors.New("failed to read"))
+		ctrl := New(mockConfigCtrl)
+		err := ctrl.CloneProfile("local", "staging")
+		assert.EqualError(t, err, "failed to read")
+	})
+}
+
 func TestControllerDeleteProfile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
@@ -331,3 +618,80 @@ func TestControllerDeleteProfile(t *testing.T) {
 		assert.EqualError(t, err, "failed to write")
 	})
 }
+
+func TestControllerEncryptedPassword(t *testing.T) {
+	passphrase := func(value string) Passphrase {
+		return func() (string, error) {
+			return value, nil
+		}
+	}
+	createProfile := func(t *testing.T, password string) string {
+		f, err := ioutil.TempFile("", "config")
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		encrypted, err := secret.Encrypt(password, "passphrase")
+		assert.NoError(t, err)
+		ctrl := New(configctrl.New(f.Name()))
+		assert.NoError(t, ctrl.CreateProfile(entity.Profile{
+			Name:     "encrypted",
+			Endpoint: "https://localhost:9200",
+			UserName: "admin",
+			Password: encrypted,
+		}))
+		return f.Name()
+	}
+	t.Run("encrypt on write and decrypt on read", func(t *testing.T) {
+		path := createProfile(t, "admin-password")
+		defer func() {
+			assert.NoError(t, os.Remove(path))
+		}()
+		contents, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "password: "+secret.Prefix)
+		assert.NotContains(t, string(contents), "admin-password")
+		ctrl := NewWithPassphrase(configctrl.New(path), passphrase("passphrase"))
+		p, ok, err := ctrl.GetProfileForExecution("encrypted")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "admin-password", p.Password)
+	})
+	t.Run("passphrase is not provided", func(t *testing.T) {
+		path := createProfile(t, "admin-password")
+		defer func() {
+			assert.NoError(t, os.Remove(path))
+		}()
+		ctrl := NewWithPassphrase(configctrl.New(path), func() (string, error) {
+			return "", ErrPassphraseRequired
+		})
+		_, _, err := ctrl.GetProfileForExecution("encrypted")
+		assert.EqualError(t, err, "password of profile 'encrypted' is encrypted: passphrase is required, set OPENSEARCH_PASSPHRASE to decrypt it")
+	})
+	t.Run("wrong passphrase", func(t *testing.T) {
+		path := createProfile(t, "admin-password")
+		defer func() {
+			assert.NoError(t, os.Remove(path))
+		}()
+		ctrl := NewWithPassphrase(configctrl.New(path), passphrase("wrong"))
+		_, _, err := ctrl.GetProfileForExecution("encrypted")
+		assert.True(t, errors.Is(err, secret.ErrWrongPassphrase))
+	})
+	t.Run("passphrase from environment", func(t *testing.T) {
+		path := createProfile(t, "admin-password")
+		defer func() {
+			assert.NoError(t, os.Remove(path))
+		}()
+		oldValue, exists := os.LookupEnv(environment.OPENSEARCH_PASSPHRASE)
+		assert.NoError(t, os.Setenv(environment.OPENSEARCH_PASSPHRASE, "passphrase"))
+		defer func() {
+			if exists {
+				assert.NoError(t, os.Setenv(environment.OPENSEARCH_PASSPHRASE, oldValue))
+				return
+			}
+			assert.NoError(t, os.Unsetenv(environment.OPENSEARCH_PASSPHRASE))
+		}()
+		p, ok, err := New(configctrl.New(path)).GetProfileForExecution("encrypted")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "admin-password", p.Password)
+	})
+}
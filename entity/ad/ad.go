@@ -27,9 +27,14 @@ package ad
 
 import (
 	"encoding/json"
+	"fmt"
 	"opensearch-cli/entity"
+	"strings"
 )
 
+//IntervalUnits are units of time accepted by AD plugin for detection interval and window delay
+var IntervalUnits = []string{"Minutes", "Seconds"}
+
 //Feature structure for detector features
 type Feature struct {
 	Name             string          `json:"feature_name"`
@@ -48,6 +53,32 @@ type Interval struct {
 	Period Period `json:"period"`
 }
 
+//Validate checks whether unit of interval is one of IntervalUnits, and interval is not negative.
+//Zero interval is allowed only if allowZero is true. Field is used to identify interval in error
+func (i Interval) Validate(field string, allowZero bool) error {
+	if !isIntervalUnit(i.Period.Unit) {
+		return fmt.Errorf("%s has invalid unit: '%s', supported units are: %s",
+			field, i.Period.Unit, strings.Join(IntervalUnits, ", "))
+	}
+	if i.Period.Duration < 0 && allowZero {
+		return fmt.Errorf("%s must not be negative, found: %d", field, i.Period.Duration)
+	}
+	if i.Period.Duration <= 0 && !allowZero {
+		return fmt.Errorf("%s must be positive, found: %d", field, i.Period.Duration)
+	}
+	return nil
+}
+
+//isIntervalUnit returns true if unit is one of IntervalUnits, ignoring case like AD plugin
+func isIntervalUnit(unit string) bool {
+	for _, u := range IntervalUnits {
+		if strings.EqualFold(u, unit) {
+			return true
+		}
+	}
+	return false
+}
+
 //CreateDetector represents Detector creation request
 type CreateDetector struct {
 	Name        string          `json:"name"`
@@ -60,6 +91,14 @@ type CreateDetector struct {
 	Delay       Interval        `json:"window_delay"`
 }
 
+//Validate checks detector's detection interval and window delay, window delay can be zero
+func (d CreateDetector) Validate() error {
+	if err := d.Interval.Validate("detection_interval", false); err != nil {
+		return err
+	}
+	return d.Delay.Validate("window_delay", true)
+}
+
 //FeatureRequest represents feature request
 type FeatureRequest struct {
 	AggregationType []string `json:"aggregation_type"`
@@ -184,3 +223,39 @@ type UpdateDetectorUserInput DetectorOutput
 
 // UpdateDetector represents detector's settings updated by api
 type UpdateDetector CreateDetector
+
+//CreateResponse represents response of create detector api
+type CreateResponse struct {
+	ID              string          `json:"_id"`
+	Version         int64           `json:"_version"`
+	SeqNo           int64           `json:"_seq_no"`
+	PrimaryTerm     int64           `json:"_primary_term"`
+	AnomalyDetector AnomalyDetector `json:"anomaly_detector"`
+}
+
+//InitProgress represents progress of detector's initialization
+type InitProgress struct {
+	Percentage           string `json:"percentage"`
+	EstimatedMinutesLeft int32  `json:"estimated_minutes_left"`
+	NeededShingles       int32  `json:"needed_shingles"`
+}
+
+//ModelProfile represents model of detector hosted on a node
+type ModelProfile struct {
+	ModelID          string `json:"model_id"`
+	ModelSizeInBytes int64  `json:"model_size_in_bytes"`
+	NodeID           string `json:"node_id"`
+}
+
+//Profile represents detector's profile, only profile types which were requested are set
+type Profile struct {
+	State            string         `json:"state,omitempty"`
+	Error            string         `json:"error,omitempty"`
+	Models           []ModelProfile `json:"models,omitempty"`
+	ShingleSize      int32          `json:"shingle_size,omitempty"`
+	CoordinatingNode string         `json:"coordinating_node,omitempty"`
+	TotalSizeInBytes int64          `json:"total_size_in_bytes,omitempty"`
+	InitProgress     *InitProgress  `json:"init_progress,omitempty"`
+	TotalEntities    int64          `json:"total_entities,omitempty"`
+	ActiveEntities   int64          `json:"active_entities,omitempty"`
+}
@@ -268,3 +268,124 @@ func TestGetDetectorResponse(t *testing.T) {
 		assert.EqualValues(t, expected, actual)
 	})
 }
+
+func TestCreateResponseUnMarshalling(t *testing.T) {
+	t.Run("deserialization success", func(t *testing.T) {
+		responseJSON := `
+		{
+		  "_id": "m4ccEnIBTXsGi3mvMt9p",
+		  "_version": 1,
+		  "_seq_no": 0,
+		  "_primary_term": 1,
+		  "anomaly_detector": {
+			"name": "test-detector",
+			"description": "Test detector",
+			"time_field": "timestamp",
+			"indices": ["order*"],
+			"feature_attributes": [
+			  {
+				"feature_name": "total_order",
+				"feature_enabled": true,
+				"aggregation_query": {"total_order":{"sum":{"field":"value"}}}
+			  }
+			],
+			"detection_interval": {"period": {"interval": 1, "unit": "Minutes"}},
+			"window_delay": {"period": {"interval": 1, "unit": "Minutes"}},
+			"schema_version": 0,
+			"last_update_time": 1589441737319
+		  }
+		}`
+		var actual CreateResponse
+		assert.NoError(t, json.Unmarshal([]byte(responseJSON), &actual))
+		assert.EqualValues(t, "m4ccEnIBTXsGi3mvMt9p", actual.ID)
+		assert.EqualValues(t, 1, actual.Version)
+		assert.EqualValues(t, 1, actual.PrimaryTerm)
+		assert.EqualValues(t, "test-detector", actual.AnomalyDetector.Name)
+		assert.EqualValues(t, []string{"order*"}, actual.AnomalyDetector.Index)
+		assert.EqualValues(t, Interval{Period: Period{Duration: 1, Unit: "Minutes"}}, actual.AnomalyDetector.Interval)
+		assert.EqualValues(t, "total_order", actual.AnomalyDetector.Features[0].Name)
+		assert.EqualValues(t, 1589441737319, actual.AnomalyDetector.LastUpdateTime)
+	})
+}
+
+func TestProfileMarshalling(t *testing.T) {
+	t.Run("deserialization success", func(t *testing.T) {
+		responseJSON := `
+		{
+		  "state": "INIT",
+		  "models": [
+			{
+			  "model_id": "cneh7HEBHPICjJIdXdrR_model_rcf_2",
+			  "model_size_in_bytes": 4456448,
+			  "node_id": "VS29z70PSzOdHiFEw4SThQ"
+			}
+		  ],
+		  "shingle_size": 8,
+		  "coordinating_node": "VS29z70PSzOdHiFEw4SThQ",
+		  "total_size_in_bytes": 4456448,
+		  "init_progress": {
+			"percentage": "10%",
+			"estimated_minutes_left": 45,
+			"needed_shingles": 9
+		  }
+		}`
+		var actual Profile
+		assert.NoError(t, json.Unmarshal([]byte(responseJSON), &actual))
+		assert.EqualValues(t, Profile{
+			State: "INIT",
+			Models: []ModelProfile{
+				{
+					ModelID:          "cneh7HEBHPICjJIdXdrR_model_rcf_2",
+					ModelSizeInBytes: 4456448,
+					NodeID:           "VS29z70PSzOdHiFEw4SThQ",
+				},
+			},
+			ShingleSize:      8,
+			CoordinatingNode: "VS29z70PSzOdHiFEw4SThQ",
+			TotalSizeInBytes: 4456448,
+			InitProgress: &InitProgress{
+				Percentage:           "10%",
+				EstimatedMinutesLeft: 45,
+				NeededShingles:       9,
+			},
+		}, actual)
+	})
+	t.Run("serialization omits missing profile types", func(t *testing.T) {
+		actual, err := json.Marshal(Profile{State: "RUNNING"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"state":"RUNNING"}`, string(actual))
+	})
+}
+
+func TestCreateDetector_Validate(t *testing.T) {
+	interval := func(duration int32, unit string) Interval {
+		return Interval{Period: Period{Duration: duration, Unit: unit}}
+	}
+	t.Run("valid units", func(t *testing.T) {
+		detector := getCreateDetector()
+		assert.NoError(t, detector.Validate())
+		detector.Interval = interval(30, "Seconds")
+		detector.Delay = interval(0, "minutes")
+		assert.NoError(t, detector.Validate())
+	})
+	t.Run("invalid detection interval unit", func(t *testing.T) {
+		detector := getCreateDetector()
+		detector.Interval = interval(1, "Minuts")
+		assert.EqualError(t, detector.Validate(), "detection_interval has invalid unit: 'Minuts', supported units are: Minutes, Seconds")
+	})
+	t.Run("missing window delay unit", func(t *testing.T) {
+		detector := getCreateDetector()
+		detector.Delay = interval(1, "")
+		assert.EqualError(t, detector.Validate(), "window_delay has invalid unit: '', supported units are: Minutes, Seconds")
+	})
+	t.Run("zero detection interval", func(t *testing.T) {
+		detector := getCreateDetector()
+		detector.Interval = interval(0, "Minutes")
+		assert.EqualError(t, detector.Validate(), "detection_interval must be positive, found: 0")
+	})
+	t.Run("negative window delay", func(t *testing.T) {
+		detector := getCreateDetector()
+		detector.Delay = interval(-1, "Minutes")
+		assert.EqualError(t, detector.Validate(), "window_delay must not be negative, found: -1")
+	})
+}
@@ -27,5 +27,7 @@ package entity
 
 //Config represents config file structure
 type Config struct {
-	Profiles []Profile `yaml:"profiles"`
+	//DefaultProfile is name of profile used if profile is neither provided by flag nor by environment variable
+	DefaultProfile string    `yaml:"default_profile,omitempty"`
+	Profiles       []Profile `yaml:"profiles"`
 }
@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 )
 
 //RequestError contains more information that can be used by client to provide
@@ -37,6 +38,7 @@ type RequestError struct {
 	statusCode int
 	err        error
 	response   []byte
+	header     http.Header
 }
 
 //NewRequestError builds RequestError
@@ -48,6 +50,13 @@ func NewRequestError(statusCode int, body io.ReadCloser, err error) *RequestErro
 	}
 }
 
+//NewRequestErrorFromResponse builds RequestError from response's status code, body and headers
+func NewRequestErrorFromResponse(response *http.Response, err error) *RequestError {
+	requestErr := NewRequestError(response.StatusCode, response.Body, err)
+	requestErr.header = response.Header
+	return requestErr
+}
+
 //Error inherits error interface to pass as error
 func (r *RequestError) Error() string {
 	return r.err.Error()
@@ -68,6 +77,16 @@ func (r *RequestError) GetResponse() string {
 	return string(formattedResponse)
 }
 
+//Header to get response's headers, it is nil if error was not built from response
+func (r *RequestError) Header() http.Header {
+	return r.header
+}
+
+//GetResponseBody to get raw error response from OpenSearch
+func (r *RequestError) GetResponseBody() []byte {
+	return r.response
+}
+
 //getResponseBody to extract response body from OpenSearch server
 func getResponseBody(b io.Reader) []byte {
 	resBytes, err := ioutil.ReadAll(b)
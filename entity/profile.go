@@ -25,16 +25,38 @@
 
 package entity
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	//MaskedValue replaces secrets of profile which should not be displayed
+	MaskedValue = "********"
+	//PluginsPathPrefix is prefix of plugin APIs since OpenSearch 1.0
+	PluginsPathPrefix = "_plugins"
+	//OpenDistroPathPrefix is legacy prefix of plugin APIs, which is used by Open Distro and older OpenSearch clusters
+	OpenDistroPathPrefix = "_opendistro"
+	//AbsoluteIndexIdentifier is prefix of index name which should be used without profile's index prefix
+	AbsoluteIndexIdentifier = "/"
+	invalidIndexCharacters  = `\/*?"<>| ,#:`
+)
+
 type AWSIAM struct {
 	ProfileName string `yaml:"profile"`
 	ServiceName string `yaml:"service"`
 }
 
-//Trust contains file path for certificate and private key locations
+//Trust contains file path for certificate and private key locations, or their PEM encoded values
+//which are used instead of file path if provided
 type Trust struct {
 	CAFilePath                *string
 	ClientCertificateFilePath *string
 	ClientKeyFilePath         *string
+	CAPEM                     *string
+	ClientCertificatePEM      *string
+	ClientKeyPEM              *string
 }
 
 type Profile struct {
@@ -46,4 +68,235 @@ type Profile struct {
 	Certificate *Trust  `yaml:"certificate,omitempty"`
 	MaxRetry    *int    `yaml:"max_retry,omitempty"`
 	Timeout     *int64  `yaml:"timeout,omitempty"`
+	// JitterBackoff waits between retries as long as Retry-After header asks for, up to maximum wait, else, adds random jitter to exponential backoff
+	JitterBackoff bool `yaml:"jitter_backoff,omitempty"`
+	// Compression asks cluster for gzip compressed responses and decompresses them transparently
+	Compression bool `yaml:"compression,omitempty"`
+	// CompressRequest sends request body compressed with gzip, it enables Compression too.
+	// Streamed request bodies are sent uncompressed
+	CompressRequest bool `yaml:"compress_request,omitempty"`
+	// Token is sent as "Authorization: Bearer <token>", it takes precedence over APIKey and basic authentication
+	Token string `yaml:"token,omitempty"`
+	// APIKey is sent as "Authorization: ApiKey <key>", it takes precedence over basic authentication
+	APIKey string `yaml:"api_key,omitempty"`
+	// Insecure skips verification of cluster's certificate, it should be used only for testing
+	Insecure bool `yaml:"insecure,omitempty"`
+	// Proxy is url of proxy server to connect to cluster, it overrides HTTP_PROXY and HTTPS_PROXY environment variables
+	Proxy string `yaml:"proxy,omitempty"`
+	// MaxIdleConns is maximum number of idle connections kept open across all hosts, default is 100
+	MaxIdleConns *int `yaml:"max_idle_conns,omitempty"`
+	// MaxIdleConnsPerHost is maximum number of idle connections kept open for a host, default is 10
+	MaxIdleConnsPerHost *int `yaml:"max_idle_conns_per_host,omitempty"`
+	// UserAgent overrides default User-Agent header "opensearch-cli/<version>" sent with every request
+	UserAgent string `yaml:"user_agent,omitempty"`
+	// Endpoints are additional endpoints of the cluster, they are tried in order if Endpoint is unavailable
+	Endpoints []string `yaml:"endpoints,omitempty"`
+	// MaxResponseSize is maximum size of response read into memory in bytes, default is 100 MiB. Zero means unlimited
+	MaxResponseSize *int64 `yaml:"max_response_size,omitempty"`
+	// RedirectPolicy is either same-host, all or none, default is same-host. Authorization is not sent to other hosts
+	RedirectPolicy string `yaml:"redirect_policy,omitempty"`
+	// OpaqueID sends X-Opaque-Id header with every request, its value is generated once per invocation
+	// unless it is provided by --opaque-id
+	OpaqueID bool `yaml:"opaque_id,omitempty"`
+	// PluginPathPrefix is either _plugins or _opendistro. If it is not set, plugin APIs are called with _plugins
+	// prefix, and with _opendistro prefix if cluster responds with 404
+	PluginPathPrefix string `yaml:"plugin_path_prefix,omitempty"`
+	// IndexPrefix is prepended to index names given to index APIs and to indices of detectors.
+	// Index name starting with AbsoluteIndexIdentifier is used without prefix
+	IndexPrefix string `yaml:"index_prefix,omitempty"`
+}
+
+//PrefixIndex returns index name with profile's index prefix, name starting with AbsoluteIndexIdentifier is
+//returned without that identifier and without prefix. Every index of comma separated list is prefixed,
+//exclusion like -logs* keeps its leading -, and only index of cross cluster name like remote:logs is prefixed
+func (p *Profile) PrefixIndex(name string) string {
+	if strings.Contains(name, ",") {
+		names := strings.Split(name, ",")
+		for i, n := range names {
+			names[i] = p.PrefixIndex(n)
+		}
+		return strings.Join(names, ",")
+	}
+	if strings.HasPrefix(name, "-") {
+		return "-" + p.PrefixIndex(strings.TrimPrefix(name, "-"))
+	}
+	if strings.HasPrefix(name, AbsoluteIndexIdentifier) {
+		return strings.TrimPrefix(name, AbsoluteIndexIdentifier)
+	}
+	if i := strings.Index(name, ":"); i >= 0 {
+		return name[:i+1] + p.PrefixIndex(name[i+1:])
+	}
+	if len(p.IndexPrefix) == 0 || len(name) == 0 {
+		return name
+	}
+	return p.IndexPrefix + name
+}
+
+//PrefixIndices returns index names with profile's index prefix, see PrefixIndex
+func (p *Profile) PrefixIndices(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = p.PrefixIndex(name)
+	}
+	return result
+}
+
+//GetEndpoints returns Endpoint followed by additional Endpoints in order, without duplicates
+func (p *Profile) GetEndpoints() []string {
+	var endpoints []string
+	seen := map[string]bool{}
+	for _, endpoint := range append([]string{p.Endpoint}, p.Endpoints...) {
+		if len(endpoint) == 0 || seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+//Clone returns deep copy of profile with given name, the copy does not share any pointer or slice
+//with the original profile, hence either of them can be modified without affecting the other
+func (p *Profile) Clone(newName string) *Profile {
+	clone := *p
+	clone.Name = newName
+	if p.AWS != nil {
+		aws := *p.AWS
+		clone.AWS = &aws
+	}
+	if p.Certificate != nil {
+		clone.Certificate = &Trust{
+			CAFilePath:                cloneString(p.Certificate.CAFilePath),
+			ClientCertificateFilePath: cloneString(p.Certificate.ClientCertificateFilePath),
+			ClientKeyFilePath:         cloneString(p.Certificate.ClientKeyFilePath),
+			CAPEM:                     cloneString(p.Certificate.CAPEM),
+			ClientCertificatePEM:      cloneString(p.Certificate.ClientCertificatePEM),
+			ClientKeyPEM:              cloneString(p.Certificate.ClientKeyPEM),
+		}
+	}
+	clone.MaxRetry = cloneInt(p.MaxRetry)
+	clone.MaxIdleConns = cloneInt(p.MaxIdleConns)
+	clone.MaxIdleConnsPerHost = cloneInt(p.MaxIdleConnsPerHost)
+	clone.Timeout = cloneInt64(p.Timeout)
+	clone.MaxResponseSize = cloneInt64(p.MaxResponseSize)
+	if p.Endpoints != nil {
+		clone.Endpoints = append([]string{}, p.Endpoints...)
+	}
+	return &clone
+}
+
+//Masked returns copy of profile with password, token, API key and client key PEM replaced by MaskedValue,
+//secrets which are not set remain empty
+func (p *Profile) Masked() *Profile {
+	masked := p.Clone(p.Name)
+	maskString(&masked.Password)
+	maskString(&masked.Token)
+	maskString(&masked.APIKey)
+	if masked.Certificate != nil && masked.Certificate.ClientKeyPEM != nil {
+		maskString(masked.Certificate.ClientKeyPEM)
+	}
+	return masked
+}
+
+func maskString(value *string) {
+	if len(*value) > 0 {
+		*value = MaskedValue
+	}
+}
+
+func cloneString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	result := *value
+	return &result
+}
+
+func cloneInt(value *int) *int {
+	if value == nil {
+		return nil
+	}
+	result := *value
+	return &result
+}
+
+func cloneInt64(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+	result := *value
+	return &result
+}
+
+//Validate checks whether profile can be used to connect to cluster. It returns error if name is empty,
+//endpoint is not an absolute http or https url, or authentication settings are incomplete
+func (p *Profile) Validate() error {
+	if p == nil {
+		return fmt.Errorf("profile cannot be nil")
+	}
+	if len(p.Name) < 1 {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	endpoints := p.GetEndpoints()
+	if len(endpoints) == 0 {
+		endpoints = []string{""}
+	}
+	for _, endpoint := range endpoints {
+		if err := validateURL("endpoint", endpoint); err != nil {
+			return fmt.Errorf("profile %s has invalid endpoint: %w", p.Name, err)
+		}
+	}
+	if len(p.Proxy) > 0 {
+		if err := validateURL("proxy", p.Proxy); err != nil {
+			return fmt.Errorf("profile %s has invalid proxy: %w", p.Name, err)
+		}
+	}
+	if len(p.Password) > 0 && len(p.UserName) < 1 {
+		return fmt.Errorf("profile %s has password but no user name", p.Name)
+	}
+	if p.AWS != nil && len(p.UserName) > 0 {
+		return fmt.Errorf("profile %s cannot use both aws_iam and user name", p.Name)
+	}
+	if p.AWS != nil && len(p.AWS.ServiceName) < 1 {
+		return fmt.Errorf("profile %s has aws_iam without service name", p.Name)
+	}
+	if p.Certificate != nil {
+		hasCertificate := p.Certificate.ClientCertificateFilePath != nil || p.Certificate.ClientCertificatePEM != nil
+		hasKey := p.Certificate.ClientKeyFilePath != nil || p.Certificate.ClientKeyPEM != nil
+		if hasCertificate != hasKey {
+			return fmt.Errorf("profile %s must provide both client certificate and client key", p.Name)
+		}
+	}
+	switch p.PluginPathPrefix {
+	case "", PluginsPathPrefix, OpenDistroPathPrefix:
+	default:
+		return fmt.Errorf("profile %s has invalid plugin path prefix: %s, supported prefixes are: %s, %s",
+			p.Name, p.PluginPathPrefix, PluginsPathPrefix, OpenDistroPathPrefix)
+	}
+	if strings.ContainsAny(p.IndexPrefix, invalidIndexCharacters) || strings.IndexAny(p.IndexPrefix, "_-+") == 0 {
+		return fmt.Errorf("profile %s has invalid index prefix: %s, it cannot start with _, - or + or contain any of %s",
+			p.Name, p.IndexPrefix, invalidIndexCharacters)
+	}
+	return nil
+}
+
+//validateURL checks whether value is an absolute http or https url
+func validateURL(field string, value string) error {
+	if len(value) < 1 {
+		return fmt.Errorf("%s cannot be empty", field)
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%s: %s must start with http:// or https://", field, value)
+	}
+	if len(u.Host) < 1 {
+		return fmt.Errorf("%s: %s must contain host", field, value)
+	}
+	return nil
 }
@@ -26,26 +26,72 @@
 package ad
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"opensearch-cli/client"
 	"opensearch-cli/entity"
+	"opensearch-cli/entity/ad"
+	"opensearch-cli/entity/platform"
 	gw "opensearch-cli/gateway"
 	"opensearch-cli/mapper"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 )
 
 const (
-	baseURL           = "_plugins/_anomaly_detection/detectors"
-	startURLTemplate  = baseURL + "/%s/" + "_start"
-	stopURLTemplate   = baseURL + "/%s/" + "_stop"
-	searchURLTemplate = baseURL + "/_search"
-	deleteURLTemplate = baseURL + "/%s"
-	getURLTemplate    = baseURL + "/%s"
-	updateURLTemplate = baseURL + "/%s"
+	baseURL                  = "_plugins/_anomaly_detection/detectors"
+	startURLTemplate         = baseURL + "/%s/" + "_start"
+	stopURLTemplate          = baseURL + "/%s/" + "_stop"
+	searchURLTemplate        = baseURL + "/_search"
+	deleteURLTemplate        = baseURL + "/%s"
+	getURLTemplate           = baseURL + "/%s"
+	updateURLTemplate        = baseURL + "/%s"
+	previewURLTemplate       = baseURL + "/_preview"
+	previewByIDURLTemplate   = baseURL + "/%s/" + "_preview"
+	resultsSearchURLTemplate = baseURL + "/results/_search"
+	profileURLTemplate       = baseURL + "/%s/" + "_profile"
+	allProfileTypes          = "_all"
+	validateURLTemplate      = baseURL + "/_validate"
+	emptyValidationResponse  = "{}"
+	topAnomaliesURLTemplate  = baseURL + "/%s/" + "_topAnomalies"
+	historicalQueryParam     = "historical"
+	defaultSearchPageSize    = 20
+	detectorNameKeywordField = "name.keyword"
+	maxConcurrentRequests    = 5
+	countURLTemplate         = baseURL + "/count"
+	namePrefixQueryParam     = "name_prefix"
+	stateProfileType         = "state"
+	errorProfileType         = "error"
+	failedDetectorState      = "FAILED"
+	exportPageSize           = 100
+	detectorIDField          = "_id"
+	detectorNameField        = "name"
+	featureAttributesField   = "feature_attributes"
+	featureNameField         = "feature_name"
+	detectorIndicesField     = "indices"
+	ifSeqNoQueryParam        = "if_seq_no"
+	ifPrimaryTermQueryParam  = "if_primary_term"
 )
 
+//ErrDetectorConflict is returned if conditional update failed because detector was modified since it was read
+var ErrDetectorConflict = errors.New("detector update conflict")
+
+//SeqNoPrimaryTerm identifies the version of detector returned by GET, it is used to update detector only if
+//it wasn't modified since. Nil SeqNoPrimaryTerm updates detector unconditionally
+type SeqNoPrimaryTerm struct {
+	SeqNo       int64 `json:"_seq_no"`
+	PrimaryTerm int64 `json:"_primary_term"`
+}
+
 //go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_ad.go -package=mocks . Gateway
 
 // Gateway interface to AD Plugin
@@ -53,10 +99,34 @@ type Gateway interface {
 	CreateDetector(context.Context, interface{}) ([]byte, error)
 	StartDetector(context.Context, string) error
 	StopDetector(context.Context, string) (*string, error)
+	StopHistoricalDetector(context.Context, string) (*string, error)
 	DeleteDetector(context.Context, string) error
 	SearchDetector(context.Context, interface{}) ([]byte, error)
 	GetDetector(context.Context, string) ([]byte, error)
-	UpdateDetector(context.Context, string, interface{}) error
+	UpdateDetector(context.Context, string, interface{}, *SeqNoPrimaryTerm) error
+	PreviewDetector(context.Context, string, interface{}) ([]byte, error)
+	SearchResults(context.Context, interface{}) ([]byte, error)
+	SearchResultsAfter(context.Context, interface{}, []interface{}, []interface{}) ([]json.RawMessage, []interface{}, error)
+	ProfileDetector(context.Context, string, ...string) ([]byte, error)
+	GetDetectorState(context.Context, string) (string, error)
+	ValidateDetector(context.Context, interface{}, string) ([]byte, error)
+	TopAnomalies(context.Context, string, bool, interface{}) ([]byte, error)
+	SearchDetectorPaged(context.Context, interface{}, int, int) ([]byte, error)
+	GetDetectorByName(context.Context, string) ([]byte, error)
+	StartDetectors(context.Context, []string) (map[string]error, error)
+	StopDetectors(context.Context, []string) (map[string]error, error)
+	CountDetectors(context.Context, string) ([]byte, error)
+	WaitForDetectorState(context.Context, string, string, time.Duration) error
+	StartHistoricalDetector(context.Context, string, int64, int64) error
+	ExportDetectors(context.Context, io.Writer) error
+	ImportDetectors(context.Context, io.Reader, bool) (map[string]error, error)
+	DeleteDetectorsByQuery(context.Context, interface{}) ([]string, map[string]error, error)
+	AddDetectorFeature(context.Context, string, interface{}) error
+	RemoveDetectorFeature(context.Context, string, string) error
+	CreateOrUpdateDetector(context.Context, interface{}) ([]byte, error)
+	CreateDetectorTyped(context.Context, ad.CreateDetector) (*ad.CreateResponse, error)
+	GetDetectorTyped(context.Context, string) (*ad.DetectorResponse, error)
+	ProfileDetectorTyped(context.Context, string, ...string) (*ad.Profile, error)
 }
 
 type gateway struct {
@@ -69,9 +139,26 @@ func New(c *client.Client, p *entity.Profile) (Gateway, error) {
 	if err != nil {
 		return nil, err
 	}
+	// older clusters expose anomaly detection APIs with _opendistro prefix only
+	g.PluginPathFallback = true
 	return &gateway{*g}, nil
 }
 
+//buildDetectorURL builds url from template for given detector ID. ID is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildDetectorURL(template string, ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, fmt.Errorf("detector Id cannot be empty")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, ID)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(ID))
+	return endpoint, nil
+}
+
 func (g *gateway) buildCreateURL() (*url.URL, error) {
 	endpoint, err := gw.GetValidEndpoint(g.Profile)
 	if err != nil {
@@ -132,6 +219,16 @@ Sample Input:
  }
 }*/
 func (g *gateway) CreateDetector(ctx context.Context, payload interface{}) ([]byte, error) {
+	payload, err := g.prefixDetectorIndices(payload)
+	if err != nil {
+		return nil, err
+	}
+	return g.postDetector(ctx, payload)
+}
+
+//postDetector creates detector and returns response. Indices of payload are sent as they are, caller applies
+//profile's index prefix to indices given by user
+func (g *gateway) postDetector(ctx context.Context, payload interface{}) ([]byte, error) {
 	createURL, err := g.buildCreateURL()
 	if err != nil {
 		return nil, err
@@ -140,20 +237,39 @@ func (g *gateway) CreateDetector(ctx context.Context, payload interface{}) ([]by
 	if err != nil {
 		return nil, err
 	}
-	response, err := g.Call(detectorRequest, http.StatusCreated)
+	response, err := g.CallExpecting(detectorRequest, http.StatusOK, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
 	return response, nil
 }
 
-func (g *gateway) buildStartURL(ID string) (*url.URL, error) {
-	endpoint, err := gw.GetValidEndpoint(g.Profile)
+//prefixDetectorIndices returns detector with profile's index prefix applied to its indices. Payload is returned
+//as it is if profile doesn't have index prefix, or payload doesn't have indices as array of strings
+func (g *gateway) prefixDetectorIndices(payload interface{}) (interface{}, error) {
+	if len(g.Profile.IndexPrefix) == 0 || payload == nil {
+		return payload, nil
+	}
+	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
-	endpoint.Path = fmt.Sprintf(startURLTemplate, ID)
-	return endpoint, nil
+	var detector map[string]json.RawMessage
+	if err = json.Unmarshal(data, &detector); err != nil || detector == nil {
+		return payload, nil
+	}
+	var indices []string
+	if err = json.Unmarshal(detector[detectorIndicesField], &indices); err != nil || indices == nil {
+		return payload, nil
+	}
+	if detector[detectorIndicesField], err = json.Marshal(g.Profile.PrefixIndices(indices)); err != nil {
+		return nil, err
+	}
+	return detector, nil
+}
+
+func (g *gateway) buildStartURL(ID string) (*url.URL, error) {
+	return g.buildDetectorURL(startURLTemplate, ID)
 }
 
 // StartDetector Starts an anomaly detector job.
@@ -174,28 +290,79 @@ func (g *gateway) StartDetector(ctx context.Context, ID string) error {
 	return nil
 }
 
+/*StartHistoricalDetector Starts historical analysis of an anomaly detector for given date range in epoch milliseconds.
+It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_start
+Sample Input:
+{
+ "start_time": 1503168590000,
+ "end_time": 1617301324000
+}*/
+func (g *gateway) StartHistoricalDetector(ctx context.Context, ID string, startMillis, endMillis int64) error {
+	if startMillis >= endMillis {
+		return fmt.Errorf("start time: %d must be before end time: %d", startMillis, endMillis)
+	}
+	startURL, err := g.buildStartURL(ID)
+	if err != nil {
+		return err
+	}
+	payload := map[string]int64{
+		"start_time": startMillis,
+		"end_time":   endMillis,
+	}
+	detectorRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, startURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return err
+	}
+	_, err = g.Call(detectorRequest, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (g *gateway) buildStopURL(ID string) (*url.URL, error) {
-	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	return g.buildDetectorURL(stopURLTemplate, ID)
+}
+
+func (g *gateway) buildStopHistoricalURL(ID string) (*url.URL, error) {
+	endpoint, err := g.buildStopURL(ID)
 	if err != nil {
 		return nil, err
 	}
-	endpoint.Path = fmt.Sprintf(stopURLTemplate, ID)
+	endpoint.RawQuery = url.Values{historicalQueryParam: []string{"true"}}.Encode()
 	return endpoint, nil
 }
 
-// StopDetector Stops an anomaly detector job.
+// StopDetector Stops an anomaly detector job, and returns response message, which is nil if cluster
+// responded with empty body.
 // It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_stop
 func (g *gateway) StopDetector(ctx context.Context, ID string) (*string, error) {
 	stopURL, err := g.buildStopURL(ID)
 	if err != nil {
 		return nil, err
 	}
+	return g.stop(ctx, stopURL)
+}
+
+// StopHistoricalDetector Stops historical analysis of an anomaly detector, real-time detector job is not affected.
+// It returns response message, which is nil if cluster responded with empty body.
+// It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_stop?historical=true
+func (g *gateway) StopHistoricalDetector(ctx context.Context, ID string) (*string, error) {
+	stopURL, err := g.buildStopHistoricalURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.stop(ctx, stopURL)
+}
+
+//stop posts to given stop endpoint and returns response message, nil if body is empty
+func (g *gateway) stop(ctx context.Context, stopURL *url.URL) (*string, error) {
 	detectorRequest, err := g.BuildRequest(ctx, http.MethodPost, "", stopURL.String(), gw.GetDefaultHeaders())
 	if err != nil {
 		return nil, err
 	}
 	res, err := g.Call(detectorRequest, http.StatusOK)
-	if err != nil {
+	if err != nil || res == nil {
 		return nil, err
 	}
 	return mapper.StringToStringPtr(fmt.Sprintf("%s", res)), nil
@@ -237,12 +404,7 @@ func (g *gateway) SearchDetector(ctx context.Context, payload interface{}) ([]by
 }
 
 func (g *gateway) buildDeleteURL(ID string) (*url.URL, error) {
-	endpoint, err := gw.GetValidEndpoint(g.Profile)
-	if err != nil {
-		return nil, err
-	}
-	endpoint.Path = fmt.Sprintf(deleteURLTemplate, ID)
-	return endpoint, nil
+	return g.buildDetectorURL(deleteURLTemplate, ID)
 }
 
 // DeleteDetector Deletes a detector based on the detector_id.
@@ -264,12 +426,7 @@ func (g *gateway) DeleteDetector(ctx context.Context, ID string) error {
 }
 
 func (g *gateway) buildGetURL(ID string) (*url.URL, error) {
-	endpoint, err := gw.GetValidEndpoint(g.Profile)
-	if err != nil {
-		return nil, err
-	}
-	endpoint.Path = fmt.Sprintf(getURLTemplate, ID)
-	return endpoint, nil
+	return g.buildDetectorURL(getURLTemplate, ID)
 }
 
 // GetDetector Returns all information about a detector based on the detector_id.
@@ -290,17 +447,24 @@ func (g *gateway) GetDetector(ctx context.Context, ID string) ([]byte, error) {
 	return response, nil
 }
 
-func (g *gateway) buildUpdateURL(ID string) (*url.URL, error) {
-	endpoint, err := gw.GetValidEndpoint(g.Profile)
+func (g *gateway) buildUpdateURL(ID string, version *SeqNoPrimaryTerm) (*url.URL, error) {
+	endpoint, err := g.buildDetectorURL(updateURLTemplate, ID)
 	if err != nil {
 		return nil, err
 	}
-	endpoint.Path = fmt.Sprintf(updateURLTemplate, ID)
+	if version != nil {
+		endpoint.RawQuery = url.Values{
+			ifSeqNoQueryParam:       []string{strconv.FormatInt(version.SeqNo, 10)},
+			ifPrimaryTermQueryParam: []string{strconv.FormatInt(version.PrimaryTerm, 10)},
+		}.Encode()
+	}
 	return endpoint, nil
 }
 
 /*UpdateDetector Updates a detector with any changes, including the description or adding or removing of features.
-It calls http request: PUT _plugins/_anomaly_detection/detectors/<detectorId>
+If seq_no and primary_term are passed, detector is updated only if it wasn't modified since it was read,
+else ErrDetectorConflict is returned.
+It calls http request: PUT _plugins/_anomaly_detection/detectors/<detectorId>?if_seq_no=<seqNo>&if_primary_term=<primaryTerm>
 Sample Input:
 {
  "name": "test-detector",
@@ -349,18 +513,1011 @@ Sample Input:
    }
  }
 }*/
-func (g *gateway) UpdateDetector(ctx context.Context, ID string, payload interface{}) error {
-	updateURL, err := g.buildUpdateURL(ID)
+func (g *gateway) UpdateDetector(ctx context.Context, ID string, payload interface{}, version *SeqNoPrimaryTerm) error {
+	payload, err := g.prefixDetectorIndices(payload)
 	if err != nil {
 		return err
 	}
+	_, err = g.putDetector(ctx, ID, payload, version)
+	return err
+}
+
+//putDetector updates detector and returns response, detector is updated only if its version matches unless
+//version is nil. Indices of payload are sent as they are, caller applies profile's index prefix to indices given by user
+func (g *gateway) putDetector(ctx context.Context, ID string, payload interface{}, version *SeqNoPrimaryTerm) ([]byte, error) {
+	updateURL, err := g.buildUpdateURL(ID, version)
+	if err != nil {
+		return nil, err
+	}
 	detectorRequest, err := g.BuildRequest(ctx, http.MethodPut, payload, updateURL.String(), gw.GetDefaultHeaders())
 	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(detectorRequest, http.StatusOK)
+	var responseErr *gw.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusConflict {
+		return nil, fmt.Errorf("%w: detector %s was modified after it was read, get the latest detector and try again", ErrDetectorConflict, ID)
+	}
+	return response, err
+}
+
+func (g *gateway) buildPreviewURL(ID string) (*url.URL, error) {
+	// preview an existing detector if ID is provided, else, use detector from payload
+	if len(ID) > 0 {
+		return g.buildDetectorURL(previewByIDURLTemplate, ID)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = previewURLTemplate
+	return endpoint, nil
+}
+
+/*PreviewDetector Runs a detector against historical data without creating it and returns anomaly results.
+It calls http request: POST _plugins/_anomaly_detection/detectors/_preview
+If detector ID is provided, it calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_preview
+Sample Input:
+{
+ "period_start": 1612982516000,
+ "period_end": 1614278539000,
+ "detector": {
+   "name": "test-detector",
+   "time_field": "timestamp",
+   "indices": [
+     "order*"
+   ],
+   "feature_attributes": [
+     {
+       "feature_name": "total_order",
+       "feature_enabled": true,
+       "aggregation_query": {
+         "total_order": {
+           "sum": {
+             "field": "value"
+           }
+         }
+       }
+     }
+   ],
+   "detection_interval": {
+     "period": {
+       "interval": 1,
+       "unit": "Minutes"
+     }
+   }
+ }
+}*/
+func (g *gateway) PreviewDetector(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	previewURL, err := g.buildPreviewURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	previewRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, previewURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(previewRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (g *gateway) buildResultsSearchURL() (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = resultsSearchURLTemplate
+	return endpoint, nil
+}
+
+/*SearchResults Returns anomaly results for a search query.
+It calls http request: POST _plugins/_anomaly_detection/detectors/results/_search
+Sample Input:
+{
+ "query": {
+   "bool": {
+     "filter": [
+       {
+         "term": {
+           "detector_id": "m4ccEnIBTXsGi3mvMt9p"
+         }
+       },
+       {
+         "range": {
+           "data_start_time": {
+             "gte": 1612982516000,
+             "lte": 1614278539000,
+             "format": "epoch_millis"
+           }
+         }
+       }
+     ]
+   }
+ }
+}*/
+func (g *gateway) SearchResults(ctx context.Context, payload interface{}) ([]byte, error) {
+	searchURL, err := g.buildResultsSearchURL()
+	if err != nil {
+		return nil, err
+	}
+	searchRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, searchURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(searchRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+//buildSearchAfterPayload merges sort and search_after into search query
+func buildSearchAfterPayload(query interface{}, sort []interface{}, searchAfter []interface{}) (map[string]interface{}, error) {
+	if len(sort) == 0 {
+		return nil, fmt.Errorf("sort cannot be empty, search_after requires sorted results")
+	}
+	payload := map[string]interface{}{}
+	if query != nil {
+		queryBytes, err := json.Marshal(query)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(queryBytes, &payload); err != nil {
+			return nil, fmt.Errorf("search query must be a json object: %v", err)
+		}
+	}
+	if _, ok := payload["from"]; ok {
+		return nil, fmt.Errorf("from cannot be used with search_after")
+	}
+	payload["sort"] = sort
+	if len(searchAfter) > 0 {
+		payload["search_after"] = searchAfter
+	}
+	return payload, nil
+}
+
+/*SearchResultsAfter Returns a page of anomaly results for a search query sorted by sort, starting after
+searchAfter, which are sort values of last result of previous page, or empty for first page.
+It returns results and sort values of last result, to be passed as searchAfter to get next page,
+which are nil if page is empty. Unlike from and size, it can page beyond 10000 results.
+Sort should contain a unique tie breaker, else results with same sort values may be skipped.
+It calls http request: POST _plugins/_anomaly_detection/detectors/results/_search
+Sample Input:
+{
+ "size": 1000,
+ "query": {
+   "term": {
+     "detector_id": "m4ccEnIBTXsGi3mvMt9p"
+   }
+ },
+ "sort": [
+   {"data_start_time": "asc"},
+   {"entity_id": "asc"}
+ ],
+ "search_after": [1612982516000, "entity-1"]
+}*/
+func (g *gateway) SearchResultsAfter(ctx context.Context, query interface{}, sort []interface{}, searchAfter []interface{}) ([]json.RawMessage, []interface{}, error) {
+	payload, err := buildSearchAfterPayload(query, sort, searchAfter)
+	if err != nil {
+		return nil, nil, err
+	}
+	response, err := g.SearchResults(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	var data struct {
+		Hits struct {
+			Hits []json.RawMessage `json:"hits"`
+		} `json:"hits"`
+	}
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, nil, err
+	}
+	hits := data.Hits.Hits
+	if len(hits) == 0 {
+		return hits, nil, nil
+	}
+	var last struct {
+		Sort []interface{} `json:"sort"`
+	}
+	// numbers are kept as json.Number, so that long sort values are sent back without losing precision
+	decoder := json.NewDecoder(bytes.NewReader(hits[len(hits)-1]))
+	decoder.UseNumber()
+	if err = decoder.Decode(&last); err != nil {
+		return nil, nil, err
+	}
+	if len(last.Sort) == 0 {
+		return nil, nil, fmt.Errorf("search hit has no sort values")
+	}
+	return hits, last.Sort, nil
+}
+
+func (g *gateway) buildProfileURL(ID string, profileTypes []string) (*url.URL, error) {
+	endpoint, err := g.buildDetectorURL(profileURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	var types, escapedTypes []string
+	for _, profileType := range profileTypes {
+		if profileType == allProfileTypes {
+			endpoint.RawQuery = url.Values{allProfileTypes: []string{"true"}}.Encode()
+			return endpoint, nil
+		}
+		if len(profileType) > 0 {
+			types = append(types, profileType)
+			escapedTypes = append(escapedTypes, url.PathEscape(profileType))
+		}
+	}
+	if len(types) > 0 {
+		endpoint.Path = fmt.Sprintf("%s/%s", endpoint.Path, strings.Join(types, ","))
+		endpoint.RawPath = fmt.Sprintf("%s/%s", endpoint.RawPath, strings.Join(escapedTypes, ","))
+	}
+	return endpoint, nil
+}
+
+/*ProfileDetector Returns information related to the current state of the detector and memory usage,
+including current errors and shingle size, to help troubleshoot the detector.
+It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile
+To get specific profile types: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile/init_progress,models
+To get all profile types: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile?_all=true
+Sample Output:
+{
+  "state": "INIT",
+  "init_progress": {
+    "percentage": "10%",
+    "estimated_minutes_left": 45,
+    "needed_shingles": 9
+  }
+}*/
+func (g *gateway) ProfileDetector(ctx context.Context, ID string, profileTypes ...string) ([]byte, error) {
+	profileURL, err := g.buildProfileURL(ID, profileTypes)
+	if err != nil {
+		return nil, err
+	}
+	profileRequest, err := g.BuildRequest(ctx, http.MethodGet, "", profileURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(profileRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+/*GetDetectorState Returns state of detector's job, which is DISABLED, INIT, RUNNING or FAILED,
+without fetching remaining profile types.
+It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile/state
+Sample Output:
+RUNNING*/
+func (g *gateway) GetDetectorState(ctx context.Context, ID string) (string, error) {
+	response, err := g.ProfileDetector(ctx, ID, stateProfileType)
+	if err != nil {
+		return "", err
+	}
+	var profile struct {
+		State string `json:"state"`
+	}
+	if err = json.Unmarshal(response, &profile); err != nil {
+		return "", err
+	}
+	return profile.State, nil
+}
+
+func (g *gateway) buildValidateURL(aspect string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = validateURLTemplate
+	if len(aspect) > 0 {
+		endpoint.Path = fmt.Sprintf("%s/%s", validateURLTemplate, aspect)
+		endpoint.RawPath = fmt.Sprintf("%s/%s", validateURLTemplate, url.PathEscape(aspect))
+	}
+	return endpoint, nil
+}
+
+//isValidationIssues checks whether response lists issues of detector or model, instead of reporting request failure
+func isValidationIssues(response string) bool {
+	var issues map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(response), &issues); err != nil {
+		return false
+	}
+	_, detector := issues["detector"]
+	_, model := issues["model"]
+	return detector || model
+}
+
+/*ValidateDetector Validates detector configuration for issues that would prevent it from being created
+or its model from being trained, like sparse data. Empty aspect validates detector configuration,
+"model" validates whether model can be trained.
+It calls http request: POST _plugins/_anomaly_detection/detectors/_validate/<aspect>
+Sample Output if no issues were found:
+{}
+Sample Output:
+{
+  "detector": {
+    "feature_attributes": {
+      "message": "Feature has invalid query returning empty aggregated data: average_total_rev",
+      "sub_issues": {
+        "average_total_rev": "Feature has invalid query returning empty aggregated data"
+      }
+    }
+  }
+}*/
+func (g *gateway) ValidateDetector(ctx context.Context, payload interface{}, aspect string) ([]byte, error) {
+	validateURL, err := g.buildValidateURL(aspect)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err = g.prefixDetectorIndices(payload); err != nil {
+		return nil, err
+	}
+	validateRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, validateURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Execute(validateRequest)
+	if err != nil {
+		// plugin reports validation issues found in the request as bad request, return those issues to caller
+		r, ok := err.(*platform.RequestError)
+		if !ok || r.StatusCode() != http.StatusBadRequest || !isValidationIssues(r.GetResponse()) {
+			return nil, err
+		}
+		response = []byte(r.GetResponse())
+	}
+	if len(bytes.TrimSpace(response)) == 0 {
+		return []byte(emptyValidationResponse), nil
+	}
+	return response, nil
+}
+
+func (g *gateway) buildTopAnomaliesURL(ID string, historical bool) (*url.URL, error) {
+	endpoint, err := g.buildDetectorURL(topAnomaliesURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	if historical {
+		endpoint.RawQuery = url.Values{historicalQueryParam: []string{strconv.FormatBool(historical)}}.Encode()
+	}
+	return endpoint, nil
+}
+
+/*TopAnomalies Returns the entities with highest anomaly grade for a high cardinality detector.
+It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_topAnomalies
+If historical is true, it returns results of historical analysis:
+POST _plugins/_anomaly_detection/detectors/<detectorId>/_topAnomalies?historical=true
+Sample Input:
+{
+ "size": 3,
+ "category_field": ["ip"],
+ "order": "severity",
+ "start_time_ms": 1612982516000,
+ "end_time_ms": 1614278539000
+}*/
+func (g *gateway) TopAnomalies(ctx context.Context, ID string, historical bool, payload interface{}) ([]byte, error) {
+	topAnomaliesURL, err := g.buildTopAnomaliesURL(ID, historical)
+	if err != nil {
+		return nil, err
+	}
+	topAnomaliesRequest, err := g.BuildRequest(ctx, http.MethodPost, payload, topAnomaliesURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(topAnomaliesRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+//buildPagedSearchPayload merges from and size into search query
+func buildPagedSearchPayload(query interface{}, from int, size int) (map[string]interface{}, error) {
+	if from < 0 {
+		return nil, fmt.Errorf("from: %d cannot be negative", from)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("size: %d cannot be negative", size)
+	}
+	if size == 0 {
+		size = defaultSearchPageSize
+	}
+	payload := map[string]interface{}{}
+	if query != nil {
+		queryBytes, err := json.Marshal(query)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(queryBytes, &payload); err != nil {
+			return nil, fmt.Errorf("search query must be a json object: %v", err)
+		}
+	}
+	payload["from"] = from
+	payload["size"] = size
+	return payload, nil
+}
+
+// SearchDetectorPaged Returns a page of anomaly detectors for a search query, starting at from,
+// with at most size detectors. If size is zero, default page size of 20 will be used.
+// It calls http request: POST _plugins/_anomaly_detection/detectors/_search
+func (g *gateway) SearchDetectorPaged(ctx context.Context, query interface{}, from int, size int) ([]byte, error) {
+	payload, err := buildPagedSearchPayload(query, from, size)
+	if err != nil {
+		return nil, err
+	}
+	return g.SearchDetector(ctx, payload)
+}
+
+// GetDetectorByName Returns search hit of the detector whose name exactly matches given name.
+// It fails if no detector or more than one detector is found with given name.
+// It calls http request: POST _plugins/_anomaly_detection/detectors/_search
+func (g *gateway) GetDetectorByName(ctx context.Context, name string) ([]byte, error) {
+	if len(name) < 1 {
+		return nil, fmt.Errorf("detector name cannot be empty")
+	}
+	hits, err := g.searchDetectorsByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	switch len(hits) {
+	case 0:
+		return nil, fmt.Errorf("no detector found with name: %s", name)
+	case 1:
+		return hits[0], nil
+	default:
+		return nil, fmt.Errorf("%d detectors found with name: %s, expected only one", len(hits), name)
+	}
+}
+
+//searchDetectorsByName returns search hits of detectors whose name exactly matches given name
+func (g *gateway) searchDetectorsByName(ctx context.Context, name string) ([]json.RawMessage, error) {
+	payload := map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{
+				detectorNameKeywordField: name,
+			},
+		},
+	}
+	response, err := g.SearchDetector(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Hits struct {
+			Hits []json.RawMessage `json:"hits"`
+		} `json:"hits"`
+	}
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, err
+	}
+	return data.Hits.Hits, nil
+}
+
+//forEachDetector calls f for every detector ID using at most maxConcurrentRequests concurrent calls.
+//It stops scheduling new calls once ctx is done, and returns error for every detector ID.
+func forEachDetector(ctx context.Context, IDs []string, f func(context.Context, string) error) (map[string]error, error) {
+	result := gw.ForEachConcurrent(ctx, IDs, maxConcurrentRequests, f)
+	return result, ctx.Err()
+}
+
+// StartDetectors Starts anomaly detector jobs concurrently and returns error, if any, for every detector.
+// It returns error only if ctx is cancelled before all detectors are started.
+// It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_start
+func (g *gateway) StartDetectors(ctx context.Context, IDs []string) (map[string]error, error) {
+	return forEachDetector(ctx, IDs, g.StartDetector)
+}
+
+// StopDetectors Stops anomaly detector jobs concurrently and returns error, if any, for every detector.
+// It returns error only if ctx is cancelled before all detectors are stopped.
+// It calls http request: POST _plugins/_anomaly_detection/detectors/<detectorId>/_stop
+func (g *gateway) StopDetectors(ctx context.Context, IDs []string) (map[string]error, error) {
+	return forEachDetector(ctx, IDs, func(ctx context.Context, ID string) error {
+		_, err := g.StopDetector(ctx, ID)
 		return err
+	})
+}
+
+func (g *gateway) buildCountURL(namePrefix string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
 	}
-	_, err = g.Call(detectorRequest, http.StatusOK)
+	endpoint.Path = countURLTemplate
+	if len(namePrefix) > 0 {
+		endpoint.RawQuery = url.Values{namePrefixQueryParam: []string{namePrefix}}.Encode()
+	}
+	return endpoint, nil
+}
+
+/*CountDetectors Returns total number of anomaly detectors.
+It calls http request: GET _plugins/_anomaly_detection/detectors/count
+If name prefix is not empty, only detectors whose name starts with prefix are counted:
+GET _plugins/_anomaly_detection/detectors/count?name_prefix=<prefix>
+Sample Output:
+{
+ "count": 3,
+ "match": true
+}*/
+func (g *gateway) CountDetectors(ctx context.Context, namePrefix string) ([]byte, error) {
+	countURL, err := g.buildCountURL(namePrefix)
 	if err != nil {
+		return nil, err
+	}
+	countRequest, err := g.BuildRequest(ctx, http.MethodGet, "", countURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(countRequest, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// WaitForDetectorState Polls detector's profile every pollInterval until detector's state is target.
+// It fails if detector's state is FAILED, or ctx is done before detector reaches target state.
+// It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile/state,error
+func (g *gateway) WaitForDetectorState(ctx context.Context, ID string, target string, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		return fmt.Errorf("poll interval: %v must be positive", pollInterval)
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		response, err := g.ProfileDetector(ctx, ID, stateProfileType, errorProfileType)
+		if err != nil {
+			return err
+		}
+		var profile struct {
+			State string `json:"state"`
+			Error string `json:"error"`
+		}
+		if err = json.Unmarshal(response, &profile); err != nil {
+			return err
+		}
+		if profile.State == target {
+			return nil
+		}
+		if profile.State == failedDetectorState {
+			return fmt.Errorf("detector %s is in %s state: %s", ID, profile.State, profile.Error)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+//searchAllDetectors calls f for every detector matching query, fetching detectors page by page
+func (g *gateway) searchAllDetectors(ctx context.Context, query interface{}, f func(string, map[string]json.RawMessage) error) error {
+	iterator := NewDetectorIterator(g, query, exportPageSize)
+	for {
+		raw, ok, err := iterator.Next(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		var hit struct {
+			ID     string                     `json:"_id"`
+			Source map[string]json.RawMessage `json:"_source"`
+		}
+		if err = json.Unmarshal(raw, &hit); err != nil {
+			return err
+		}
+		if err = f(hit.ID, hit.Source); err != nil {
+			return err
+		}
+	}
+}
+
+/*ExportDetectors Writes configuration of every anomaly detector to w as newline delimited json,
+one detector per line. Detectors are fetched in pages sorted by name, and every line contains
+detector's source with its "_id" so that it can be imported later.
+It calls http request: POST _plugins/_anomaly_detection/detectors/_search
+Sample Output:
+{"_id":"m4ccEnIBTXsGi3mvMt9p","description":"Test detector","detection_interval":{...},"name":"test-detector",...}
+{"_id":"n4ccEnIBTXsGi3mvMt9q","description":"Another detector","detection_interval":{...},"name":"test-detector-2",...}*/
+func (g *gateway) ExportDetectors(ctx context.Context, w io.Writer) error {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{
+				detectorNameKeywordField: "asc",
+			},
+		},
+	}
+	return g.searchAllDetectors(ctx, query, func(ID string, source map[string]json.RawMessage) error {
+		if source == nil {
+			source = map[string]json.RawMessage{}
+		}
+		rawID, err := json.Marshal(ID)
+		if err != nil {
+			return err
+		}
+		source[detectorIDField] = rawID
+		line, err := json.Marshal(source)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(line))
 		return err
+	})
+}
+
+//serverGeneratedDetectorFields are fields set by the server, they are removed from imported detectors
+var serverGeneratedDetectorFields = []string{detectorIDField, "last_update_time", "schema_version"}
+
+//readExportedDetectors reads detectors from either newline delimited json or json array
+func readExportedDetectors(r io.Reader) ([]map[string]json.RawMessage, error) {
+	reader := bufio.NewReader(r)
+	var detectors []map[string]json.RawMessage
+	for {
+		first, err := reader.Peek(1)
+		if err == io.EOF {
+			return detectors, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if unicode.IsSpace(rune(first[0])) {
+			_, _ = reader.ReadByte()
+			continue
+		}
+		decoder := json.NewDecoder(reader)
+		if first[0] == '[' {
+			if err = decoder.Decode(&detectors); err != nil {
+				return nil, fmt.Errorf("failed to read detectors: %w", err)
+			}
+			return detectors, nil
+		}
+		for {
+			var detector map[string]json.RawMessage
+			err = decoder.Decode(&detector)
+			if err == io.EOF {
+				return detectors, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read detector %d: %w", len(detectors)+1, err)
+			}
+			detectors = append(detectors, detector)
+		}
 	}
-	return nil
+}
+
+//importDetector creates detector, or updates detector with same name if recreate is true
+func (g *gateway) importDetector(ctx context.Context, name string, detector map[string]json.RawMessage, recreate bool) error {
+	var err error
+	// exported indices already have profile's index prefix, hence, they are not prefixed again
+	if recreate && len(name) > 0 {
+		_, err = g.createOrUpdateDetector(ctx, detector)
+	} else {
+		_, err = g.postDetector(ctx, detector)
+	}
+	return err
+}
+
+/*ImportDetectors Creates detectors read from r, which contains either newline delimited json or json array
+of detectors as written by ExportDetectors. Server generated fields like "_id", "last_update_time" and
+"schema_version" are removed before detector is created. If recreate is true, detector which has same name
+as an existing detector updates that detector instead.
+It returns error, if any, for every detector by name. It fails only if detectors cannot be read from r.
+It calls http request: POST _plugins/_anomaly_detection/detectors
+If recreate is true, it also calls: POST _plugins/_anomaly_detection/detectors/_search
+and PUT _plugins/_anomaly_detection/detectors/<detectorId> for existing detectors*/
+func (g *gateway) ImportDetectors(ctx context.Context, r io.Reader, recreate bool) (map[string]error, error) {
+	detectors, err := readExportedDetectors(r)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]error, len(detectors))
+	for i, detector := range detectors {
+		var name string
+		if rawName, ok := detector[detectorNameField]; ok {
+			_ = json.Unmarshal(rawName, &name)
+		}
+		key := name
+		if len(key) == 0 {
+			key = fmt.Sprintf("detector %d", i+1)
+		}
+		for _, field := range serverGeneratedDetectorFields {
+			delete(detector, field)
+		}
+		if err := ctx.Err(); err != nil {
+			result[key] = err
+			continue
+		}
+		result[key] = g.importDetector(ctx, name, detector, recreate)
+	}
+	return result, ctx.Err()
+}
+
+//isDetectorNotRunning returns true if detector cannot be stopped because it is already stopped or was never started
+func isDetectorNotRunning(err error) bool {
+	var responseErr *gw.ResponseError
+	if !errors.As(err, &responseErr) {
+		return false
+	}
+	return responseErr.StatusCode == http.StatusBadRequest || responseErr.StatusCode == http.StatusNotFound
+}
+
+//stopAndDeleteDetector stops detector, if it is running, before deleting it
+func (g *gateway) stopAndDeleteDetector(ctx context.Context, ID string) error {
+	if _, err := g.StopDetector(ctx, ID); err != nil && !isDetectorNotRunning(err) {
+		return err
+	}
+	return g.DeleteDetector(ctx, ID)
+}
+
+/*DeleteDetectorsByQuery Deletes every anomaly detector matching search query. Since running detector cannot be
+deleted, every detector is stopped before it is deleted, detectors which are not running are deleted as it is.
+It returns IDs of deleted detectors and error for every detector that couldn't be deleted.
+It fails if detectors cannot be searched, or ctx is cancelled before all detectors are deleted.
+It calls http requests: POST _plugins/_anomaly_detection/detectors/_search
+POST _plugins/_anomaly_detection/detectors/<detectorId>/_stop
+DELETE _plugins/_anomaly_detection/detectors/<detectorId>
+Sample Input:
+{
+ "query": {
+   "prefix": {
+     "name.keyword": "test-"
+   }
+ }
+}*/
+func (g *gateway) DeleteDetectorsByQuery(ctx context.Context, query interface{}) ([]string, map[string]error, error) {
+	var IDs []string
+	err := g.searchAllDetectors(ctx, query, func(ID string, _ map[string]json.RawMessage) error {
+		IDs = append(IDs, ID)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := forEachDetector(ctx, IDs, g.stopAndDeleteDetector)
+	var deleted []string
+	errs := map[string]error{}
+	for _, ID := range IDs {
+		if result[ID] != nil {
+			errs[ID] = result[ID]
+			continue
+		}
+		deleted = append(deleted, ID)
+	}
+	return deleted, errs, err
+}
+
+//getDetectorConfig returns detector's configuration without server generated fields, so that it can be updated,
+//and its seq_no and primary_term if they are present in response
+func (g *gateway) getDetectorConfig(ctx context.Context, ID string) (map[string]json.RawMessage, *SeqNoPrimaryTerm, error) {
+	response, err := g.GetDetector(ctx, ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var data struct {
+		SeqNo       *int64                     `json:"_seq_no"`
+		PrimaryTerm *int64                     `json:"_primary_term"`
+		Detector    map[string]json.RawMessage `json:"anomaly_detector"`
+	}
+	if err = json.Unmarshal(response, &data); err != nil {
+		return nil, nil, err
+	}
+	detector := data.Detector
+	if detector == nil {
+		return nil, nil, fmt.Errorf("detector %s is not found in response", ID)
+	}
+	for _, field := range serverGeneratedDetectorFields {
+		delete(detector, field)
+	}
+	var version *SeqNoPrimaryTerm
+	if data.SeqNo != nil && data.PrimaryTerm != nil {
+		version = &SeqNoPrimaryTerm{SeqNo: *data.SeqNo, PrimaryTerm: *data.PrimaryTerm}
+	}
+	return detector, version, nil
+}
+
+//getFeatures returns features of detector in order
+func getFeatures(detector map[string]json.RawMessage) ([]map[string]json.RawMessage, error) {
+	var features []map[string]json.RawMessage
+	if raw, ok := detector[featureAttributesField]; ok {
+		if err := json.Unmarshal(raw, &features); err != nil {
+			return nil, err
+		}
+	}
+	return features, nil
+}
+
+//getFeatureName returns name of feature, it is empty if feature doesn't have valid name
+func getFeatureName(feature map[string]json.RawMessage) string {
+	var name string
+	_ = json.Unmarshal(feature[featureNameField], &name)
+	return name
+}
+
+//updateFeatures updates detector with given features, remaining fields of detector are sent as it is,
+//indices read from cluster are not prefixed again
+func (g *gateway) updateFeatures(ctx context.Context, ID string, detector map[string]json.RawMessage, features []map[string]json.RawMessage, version *SeqNoPrimaryTerm) error {
+	raw, err := json.Marshal(features)
+	if err != nil {
+		return err
+	}
+	detector[featureAttributesField] = raw
+	_, err = g.putDetector(ctx, ID, detector, version)
+	return err
+}
+
+/*AddDetectorFeature Appends feature to features of detector, remaining configuration of detector is not changed.
+It fails if detector already has a feature with same name.
+It calls http requests: GET _plugins/_anomaly_detection/detectors/<detectorId>
+PUT _plugins/_anomaly_detection/detectors/<detectorId>
+Sample Input:
+{
+ "feature_name": "total_sales",
+ "feature_enabled": true,
+ "aggregation_query": {
+   "total_sales": {
+     "sum": {
+       "field": "taxful_total_price"
+     }
+   }
+ }
+}*/
+func (g *gateway) AddDetectorFeature(ctx context.Context, ID string, feature interface{}) error {
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+	var newFeature map[string]json.RawMessage
+	if err = json.Unmarshal(data, &newFeature); err != nil {
+		return fmt.Errorf("invalid feature: %w", err)
+	}
+	name := getFeatureName(newFeature)
+	if len(name) == 0 {
+		return fmt.Errorf("feature name cannot be empty")
+	}
+	detector, version, err := g.getDetectorConfig(ctx, ID)
+	if err != nil {
+		return err
+	}
+	features, err := getFeatures(detector)
+	if err != nil {
+		return err
+	}
+	for _, f := range features {
+		if getFeatureName(f) == name {
+			return fmt.Errorf("feature %s already exists in detector %s", name, ID)
+		}
+	}
+	return g.updateFeatures(ctx, ID, detector, append(features, newFeature), version)
+}
+
+//RemoveDetectorFeature Removes feature with given name from features of detector, remaining configuration
+//of detector is not changed. It fails if detector doesn't have a feature with given name.
+//It calls http requests: GET _plugins/_anomaly_detection/detectors/<detectorId>
+//PUT _plugins/_anomaly_detection/detectors/<detectorId>
+func (g *gateway) RemoveDetectorFeature(ctx context.Context, ID string, featureName string) error {
+	if len(featureName) == 0 {
+		return fmt.Errorf("feature name cannot be empty")
+	}
+	detector, version, err := g.getDetectorConfig(ctx, ID)
+	if err != nil {
+		return err
+	}
+	features, err := getFeatures(detector)
+	if err != nil {
+		return err
+	}
+	remaining := make([]map[string]json.RawMessage, 0, len(features))
+	for _, f := range features {
+		if getFeatureName(f) != featureName {
+			remaining = append(remaining, f)
+		}
+	}
+	if len(remaining) == len(features) {
+		return fmt.Errorf("feature %s does not exist in detector %s", featureName, ID)
+	}
+	return g.updateFeatures(ctx, ID, detector, remaining, version)
+}
+
+/*CreateOrUpdateDetector Creates detector from payload, or updates existing detector with same name keeping its ID.
+It fails if payload doesn't have name, or more than one detector has same name.
+It calls http requests: POST _plugins/_anomaly_detection/detectors/_search
+POST _plugins/_anomaly_detection/detectors if detector doesn't exist
+PUT _plugins/_anomaly_detection/detectors/<detectorId> if detector exists
+Sample Output:
+{
+  "_id": "m4ccEnIBTXsGi3mvMt9p",
+  "_version": 2,
+  "anomaly_detector": {...}
+}*/
+func (g *gateway) CreateOrUpdateDetector(ctx context.Context, payload interface{}) ([]byte, error) {
+	payload, err := g.prefixDetectorIndices(payload)
+	if err != nil {
+		return nil, err
+	}
+	return g.createOrUpdateDetector(ctx, payload)
+}
+
+//createOrUpdateDetector creates or updates detector like CreateOrUpdateDetector, indices of payload are sent as they are
+func (g *gateway) createOrUpdateDetector(ctx context.Context, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var detector struct {
+		Name string `json:"name"`
+	}
+	if err = json.Unmarshal(data, &detector); err != nil {
+		return nil, fmt.Errorf("invalid detector: %w", err)
+	}
+	if len(detector.Name) == 0 {
+		return nil, fmt.Errorf("detector name cannot be empty")
+	}
+	hits, err := g.searchDetectorsByName(ctx, detector.Name)
+	if err != nil {
+		return nil, err
+	}
+	switch len(hits) {
+	case 0:
+		return g.postDetector(ctx, payload)
+	case 1:
+		var hit struct {
+			ID string `json:"_id"`
+		}
+		if err = json.Unmarshal(hits[0], &hit); err != nil {
+			return nil, err
+		}
+		return g.putDetector(ctx, hit.ID, payload, nil)
+	default:
+		return nil, fmt.Errorf("%d detectors found with name: %s, expected only one", len(hits), detector.Name)
+	}
+}
+
+//CreateDetectorTyped Creates an anomaly detector job like CreateDetector, using typed request and response.
+//Detection interval and window delay are validated before request is sent.
+//It calls http request: POST _plugins/_anomaly_detection/detectors
+func (g *gateway) CreateDetectorTyped(ctx context.Context, detector ad.CreateDetector) (*ad.CreateResponse, error) {
+	if err := detector.Validate(); err != nil {
+		return nil, err
+	}
+	response, err := g.CreateDetector(ctx, detector)
+	if err != nil {
+		return nil, err
+	}
+	var result ad.CreateResponse
+	if err = json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("invalid create detector response: %w", err)
+	}
+	return &result, nil
+}
+
+//GetDetectorTyped Returns anomaly detector like GetDetector, parsed into typed response.
+//It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>
+func (g *gateway) GetDetectorTyped(ctx context.Context, ID string) (*ad.DetectorResponse, error) {
+	response, err := g.GetDetector(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	var result ad.DetectorResponse
+	if err = json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("invalid get detector response: %w", err)
+	}
+	return &result, nil
+}
+
+//ProfileDetectorTyped Returns detector's profile like ProfileDetector, parsed into typed response.
+//It calls http request: GET _plugins/_anomaly_detection/detectors/<detectorId>/_profile/<profileTypes>
+func (g *gateway) ProfileDetectorTyped(ctx context.Context, ID string, profileTypes ...string) (*ad.Profile, error) {
+	response, err := g.ProfileDetector(ctx, ID, profileTypes...)
+	if err != nil {
+		return nil, err
+	}
+	var result ad.Profile
+	if err = json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("invalid detector profile response: %w", err)
+	}
+	return &result, nil
 }
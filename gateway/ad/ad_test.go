@@ -26,17 +26,27 @@
 package ad
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"opensearch-cli/client"
 	"opensearch-cli/client/mocks"
 	"opensearch-cli/entity"
 	"opensearch-cli/entity/ad"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -55,7 +65,7 @@ func getTestClient(t *testing.T, response string, code int, method string, actio
 		// Test request parameters
 		assert.Equal(t, req.URL.String(), "http://localhost:9200/_plugins/_anomaly_detection/detectors/id"+action)
 		assert.EqualValues(t, req.Method, method)
-		assert.EqualValues(t, len(req.Header), 2)
+		assert.EqualValues(t, len(req.Header), 3)
 		return &http.Response{
 			StatusCode: code,
 			// Send response to be tested
@@ -75,6 +85,7 @@ func TestGateway_StartDetector(t *testing.T) {
 		testClient := getTestClient(t, `connection failed`, 400, http.MethodPost, "/_start")
 
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -91,6 +102,7 @@ func TestGateway_StartDetector(t *testing.T) {
 		  "_primary_term" : 1
 		}`, 200, http.MethodPost, "/_start")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -100,12 +112,65 @@ func TestGateway_StartDetector(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+func TestGateway_StartHistoricalDetector(t *testing.T) {
+	ctx := context.Background()
+	t.Run("started successfully", func(t *testing.T) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id/_start", req.URL.String())
+			assert.EqualValues(t, http.MethodPost, req.Method)
+			body, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"start_time":1503168590000,"end_time":1617301324000}`, string(body))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"_id":"id"}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.StartHistoricalDetector(ctx, "id", 1503168590000, 1617301324000)
+		assert.NoError(t, err)
+	})
+	t.Run("start time is not before end time", func(t *testing.T) {
+		testClient := getTestClient(t, `{}`, 200, http.MethodPost, "/_start")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.StartHistoricalDetector(ctx, "id", 1617301324000, 1617301324000)
+		assert.EqualError(t, err, "start time: 1617301324000 must be before end time: 1617301324000")
+	})
+	t.Run("connection failed", func(t *testing.T) {
+		testClient := getTestClient(t, `connection failed`, 400, http.MethodPost, "/_start")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.StartHistoricalDetector(ctx, "id", 1503168590000, 1617301324000)
+		assert.EqualError(t, err, "connection failed")
+	})
+}
+
 func TestGateway_StopDetector(t *testing.T) {
 	ctx := context.Background()
 	t.Run("connection failed", func(t *testing.T) {
 		testClient := getTestClient(t, `connection failed`, 400, http.MethodPost, "/_stop")
 
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -117,6 +182,7 @@ func TestGateway_StopDetector(t *testing.T) {
 	t.Run("stop successfully", func(t *testing.T) {
 		testClient := getTestClient(t, `Stopped detector: id`, 200, http.MethodPost, "/_stop")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -126,6 +192,70 @@ func TestGateway_StopDetector(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, *res, "Stopped detector: id")
 	})
+	t.Run("stop with empty response", func(t *testing.T) {
+		for _, body := range []string{"", "  \n"} {
+			testClient := getTestClient(t, body, 200, http.MethodPost, "/_stop")
+			testGateway, err := New(testClient, &entity.Profile{
+				Name:     "test",
+				Endpoint: "http://localhost:9200",
+				UserName: "admin",
+				Password: "admin",
+			})
+			assert.NoError(t, err)
+			res, err := testGateway.StopDetector(ctx, "id")
+			assert.NoError(t, err)
+			assert.Nil(t, res)
+		}
+	})
+}
+
+func TestGateway_StopHistoricalDetector(t *testing.T) {
+	ctx := context.Background()
+	profile := &entity.Profile{
+		Name:     "test",
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+	t.Run("connection failed", func(t *testing.T) {
+		testClient := getTestClient(t, `connection failed`, 400, http.MethodPost, "/_stop?historical=true")
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		_, err = testGateway.StopHistoricalDetector(ctx, "id")
+		assert.EqualError(t, err, "connection failed")
+	})
+	t.Run("stop successfully", func(t *testing.T) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.EqualValues(t, "true", req.URL.Query().Get("historical"))
+			assert.EqualValues(t, "/_plugins/_anomaly_detection/detectors/id/_stop", req.URL.Path)
+			assert.EqualValues(t, http.MethodPost, req.Method)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`Stopped detector: id`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		res, err := testGateway.StopHistoricalDetector(ctx, "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "Stopped detector: id", *res)
+	})
+	t.Run("stop with empty response", func(t *testing.T) {
+		testClient := getTestClient(t, "", 200, http.MethodPost, "/_stop?historical=true")
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		res, err := testGateway.StopHistoricalDetector(ctx, "id")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+	t.Run("empty id", func(t *testing.T) {
+		testGateway, err := New(getTestClient(t, "", 200, http.MethodPost, ""), profile)
+		assert.NoError(t, err)
+		_, err = testGateway.StopHistoricalDetector(ctx, "")
+		assert.EqualError(t, err, "detector Id cannot be empty")
+	})
 }
 
 func TestGateway_DeleteDetector(t *testing.T) {
@@ -133,6 +263,7 @@ func TestGateway_DeleteDetector(t *testing.T) {
 	t.Run("connection failed", func(t *testing.T) {
 		testClient := getTestClient(t, `connection failed`, 400, http.MethodDelete, "")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -159,6 +290,7 @@ func TestGateway_DeleteDetector(t *testing.T) {
 		  "_primary_term" : 1
 		}`, 200, http.MethodDelete, "")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -176,6 +308,7 @@ func TestGateway_SearchDetector(t *testing.T) {
 
 		testClient := getSearchClient(t, responseData, 200)
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -194,6 +327,7 @@ func TestGateway_SearchDetector(t *testing.T) {
 
 		testClient := getSearchClient(t, []byte("No connection found"), 400)
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -216,6 +350,22 @@ func TestGateway_CreateDetector(t *testing.T) {
 
 		testClient := getCreateClient(t, responseData, 201)
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.CreateDetector(ctx, getCreateDetector())
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, responseData)
+	})
+
+	t.Run("create succeeded with 200", func(t *testing.T) {
+
+		testClient := getCreateClient(t, responseData, 200)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -230,6 +380,7 @@ func TestGateway_CreateDetector(t *testing.T) {
 
 		testClient := getCreateClient(t, []byte("No connection found"), 400)
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -250,7 +401,7 @@ func getSearchClient(t *testing.T, responseData []byte, code int) *client.Client
 		err := json.Unmarshal(resBytes, &body)
 		assert.NoError(t, err)
 		assert.EqualValues(t, body.Query.Match.Name, "detector-name")
-		assert.EqualValues(t, len(req.Header), 2)
+		assert.EqualValues(t, len(req.Header), 3)
 		return &http.Response{
 			StatusCode: code,
 			// Send response to be tested
@@ -307,7 +458,7 @@ func getCreateClient(t *testing.T, responseData []byte, code int) *client.Client
 		err := json.Unmarshal(resBytes, &body)
 		assert.NoError(t, err)
 		assert.Equal(t, getCreateDetector(), body)
-		assert.EqualValues(t, 2, len(req.Header))
+		assert.EqualValues(t, 3, len(req.Header))
 		return &http.Response{
 			StatusCode: code,
 			// Send response to be tested
@@ -325,6 +476,7 @@ func TestGateway_GetDetector(t *testing.T) {
 	t.Run("connection failed", func(t *testing.T) {
 		testClient := getTestClient(t, `connection failed`, 400, http.MethodGet, "")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -336,6 +488,7 @@ func TestGateway_GetDetector(t *testing.T) {
 	t.Run("get success", func(t *testing.T) {
 		testClient := getTestClient(t, string(helperLoadBytes(t, "get_result.json")), 200, http.MethodGet, "")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
@@ -345,6 +498,33 @@ func TestGateway_GetDetector(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, helperLoadBytes(t, "get_result.json"), resp)
 	})
+	t.Run("get gzip compressed response", func(t *testing.T) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.EqualValues(t, "gzip", req.Header.Get("Accept-Encoding"))
+			var compressed bytes.Buffer
+			writer := gzip.NewWriter(&compressed)
+			_, err := writer.Write(helperLoadBytes(t, "get_result.json"))
+			assert.NoError(t, err)
+			assert.NoError(t, writer.Close())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(&compressed),
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Request:    req,
+			}
+		})
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:        "test",
+			Endpoint:    "http://localhost:9200",
+			UserName:    "admin",
+			Password:    "admin",
+			Compression: true,
+		})
+		assert.NoError(t, err)
+		resp, err := testGateway.GetDetector(ctx, "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, helperLoadBytes(t, "get_result.json"), resp)
+	})
 }
 
 func TestGateway_UpdateDetector(t *testing.T) {
@@ -352,23 +532,1556 @@ func TestGateway_UpdateDetector(t *testing.T) {
 	t.Run("connection failed", func(t *testing.T) {
 		testClient := getTestClient(t, `connection failed`, 400, http.MethodPut, "")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		err = testGateway.UpdateDetector(ctx, "id", nil)
+		err = testGateway.UpdateDetector(ctx, "id", nil, nil)
 		assert.EqualError(t, err, "connection failed")
 	})
 	t.Run("update success", func(t *testing.T) {
 		testClient := getTestClient(t, "ok", 200, http.MethodPut, "")
 		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.UpdateDetector(ctx, "id", nil, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("conditional update", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id?if_primary_term=2&if_seq_no=15",
+			`{"_id":"id"}`, 200, http.MethodPut)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.UpdateDetector(ctx, "id", nil, &SeqNoPrimaryTerm{SeqNo: 15, PrimaryTerm: 2})
+		assert.NoError(t, err)
+	})
+	t.Run("conflict", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id?if_primary_term=2&if_seq_no=15",
+			`version conflict`, 409, http.MethodPut)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		err = testGateway.UpdateDetector(ctx, "id", nil, &SeqNoPrimaryTerm{SeqNo: 15, PrimaryTerm: 2})
+		assert.True(t, errors.Is(err, ErrDetectorConflict))
+		assert.EqualError(t, err, "detector update conflict: detector id was modified after it was read, get the latest detector and try again")
+	})
+}
+
+func getTestClientForURL(t *testing.T, url string, response string, code int, method string) *client.Client {
+	return mocks.NewTestClient(func(req *http.Request) *http.Response {
+		// Test request parameters
+		assert.Equal(t, url, req.URL.String())
+		assert.EqualValues(t, method, req.Method)
+		assert.EqualValues(t, 3, len(req.Header))
+		return &http.Response{
+			StatusCode: code,
+			// Send response to be tested
+			Body: ioutil.NopCloser(bytes.NewBufferString(response)),
+			// Must be set to non-nil value or it panics
+			Header:  make(http.Header),
+			Status:  "SOME OUTPUT",
+			Request: req,
+		}
+	})
+}
+
+func TestGateway_PreviewDetector(t *testing.T) {
+	ctx := context.Background()
+	payload := map[string]interface{}{
+		"period_start": 1612982516000,
+		"period_end":   1614278539000,
+	}
+	t.Run("preview detector from payload", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_preview",
+			`{"anomaly_result":[]}`, 200, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		payload["detector"] = getCreateDetector()
+		response, err := testGateway.PreviewDetector(ctx, "", payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"anomaly_result":[]}`, string(response))
+	})
+	t.Run("preview existing detector", func(t *testing.T) {
+		testClient := getTestClient(t, `{"anomaly_result":[]}`, 200, http.MethodPost, "/_preview")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.PreviewDetector(ctx, "id", payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"anomaly_result":[]}`, string(response))
+	})
+	t.Run("preview failed", func(t *testing.T) {
+		testClient := getTestClient(t, `detector not found`, 404, http.MethodPost, "/_preview")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:             "test",
+			Endpoint:         "http://localhost:9200",
+			UserName:         "admin",
+			Password:         "admin",
+			PluginPathPrefix: entity.PluginsPathPrefix,
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.PreviewDetector(ctx, "id", payload)
+		assert.EqualError(t, err, "detector not found")
+	})
+}
+
+func TestGateway_SearchResults(t *testing.T) {
+	ctx := context.Background()
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{
+						"range": map[string]interface{}{
+							"data_start_time": map[string]interface{}{
+								"gte": 1612982516000,
+								"lte": 1614278539000,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	t.Run("search results succeeded", func(t *testing.T) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/results/_search", req.URL.String())
+			assert.EqualValues(t, http.MethodPost, req.Method)
+			reqBytes, _ := ioutil.ReadAll(req.Body)
+			expected, _ := json.Marshal(query)
+			assert.JSONEq(t, string(expected), string(reqBytes))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"hits":{"hits":[]}}`)),
+				Header:     make(http.Header),
+				Status:     "SOME OUTPUT",
+				Request:    req,
+			}
+		})
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.SearchResults(ctx, query)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"hits":{"hits":[]}}`, string(response))
+	})
+	t.Run("search results failed", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/results/_search",
+			"No connection found", 400, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.SearchResults(ctx, query)
+		assert.EqualError(t, err, "No connection found")
+	})
+}
+
+func TestGateway_buildResultsSearchURL(t *testing.T) {
+	testGateway := &gateway{}
+	testGateway.Profile = &entity.Profile{Endpoint: "http://localhost:9200"}
+	actual, err := testGateway.buildResultsSearchURL()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/results/_search", actual.String())
+}
+
+func TestGateway_ProfileDetector(t *testing.T) {
+	ctx := context.Background()
+	profile := `{"state":"INIT","init_progress":{"percentage":"10%"}}`
+	t.Run("profile without types", func(t *testing.T) {
+		testClient := getTestClient(t, profile, 200, http.MethodGet, "/_profile")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ProfileDetector(ctx, "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, profile, string(response))
+	})
+	t.Run("profile with multiple types", func(t *testing.T) {
+		testClient := getTestClient(t, profile, 200, http.MethodGet, "/_profile/init_progress,models")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ProfileDetector(ctx, "id", "init_progress", "models")
+		assert.NoError(t, err)
+		assert.EqualValues(t, profile, string(response))
+	})
+	t.Run("profile with all types", func(t *testing.T) {
+		testClient := getTestClient(t, profile, 200, http.MethodGet, "/_profile?_all=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ProfileDetector(ctx, "id", "_all")
+		assert.NoError(t, err)
+		assert.EqualValues(t, profile, string(response))
+	})
+	t.Run("profile escapes detector id", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/a%2Fb/_profile",
+			profile, 200, http.MethodGet)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.ProfileDetector(ctx, "a/b")
+		assert.NoError(t, err)
+	})
+	t.Run("profile failed", func(t *testing.T) {
+		testClient := getTestClient(t, "detector not found", 404, http.MethodGet, "/_profile")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:             "test",
+			Endpoint:         "http://localhost:9200",
+			UserName:         "admin",
+			Password:         "admin",
+			PluginPathPrefix: entity.PluginsPathPrefix,
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.ProfileDetector(ctx, "id")
+		assert.EqualError(t, err, "detector not found")
+	})
+}
+
+func TestGateway_GetDetectorState(t *testing.T) {
+	ctx := context.Background()
+	t.Run("state", func(t *testing.T) {
+		testClient := getTestClient(t, `{"state":"RUNNING"}`, 200, http.MethodGet, "/_profile/state")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		state, err := testGateway.GetDetectorState(ctx, "id")
+		assert.NoError(t, err)
+		assert.Equal(t, "RUNNING", state)
+	})
+	t.Run("invalid profile", func(t *testing.T) {
+		testClient := getTestClient(t, `not json`, 200, http.MethodGet, "/_profile/state")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorState(ctx, "id")
+		assert.Error(t, err)
+	})
+	t.Run("profile failed", func(t *testing.T) {
+		testClient := getTestClient(t, "detector not found", 404, http.MethodGet, "/_profile/state")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:             "test",
+			Endpoint:         "http://localhost:9200",
+			UserName:         "admin",
+			Password:         "admin",
+			PluginPathPrefix: entity.PluginsPathPrefix,
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorState(ctx, "id")
+		assert.EqualError(t, err, "detector not found")
+	})
+}
+
+func TestGateway_LegacyPluginPath(t *testing.T) {
+	var paths []string
+	testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+		paths = append(paths, req.URL.Path)
+		code, response := 200, `{"state":"RUNNING"}`
+		if strings.HasPrefix(req.URL.Path, "/_plugins/") {
+			code, response = 404, "not found"
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+			Header:     make(http.Header),
+			Status:     "SOME OUTPUT",
+			Request:    req,
+		}
+	})
+	testGateway, err := New(testClient, &entity.Profile{
+		Name:     "test",
+		Endpoint: "http://localhost:9200",
+	})
+	assert.NoError(t, err)
+	state, err := testGateway.GetDetectorState(context.Background(), "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "RUNNING", state)
+	assert.Equal(t, []string{
+		"/_plugins/_anomaly_detection/detectors/id/_profile/state",
+		"/_opendistro/_anomaly_detection/detectors/id/_profile/state",
+	}, paths)
+}
+
+func TestGateway_ValidateDetector(t *testing.T) {
+	ctx := context.Background()
+	issues := `{
+  "detector": {
+    "indices": {
+      "message": "No data in the source index"
+    }
+  }
+}`
+	t.Run("no issues found", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate",
+			"{}", 200, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "{}", string(response))
+	})
+	t.Run("empty response", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate/model",
+			"", 200, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "model")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "{}", string(response))
+	})
+	t.Run("issues found", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate",
+			issues, 200, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, issues, string(response))
+	})
+	t.Run("issues found as client error", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate/model",
+			issues, 400, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		err = testGateway.UpdateDetector(ctx, "id", nil)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "model")
 		assert.NoError(t, err)
+		assert.EqualValues(t, issues, string(response))
+	})
+	t.Run("bad request without issues", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate",
+			`{"error":{"type":"parsing_exception","reason":"unknown field"},"status":400}`, 400, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "")
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate",
+			"Unauthorized", 401, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "")
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate",
+			`{"error":"no handler found for uri"}`, 404, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:             "test",
+			Endpoint:         "http://localhost:9200",
+			UserName:         "admin",
+			Password:         "admin",
+			PluginPathPrefix: entity.PluginsPathPrefix,
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.ValidateDetector(ctx, getCreateDetector(), "")
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+	t.Run("server error", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_validate",
+			"internal error", 500, http.MethodPost)
+		noRetry := 0
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+			MaxRetry: &noRetry,
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.ValidateDetector(ctx, getCreateDetector(), "")
+		assert.Error(t, err)
+	})
+}
+
+func TestGateway_TopAnomalies(t *testing.T) {
+	ctx := context.Background()
+	payload := map[string]interface{}{
+		"size":           3,
+		"category_field": []string{"ip"},
+		"order":          "severity",
+	}
+	buckets := `{"buckets":[{"key":{"ip":"1.2.3.4"},"doc_count":10,"max_anomaly_grade":0.8}]}`
+	t.Run("real-time top anomalies", func(t *testing.T) {
+		testClient := getTestClient(t, buckets, 200, http.MethodPost, "/_topAnomalies")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.TopAnomalies(ctx, "id", false, payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, buckets, string(response))
+	})
+	t.Run("historical top anomalies", func(t *testing.T) {
+		testClient := getTestClient(t, buckets, 200, http.MethodPost, "/_topAnomalies?historical=true")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.TopAnomalies(ctx, "id", true, payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, buckets, string(response))
+	})
+	t.Run("detector id is escaped", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/a%20b/_topAnomalies",
+			buckets, 200, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.TopAnomalies(ctx, "a b", false, payload)
+		assert.NoError(t, err)
+	})
+	t.Run("top anomalies failed", func(t *testing.T) {
+		testClient := getTestClient(t, "not a high cardinality detector", 400, http.MethodPost, "/_topAnomalies")
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.TopAnomalies(ctx, "id", false, payload)
+		assert.EqualError(t, err, "not a high cardinality detector")
+	})
+}
+
+func TestGateway_SearchDetectorPaged(t *testing.T) {
+	ctx := context.Background()
+	query := ad.SearchRequest{
+		Query: ad.SearchQuery{
+			Match: ad.Match{
+				Name: "detector-name",
+			},
+		}}
+	getPagedClient := func(t *testing.T, expectedBody string) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_search", req.URL.String())
+			assert.EqualValues(t, http.MethodPost, req.Method)
+			reqBytes, _ := ioutil.ReadAll(req.Body)
+			assert.JSONEq(t, expectedBody, string(reqBytes))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"hits":{"hits":[]}}`)),
+				Header:     make(http.Header),
+				Status:     "SOME OUTPUT",
+				Request:    req,
+			}
+		})
+	}
+	t.Run("pagination merged into query", func(t *testing.T) {
+		testClient := getPagedClient(t, `{"query":{"match":{"name":"detector-name"}},"from":40,"size":10}`)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.SearchDetectorPaged(ctx, query, 40, 10)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"hits":{"hits":[]}}`, string(response))
+	})
+	t.Run("default size", func(t *testing.T) {
+		testClient := getPagedClient(t, `{"query":{"match":{"name":"detector-name"}},"from":0,"size":20}`)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.SearchDetectorPaged(ctx, query, 0, 0)
+		assert.NoError(t, err)
+	})
+	t.Run("page overrides pagination in query", func(t *testing.T) {
+		testClient := getPagedClient(t, `{"query":{"match_all":{}},"from":5,"size":5}`)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.SearchDetectorPaged(ctx, json.RawMessage(`{"query":{"match_all":{}},"size":1000}`), 5, 5)
+		assert.NoError(t, err)
+	})
+	t.Run("negative size", func(t *testing.T) {
+		testGateway, err := New(mocks.NewTestClient(nil), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.SearchDetectorPaged(ctx, query, 0, -1)
+		assert.EqualError(t, err, "size: -1 cannot be negative")
+	})
+}
+
+func TestGateway_GetDetectorByName(t *testing.T) {
+	ctx := context.Background()
+	getSearchByNameClient := func(t *testing.T, response string) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_search", req.URL.String())
+			assert.EqualValues(t, http.MethodPost, req.Method)
+			reqBytes, _ := ioutil.ReadAll(req.Body)
+			assert.JSONEq(t, `{"query":{"term":{"name.keyword":"detector-name"}}}`, string(reqBytes))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+				Header:     make(http.Header),
+				Status:     "SOME OUTPUT",
+				Request:    req,
+			}
+		})
+	}
+	hit := `{"_id":"id1","_source":{"name":"detector-name"}}`
+	t.Run("no match", func(t *testing.T) {
+		testGateway, err := New(getSearchByNameClient(t, `{"hits":{"hits":[]}}`), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorByName(ctx, "detector-name")
+		assert.EqualError(t, err, "no detector found with name: detector-name")
+	})
+	t.Run("one match", func(t *testing.T) {
+		testGateway, err := New(getSearchByNameClient(t, `{"hits":{"hits":[`+hit+`]}}`), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.GetDetectorByName(ctx, "detector-name")
+		assert.NoError(t, err)
+		assert.JSONEq(t, hit, string(response))
+	})
+	t.Run("many matches", func(t *testing.T) {
+		testGateway, err := New(getSearchByNameClient(t, `{"hits":{"hits":[`+hit+`,`+hit+`]}}`), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorByName(ctx, "detector-name")
+		assert.EqualError(t, err, "2 detectors found with name: detector-name, expected only one")
+	})
+	t.Run("empty name", func(t *testing.T) {
+		testGateway, err := New(mocks.NewTestClient(nil), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorByName(ctx, "")
+		assert.EqualError(t, err, "detector name cannot be empty")
+	})
+}
+
+func TestGateway_StartStopDetectors(t *testing.T) {
+	getBatchClient := func(t *testing.T, action string, failed map[string]bool) *client.Client {
+		return mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.EqualValues(t, http.MethodPost, req.Method)
+			assert.True(t, strings.HasSuffix(req.URL.Path, action))
+			// cluster exposes both _plugins and legacy _opendistro prefix
+			ID := strings.TrimPrefix(req.URL.Path, "/_plugins/_anomaly_detection/detectors/")
+			ID = strings.TrimSuffix(strings.TrimPrefix(ID, "/_opendistro/_anomaly_detection/detectors/"), action)
+			code, response := 200, "ok"
+			if failed[ID] {
+				code, response = 404, "detector "+ID+" not found"
+			}
+			return &http.Response{
+				StatusCode: code,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+				Header:     make(http.Header),
+				Status:     "SOME OUTPUT",
+				Request:    req,
+			}
+		})
+	}
+	IDs := []string{"id1", "id2", "id3", "id4", "id5", "id6", "id7"}
+	t.Run("start with mixed results", func(t *testing.T) {
+		testGateway, err := New(getBatchClient(t, "/_start", map[string]bool{"id2": true, "id6": true}), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+		})
+		assert.NoError(t, err)
+		result, err := testGateway.StartDetectors(context.Background(), IDs)
+		assert.NoError(t, err)
+		assert.Len(t, result, len(IDs))
+		for _, ID := range IDs {
+			if ID == "id2" || ID == "id6" {
+				assert.EqualError(t, result[ID], "detector "+ID+" not found")
+				continue
+			}
+			assert.NoError(t, result[ID])
+		}
+	})
+	t.Run("stop with mixed results", func(t *testing.T) {
+		testGateway, err := New(getBatchClient(t, "/_stop", map[string]bool{"id1": true}), &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+		})
+		assert.NoError(t, err)
+		result, err := testGateway.StopDetectors(context.Background(), IDs)
+		assert.NoError(t, err)
+		assert.Len(t, result, len(IDs))
+		assert.EqualError(t, result["id1"], "detector id1 not found")
+		assert.NoError(t, result["id7"])
+	})
+	t.Run("context cancelled mid-flight", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			if strings.Contains(req.URL.Path, "/id1/") {
+				cancel()
+			} else {
+				// hold remaining slots until batch is cancelled
+				<-req.Context().Done()
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("ok")),
+				Header:     make(http.Header),
+				Status:     "SOME OUTPUT",
+				Request:    req,
+			}
+		})
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+		})
+		assert.NoError(t, err)
+		result, err := testGateway.StartDetectors(ctx, IDs)
+		assert.EqualError(t, err, context.Canceled.Error())
+		assert.Len(t, result, len(IDs))
+		assert.EqualError(t, result["id6"], context.Canceled.Error())
+		assert.EqualError(t, result["id7"], context.Canceled.Error())
+	})
+}
+
+func TestGateway_GetDetectorDeadline(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	testGateway, err := New(testClient, &entity.Profile{
+		Name:     "test",
+		Endpoint: server.URL,
+		UserName: "admin",
+		Password: "admin",
+	})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = testGateway.GetDetector(ctx, "id")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestGateway_buildDetectorURL(t *testing.T) {
+	testGateway := &gateway{}
+	testGateway.Profile = &entity.Profile{Endpoint: "http://localhost:9200"}
+	builders := map[string]func(string) (*url.URL, error){
+		"":        testGateway.buildGetURL,
+		"/_start": testGateway.buildStartURL,
+		"/_stop":  testGateway.buildStopURL,
+	}
+	for action, build := range builders {
+		t.Run("escape slash"+action, func(t *testing.T) {
+			actual, err := build("id/_search")
+			assert.NoError(t, err)
+			assert.EqualValues(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id%2F_search"+action, actual.String())
+		})
+		t.Run("escape space and query"+action, func(t *testing.T) {
+			actual, err := build("my id?pretty")
+			assert.NoError(t, err)
+			assert.EqualValues(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/my%20id%3Fpretty"+action, actual.String())
+			assert.Empty(t, actual.RawQuery)
+		})
+		t.Run("empty id"+action, func(t *testing.T) {
+			_, err := build("")
+			assert.EqualError(t, err, "detector Id cannot be empty")
+		})
+	}
+	t.Run("delete and update", func(t *testing.T) {
+		deleteURL, err := testGateway.buildDeleteURL("a/b c")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/a%2Fb%20c", deleteURL.String())
+		updateURL, err := testGateway.buildUpdateURL("a/b c", nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/a%2Fb%20c", updateURL.String())
+		_, err = testGateway.buildDeleteURL("")
+		assert.EqualError(t, err, "detector Id cannot be empty")
+		_, err = testGateway.buildUpdateURL("", nil)
+		assert.EqualError(t, err, "detector Id cannot be empty")
+	})
+	t.Run("gateway rejects empty id", func(t *testing.T) {
+		g, err := New(mocks.NewTestClient(nil), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		err = g.StartDetector(context.Background(), "")
+		assert.EqualError(t, err, "detector Id cannot be empty")
+		_, err = g.GetDetector(context.Background(), "")
+		assert.EqualError(t, err, "detector Id cannot be empty")
+	})
+}
+
+func TestGateway_CountDetectors(t *testing.T) {
+	ctx := context.Background()
+	t.Run("count all detectors", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/count",
+			`{"count":3,"match":true}`, 200, http.MethodGet)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.CountDetectors(ctx, "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"count":3,"match":true}`, string(response))
+	})
+	t.Run("count detectors with name prefix", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/count?name_prefix=test+detector",
+			`{"count":1,"match":true}`, 200, http.MethodGet)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.CountDetectors(ctx, "test detector")
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"count":1,"match":true}`, string(response))
+	})
+	t.Run("no detectors found", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/count",
+			`{"count":0,"match":false}`, 200, http.MethodGet)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		response, err := testGateway.CountDetectors(ctx, "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"count":0,"match":false}`, string(response))
+	})
+	t.Run("count failed", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/count",
+			"No connection found", 400, http.MethodGet)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, err = testGateway.CountDetectors(ctx, "")
+		assert.EqualError(t, err, "No connection found")
+	})
+}
+
+func TestGateway_WaitForDetectorState(t *testing.T) {
+	getProfileServer := func(states ...string) (*httptest.Server, *int32) {
+		var polls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.EqualValues(t, "/_plugins/_anomaly_detection/detectors/id/_profile/state,error", r.URL.Path)
+			poll := int(atomic.AddInt32(&polls, 1))
+			if poll > len(states) {
+				poll = len(states)
+			}
+			_, _ = w.Write([]byte(states[poll-1]))
+		}))
+		return server, &polls
+	}
+	getGateway := func(t *testing.T, endpoint string) Gateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: endpoint,
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		return testGateway
+	}
+	t.Run("detector transitions to running", func(t *testing.T) {
+		server, polls := getProfileServer(`{"state":"DISABLED"}`, `{"state":"INIT"}`, `{"state":"RUNNING"}`)
+		defer server.Close()
+		err := getGateway(t, server.URL).WaitForDetectorState(context.Background(), "id", "RUNNING", time.Millisecond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, atomic.LoadInt32(polls))
+	})
+	t.Run("detector failed", func(t *testing.T) {
+		server, polls := getProfileServer(`{"state":"INIT"}`, `{"state":"FAILED","error":"no data in the index"}`)
+		defer server.Close()
+		err := getGateway(t, server.URL).WaitForDetectorState(context.Background(), "id", "RUNNING", time.Millisecond)
+		assert.EqualError(t, err, "detector id is in FAILED state: no data in the index")
+		assert.EqualValues(t, 2, atomic.LoadInt32(polls))
+	})
+	t.Run("stop waiting if context deadline exceeded", func(t *testing.T) {
+		server, _ := getProfileServer(`{"state":"INIT"}`)
+		defer server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := getGateway(t, server.URL).WaitForDetectorState(ctx, "id", "RUNNING", 10*time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("invalid poll interval", func(t *testing.T) {
+		err := getGateway(t, "http://localhost:9200").WaitForDetectorState(context.Background(), "id", "RUNNING", 0)
+		assert.EqualError(t, err, "poll interval: 0s must be positive")
+	})
+}
+
+func TestGateway_ExportDetectors(t *testing.T) {
+	getSearchServer := func(t *testing.T, total int) (*httptest.Server, *int32) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			assert.EqualValues(t, "/_plugins/_anomaly_detection/detectors/_search", r.URL.Path)
+			var payload struct {
+				From int                      `json:"from"`
+				Size int                      `json:"size"`
+				Sort []map[string]interface{} `json:"sort"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.EqualValues(t, []map[string]interface{}{{"name.keyword": "asc"}}, payload.Sort)
+			var hits []string
+			for i := payload.From; i < total && i < payload.From+payload.Size; i++ {
+				hits = append(hits, fmt.Sprintf(`{"_id":"id-%d","_source":{"name":"detector-%d"}}`, i, i))
+			}
+			_, _ = fmt.Fprintf(w, `{"hits":{"total":{"value":%d},"hits":[%s]}}`, total, strings.Join(hits, ","))
+		}))
+		return server, &requests
+	}
+	getGateway := func(t *testing.T, endpoint string) Gateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: endpoint,
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		return testGateway
+	}
+	getExportedLines := func(t *testing.T, buffer *bytes.Buffer) []map[string]string {
+		var result []map[string]string
+		scanner := bufio.NewScanner(buffer)
+		for scanner.Scan() {
+			var detector map[string]string
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &detector))
+			result = append(result, detector)
+		}
+		return result
+	}
+	t.Run("export multiple pages", func(t *testing.T) {
+		server, requests := getSearchServer(t, 230)
+		defer server.Close()
+		var buffer bytes.Buffer
+		assert.NoError(t, getGateway(t, server.URL).ExportDetectors(context.Background(), &buffer))
+		detectors := getExportedLines(t, &buffer)
+		assert.Len(t, detectors, 230)
+		assert.EqualValues(t, map[string]string{"_id": "id-0", "name": "detector-0"}, detectors[0])
+		assert.EqualValues(t, map[string]string{"_id": "id-229", "name": "detector-229"}, detectors[229])
+		assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+	})
+	t.Run("export full last page", func(t *testing.T) {
+		server, requests := getSearchServer(t, 200)
+		defer server.Close()
+		var buffer bytes.Buffer
+		assert.NoError(t, getGateway(t, server.URL).ExportDetectors(context.Background(), &buffer))
+		assert.Len(t, getExportedLines(t, &buffer), 200)
+		assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+	})
+	t.Run("export no detectors", func(t *testing.T) {
+		server, requests := getSearchServer(t, 0)
+		defer server.Close()
+		var buffer bytes.Buffer
+		assert.NoError(t, getGateway(t, server.URL).ExportDetectors(context.Background(), &buffer))
+		assert.Empty(t, buffer.String())
+		assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+	})
+	t.Run("search failed", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_search",
+			"No connection found", 400, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		var buffer bytes.Buffer
+		assert.EqualError(t, testGateway.ExportDetectors(context.Background(), &buffer), "No connection found")
+	})
+}
+
+func TestGateway_ImportDetectors(t *testing.T) {
+	type call struct {
+		method string
+		path   string
+		body   map[string]interface{}
+	}
+	getImportServer := func(t *testing.T, existing map[string]string) (*httptest.Server, *[]call) {
+		var mu sync.Mutex
+		var calls []call
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			calls = append(calls, call{r.Method, r.URL.Path, body})
+			mu.Unlock()
+			switch {
+			case r.URL.Path == "/_plugins/_anomaly_detection/detectors/_search":
+				name := body["query"].(map[string]interface{})["term"].(map[string]interface{})["name.keyword"].(string)
+				if ID, ok := existing[name]; ok {
+					_, _ = fmt.Fprintf(w, `{"hits":{"hits":[{"_id":"%s","_source":{"name":"%s"}}]}}`, ID, name)
+					return
+				}
+				_, _ = w.Write([]byte(`{"hits":{"hits":[]}}`))
+			case r.Method == http.MethodPost && body["name"] == "invalid":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`invalid detector`))
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"_id":"new-id"}`))
+			default:
+				_, _ = w.Write([]byte(`{"_id":"updated"}`))
+			}
+		}))
+		return server, &calls
+	}
+	getGateway := func(t *testing.T, endpoint string) Gateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: endpoint,
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		return testGateway
+	}
+	exported := `{"_id":"id-1","name":"first","last_update_time":1589441737319,"schema_version":0,"time_field":"timestamp"}
+{"_id":"id-2","name":"second","last_update_time":1589441737320,"schema_version":0,"time_field":"timestamp"}
+`
+	t.Run("create detectors from ndjson", func(t *testing.T) {
+		server, calls := getImportServer(t, map[string]string{"first": "existing-id"})
+		defer server.Close()
+		result, err := getGateway(t, server.URL).ImportDetectors(context.Background(), strings.NewReader(exported), false)
+		assert.NoError(t, err)
+		assert.EqualValues(t, map[string]error{"first": nil, "second": nil}, result)
+		assert.EqualValues(t, []call{
+			{http.MethodPost, "/_plugins/_anomaly_detection/detectors", map[string]interface{}{"name": "first", "time_field": "timestamp"}},
+			{http.MethodPost, "/_plugins/_anomaly_detection/detectors", map[string]interface{}{"name": "second", "time_field": "timestamp"}},
+		}, *calls)
+	})
+	t.Run("create detectors from array", func(t *testing.T) {
+		server, calls := getImportServer(t, nil)
+		defer server.Close()
+		input := `[{"_id":"id-1","name":"first"},{"_id":"id-2","name":"invalid"}]`
+		result, err := getGateway(t, server.URL).ImportDetectors(context.Background(), strings.NewReader(input), false)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.NoError(t, result["first"])
+		assert.EqualError(t, result["invalid"], "invalid detector")
+		assert.Len(t, *calls, 2)
+	})
+	t.Run("recreate existing detector", func(t *testing.T) {
+		server, calls := getImportServer(t, map[string]string{"first": "existing-id"})
+		defer server.Close()
+		result, err := getGateway(t, server.URL).ImportDetectors(context.Background(), strings.NewReader(exported), true)
+		assert.NoError(t, err)
+		assert.EqualValues(t, map[string]error{"first": nil, "second": nil}, result)
+		assert.Len(t, *calls, 4)
+		assert.EqualValues(t, call{http.MethodPut, "/_plugins/_anomaly_detection/detectors/existing-id",
+			map[string]interface{}{"name": "first", "time_field": "timestamp"}}, (*calls)[1])
+		assert.EqualValues(t, call{http.MethodPost, "/_plugins/_anomaly_detection/detectors",
+			map[string]interface{}{"name": "second", "time_field": "timestamp"}}, (*calls)[3])
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := getGateway(t, "http://localhost:9200").ImportDetectors(context.Background(), strings.NewReader(`{"name":`), false)
+		assert.Error(t, err)
+	})
+	t.Run("empty input", func(t *testing.T) {
+		result, err := getGateway(t, "http://localhost:9200").ImportDetectors(context.Background(), strings.NewReader("\n"), false)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestGateway_DeleteDetectorsByQuery(t *testing.T) {
+	getDeleteServer := func(t *testing.T, stopped map[string]bool, failDelete map[string]bool) (*httptest.Server, map[string][]string, *sync.Mutex) {
+		var mu sync.Mutex
+		operations := map[string][]string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/_plugins/_anomaly_detection/detectors/_search" {
+				_, _ = w.Write([]byte(`{"hits":{"hits":[{"_id":"id-1"},{"_id":"id-2"},{"_id":"id-3"}]}}`))
+				return
+			}
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/_plugins/_anomaly_detection/detectors/"), "/")
+			ID := parts[0]
+			operation := r.Method
+			if len(parts) > 1 {
+				operation = parts[1]
+			}
+			mu.Lock()
+			operations[ID] = append(operations[ID], operation)
+			mu.Unlock()
+			switch {
+			case operation == "_stop" && stopped[ID]:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"Anomaly detector job not exist: ` + ID + `"}`))
+			case operation == http.MethodDelete && failDelete[ID]:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`failed to delete ` + ID))
+			default:
+				_, _ = w.Write([]byte(`{}`))
+			}
+		}))
+		return server, operations, &mu
+	}
+	getGateway := func(t *testing.T, endpoint string) Gateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: endpoint,
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		return testGateway
+	}
+	query := map[string]interface{}{"query": map[string]interface{}{"prefix": map[string]interface{}{"name.keyword": "test-"}}}
+	t.Run("stop before delete", func(t *testing.T) {
+		server, operations, mu := getDeleteServer(t, map[string]bool{"id-2": true}, nil)
+		defer server.Close()
+		deleted, errs, err := getGateway(t, server.URL).DeleteDetectorsByQuery(context.Background(), query)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"id-1", "id-2", "id-3"}, deleted)
+		assert.Empty(t, errs)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ID := range deleted {
+			assert.EqualValues(t, []string{"_stop", http.MethodDelete}, operations[ID])
+		}
+	})
+	t.Run("partial failure", func(t *testing.T) {
+		server, _, _ := getDeleteServer(t, nil, map[string]bool{"id-3": true})
+		defer server.Close()
+		deleted, errs, err := getGateway(t, server.URL).DeleteDetectorsByQuery(context.Background(), query)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"id-1", "id-2"}, deleted)
+		assert.Len(t, errs, 1)
+		assert.EqualError(t, errs["id-3"], "failed to delete id-3")
+	})
+	t.Run("search failed", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/_search",
+			"No connection found", 400, http.MethodPost)
+		testGateway, err := New(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		_, _, err = testGateway.DeleteDetectorsByQuery(context.Background(), query)
+		assert.EqualError(t, err, "No connection found")
+	})
+}
+
+const featureTestDetector = `{
+  "_id": "id",
+  "_version": 1,
+  "_seq_no": 3,
+  "_primary_term": 1,
+  "anomaly_detector": {
+    "name": "test-detector",
+    "description": "Test detector",
+    "time_field": "timestamp",
+    "indices": ["order*"],
+    "feature_attributes": [
+      {
+        "feature_id": "feature1",
+        "feature_name": "total_order",
+        "feature_enabled": true,
+        "aggregation_query": {"total_order": {"sum": {"field": "value"}}}
+      }
+    ],
+    "detection_interval": {"period": {"interval": 1, "unit": "Minutes"}},
+    "last_update_time": 1589441737319,
+    "schema_version": 0
+  }
+}`
+
+//getFeatureTestClient returns detector on GET, and stores body of PUT request in updated
+func getFeatureTestClient(t *testing.T, updated *map[string]interface{}) *client.Client {
+	return mocks.NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "/_plugins/_anomaly_detection/detectors/id", req.URL.Path)
+		response := featureTestDetector
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			assert.Equal(t, "if_primary_term=1&if_seq_no=3", req.URL.RawQuery)
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(updated))
+			response = `{"_id":"id"}`
+		default:
+			t.Errorf("unexpected method: %s", req.Method)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+			Header:     make(http.Header),
+			Status:     "SOME OUTPUT",
+			Request:    req,
+		}
+	})
+}
+
+func TestGateway_AddDetectorFeature(t *testing.T) {
+	ctx := context.Background()
+	t.Run("append feature", func(t *testing.T) {
+		var detector map[string]interface{}
+		testGateway, err := New(getFeatureTestClient(t, &detector), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		feature := map[string]interface{}{
+			"feature_name":      "max_order",
+			"feature_enabled":   true,
+			"aggregation_query": map[string]interface{}{"max_order": map[string]interface{}{"max": map[string]interface{}{"field": "value"}}},
+		}
+		assert.NoError(t, testGateway.AddDetectorFeature(ctx, "id", feature))
+		assert.Equal(t, "test-detector", detector["name"])
+		assert.Equal(t, "Test detector", detector["description"])
+		assert.NotContains(t, detector, "last_update_time")
+		assert.NotContains(t, detector, "schema_version")
+		features := detector["feature_attributes"].([]interface{})
+		assert.Len(t, features, 2)
+		assert.Equal(t, "feature1", features[0].(map[string]interface{})["feature_id"])
+		assert.Equal(t, "total_order", features[0].(map[string]interface{})["feature_name"])
+		assert.Equal(t, "max_order", features[1].(map[string]interface{})["feature_name"])
+	})
+	t.Run("duplicate feature name", func(t *testing.T) {
+		testGateway, err := New(getFeatureTestClient(t, nil), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		err = testGateway.AddDetectorFeature(ctx, "id", map[string]interface{}{"feature_name": "total_order"})
+		assert.EqualError(t, err, "feature total_order already exists in detector id")
+	})
+	t.Run("feature without name", func(t *testing.T) {
+		testGateway, err := New(getFeatureTestClient(t, nil), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		err = testGateway.AddDetectorFeature(ctx, "id", map[string]interface{}{"feature_enabled": true})
+		assert.EqualError(t, err, "feature name cannot be empty")
+	})
+}
+
+func TestGateway_RemoveDetectorFeature(t *testing.T) {
+	ctx := context.Background()
+	t.Run("remove feature", func(t *testing.T) {
+		var detector map[string]interface{}
+		testGateway, err := New(getFeatureTestClient(t, &detector), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		assert.NoError(t, testGateway.RemoveDetectorFeature(ctx, "id", "total_order"))
+		assert.Equal(t, "test-detector", detector["name"])
+		assert.Equal(t, []interface{}{"order*"}, detector["indices"])
+		assert.NotContains(t, detector, "last_update_time")
+		assert.Equal(t, []interface{}{}, detector["feature_attributes"])
+	})
+	t.Run("missing feature", func(t *testing.T) {
+		testGateway, err := New(getFeatureTestClient(t, nil), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		err = testGateway.RemoveDetectorFeature(ctx, "id", "max_order")
+		assert.EqualError(t, err, "feature max_order does not exist in detector id")
+	})
+	t.Run("empty feature name", func(t *testing.T) {
+		testGateway, err := New(getFeatureTestClient(t, nil), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		assert.EqualError(t, testGateway.RemoveDetectorFeature(ctx, "id", ""), "feature name cannot be empty")
+	})
+}
+
+func TestGateway_CreateOrUpdateDetector(t *testing.T) {
+	ctx := context.Background()
+	getServer := func(t *testing.T, hits string, paths *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*paths = append(*paths, r.Method+" "+r.URL.Path)
+			switch {
+			case r.URL.Path == "/_plugins/_anomaly_detection/detectors/_search":
+				var body map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.EqualValues(t, "test-detector",
+					body["query"].(map[string]interface{})["term"].(map[string]interface{})["name.keyword"])
+				_, _ = fmt.Fprintf(w, `{"hits":{"hits":[%s]}}`, hits)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"_id":"new-id","_version":1}`))
+			default:
+				_, _ = w.Write([]byte(`{"_id":"existing-id","_version":2}`))
+			}
+		}))
+	}
+	getGateway := func(t *testing.T, endpoint string) Gateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{Name: "test", Endpoint: endpoint})
+		assert.NoError(t, err)
+		return testGateway
+	}
+	payload := map[string]interface{}{"name": "test-detector", "time_field": "timestamp"}
+	t.Run("create new detector", func(t *testing.T) {
+		var paths []string
+		server := getServer(t, "", &paths)
+		defer server.Close()
+		response, err := getGateway(t, server.URL).CreateOrUpdateDetector(ctx, payload)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"_id":"new-id","_version":1}`, string(response))
+		assert.EqualValues(t, []string{
+			"POST /_plugins/_anomaly_detection/detectors/_search",
+			"POST /_plugins/_anomaly_detection/detectors",
+		}, paths)
+	})
+	t.Run("update existing detector", func(t *testing.T) {
+		var paths []string
+		server := getServer(t, `{"_id":"existing-id","_source":{"name":"test-detector"}}`, &paths)
+		defer server.Close()
+		response, err := getGateway(t, server.URL).CreateOrUpdateDetector(ctx, payload)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"_id":"existing-id","_version":2}`, string(response))
+		assert.EqualValues(t, []string{
+			"POST /_plugins/_anomaly_detection/detectors/_search",
+			"PUT /_plugins/_anomaly_detection/detectors/existing-id",
+		}, paths)
+	})
+	t.Run("multiple detectors with same name", func(t *testing.T) {
+		var paths []string
+		server := getServer(t, `{"_id":"id-1"},{"_id":"id-2"}`, &paths)
+		defer server.Close()
+		_, err := getGateway(t, server.URL).CreateOrUpdateDetector(ctx, payload)
+		assert.EqualError(t, err, "2 detectors found with name: test-detector, expected only one")
+		assert.Len(t, paths, 1)
+	})
+	t.Run("missing name", func(t *testing.T) {
+		_, err := getGateway(t, "http://localhost:9200").CreateOrUpdateDetector(ctx, map[string]interface{}{"time_field": "timestamp"})
+		assert.EqualError(t, err, "detector name cannot be empty")
+	})
+}
+
+func TestGateway_TypedDetector(t *testing.T) {
+	ctx := context.Background()
+	profile := &entity.Profile{Name: "test", Endpoint: "http://localhost:9200", UserName: "admin", Password: "admin"}
+	t.Run("create detector", func(t *testing.T) {
+		testGateway, err := New(getCreateClient(t, helperLoadBytes(t, "create_result.json"), 201), profile)
+		assert.NoError(t, err)
+		response, err := testGateway.CreateDetectorTyped(ctx, getCreateDetector())
+		assert.NoError(t, err)
+		assert.EqualValues(t, "m4ccEnIBTXsGi3mvMt9p", response.ID)
+		assert.EqualValues(t, "test-detector", response.AnomalyDetector.Name)
+	})
+	t.Run("create detector failed", func(t *testing.T) {
+		testGateway, err := New(getCreateClient(t, []byte("failed"), 400), profile)
+		assert.NoError(t, err)
+		_, err = testGateway.CreateDetectorTyped(ctx, getCreateDetector())
+		assert.EqualError(t, err, "failed")
+	})
+	t.Run("create detector with invalid interval", func(t *testing.T) {
+		testGateway, err := New(mocks.NewTestClient(func(req *http.Request) *http.Response {
+			t.Errorf("unexpected request: %s", req.URL)
+			return nil
+		}), profile)
+		assert.NoError(t, err)
+		detector := getCreateDetector()
+		detector.Interval.Period.Unit = "Hours"
+		_, err = testGateway.CreateDetectorTyped(ctx, detector)
+		assert.EqualError(t, err, "detection_interval has invalid unit: 'Hours', supported units are: Minutes, Seconds")
+	})
+	t.Run("get detector", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id",
+			string(helperLoadBytes(t, "get_result.json")), 200, http.MethodGet)
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		response, err := testGateway.GetDetectorTyped(ctx, "id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "m4ccEnIBTXsGi3mvMt9p", response.ID)
+		assert.EqualValues(t, []string{"order*"}, response.AnomalyDetector.Index)
+		assert.EqualValues(t, "total_order", response.AnomalyDetector.Features[0].Name)
+	})
+	t.Run("get detector invalid response", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id",
+			`not json`, 200, http.MethodGet)
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		_, err = testGateway.GetDetectorTyped(ctx, "id")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid get detector response")
+	})
+	t.Run("profile detector", func(t *testing.T) {
+		testClient := getTestClientForURL(t, "http://localhost:9200/_plugins/_anomaly_detection/detectors/id/_profile/state,init_progress",
+			`{"state":"INIT","init_progress":{"percentage":"10%","estimated_minutes_left":45,"needed_shingles":9}}`, 200, http.MethodGet)
+		testGateway, err := New(testClient, profile)
+		assert.NoError(t, err)
+		response, err := testGateway.ProfileDetectorTyped(ctx, "id", "state", "init_progress")
+		assert.NoError(t, err)
+		assert.EqualValues(t, &ad.Profile{
+			State:        "INIT",
+			InitProgress: &ad.InitProgress{Percentage: "10%", EstimatedMinutesLeft: 45, NeededShingles: 9},
+		}, response)
+	})
+}
+
+func TestGateway_SearchResultsAfter(t *testing.T) {
+	ctx := context.Background()
+	query := map[string]interface{}{
+		"size":  2,
+		"query": map[string]interface{}{"term": map[string]interface{}{"detector_id": "id"}},
+	}
+	sort := []interface{}{
+		map[string]interface{}{"data_start_time": "asc"},
+		map[string]interface{}{"_seq_no": "asc"},
+	}
+	pages := map[string]string{
+		"":                                 `{"hits":{"hits":[{"_id":"r1","sort":[1612982516000,1]},{"_id":"r2","sort":[1612982576000,9007199254740993]}]}}`,
+		"[1612982576000,9007199254740993]": `{"hits":{"hits":[{"_id":"r3","sort":[1612982636000,3]}]}}`,
+		"[1612982636000,3]":                `{"hits":{"hits":[]}}`,
+	}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_plugins/_anomaly_detection/detectors/results/_search", r.URL.Path)
+		var body map[string]json.RawMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.JSONEq(t, `[{"data_start_time":"asc"},{"_seq_no":"asc"}]`, string(body["sort"]))
+		assert.JSONEq(t, `{"term":{"detector_id":"id"}}`, string(body["query"]))
+		assert.NotContains(t, body, "from")
+		cursor := string(body["search_after"])
+		requested = append(requested, cursor)
+		_, _ = w.Write([]byte(pages[cursor]))
+	}))
+	defer server.Close()
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	testGateway, err := New(testClient, &entity.Profile{Name: "test", Endpoint: server.URL})
+	assert.NoError(t, err)
+
+	t.Run("chain pages using sort values", func(t *testing.T) {
+		requested = nil
+		hits, after, err := testGateway.SearchResultsAfter(ctx, query, sort, nil)
+		assert.NoError(t, err)
+		assert.Len(t, hits, 2)
+		assert.EqualValues(t, []interface{}{json.Number("1612982576000"), json.Number("9007199254740993")}, after)
+
+		hits, after, err = testGateway.SearchResultsAfter(ctx, query, sort, after)
+		assert.NoError(t, err)
+		assert.Len(t, hits, 1)
+		assert.JSONEq(t, `{"_id":"r3","sort":[1612982636000,3]}`, string(hits[0]))
+
+		hits, after, err = testGateway.SearchResultsAfter(ctx, query, sort, after)
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+		assert.Nil(t, after)
+		assert.EqualValues(t, []string{"", "[1612982576000,9007199254740993]", "[1612982636000,3]"}, requested)
+	})
+	t.Run("sort is required", func(t *testing.T) {
+		_, _, err := testGateway.SearchResultsAfter(ctx, query, nil, nil)
+		assert.EqualError(t, err, "sort cannot be empty, search_after requires sorted results")
+	})
+	t.Run("from is rejected", func(t *testing.T) {
+		_, _, err := testGateway.SearchResultsAfter(ctx, map[string]interface{}{"from": 10}, sort, nil)
+		assert.EqualError(t, err, "from cannot be used with search_after")
+	})
+}
+
+func TestGateway_IndexPrefix(t *testing.T) {
+	ctx := context.Background()
+	getGateway := func(t *testing.T, indexPrefix string, expectedIndices string) Gateway {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			var body map[string]json.RawMessage
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.JSONEq(t, expectedIndices, string(body["indices"]))
+			assert.Equal(t, `"test-detector"`, string(body["name"]))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"_id":"id"}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		testGateway, err := New(testClient, &entity.Profile{Name: "test", Endpoint: "http://localhost:9200", IndexPrefix: indexPrefix})
+		assert.NoError(t, err)
+		return testGateway
+	}
+	detector := map[string]interface{}{
+		"name":    "test-detector",
+		"indices": []string{"orders*", "team-a-refunds", "/shared-orders", "remote:orders"},
+	}
+	const prefixed = `["team-a-orders*","team-a-team-a-refunds","shared-orders","remote:team-a-orders"]`
+	t.Run("create detector with prefixed indices", func(t *testing.T) {
+		_, err := getGateway(t, "team-a-", prefixed).CreateDetector(ctx, detector)
+		assert.NoError(t, err)
+	})
+	t.Run("update detector with prefixed indices", func(t *testing.T) {
+		err := getGateway(t, "team-a-", prefixed).UpdateDetector(ctx, "id", detector, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("indices read from cluster are not prefixed again", func(t *testing.T) {
+		var updated map[string]interface{}
+		testGateway, err := New(getFeatureTestClient(t, &updated), &entity.Profile{Name: "test", Endpoint: "http://localhost:9200", IndexPrefix: "team-a-"})
+		assert.NoError(t, err)
+		assert.NoError(t, testGateway.RemoveDetectorFeature(ctx, "id", "total_order"))
+		assert.EqualValues(t, []interface{}{"order*"}, updated["indices"])
+	})
+	t.Run("typed detector is prefixed", func(t *testing.T) {
+		typed := getCreateDetector()
+		typed.Name = "test-detector"
+		_, err := getGateway(t, "team-a-", `["team-a-order*"]`).CreateDetector(ctx, typed)
+		assert.NoError(t, err)
+	})
+	t.Run("indices are not changed without prefix", func(t *testing.T) {
+		_, err := getGateway(t, "", `["orders*","team-a-refunds","/shared-orders","remote:orders"]`).CreateDetector(ctx, detector)
+		assert.NoError(t, err)
+	})
+	t.Run("exported detectors are imported without prefixing indices again", func(t *testing.T) {
+		var imported []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]json.RawMessage
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if r.URL.Path == "/_plugins/_anomaly_detection/detectors/_search" {
+				if _, ok := body["sort"]; ok {
+					_, _ = w.Write([]byte(`{"hits":{"total":{"value":1},"hits":[{"_id":"id","_source":{"name":"test-detector","indices":["team-a-orders*"]}}]}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"hits":{"hits":[{"_id":"id","_source":{"name":"test-detector"}}]}}`))
+				return
+			}
+			imported = append(imported, r.Method+" "+string(body["indices"]))
+			_, _ = w.Write([]byte(`{"_id":"id"}`))
+		}))
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testGateway, err := New(testClient, &entity.Profile{Name: "test", Endpoint: server.URL, IndexPrefix: "team-a-"})
+		assert.NoError(t, err)
+		var exported bytes.Buffer
+		assert.NoError(t, testGateway.ExportDetectors(ctx, &exported))
+		for _, recreate := range []bool{false, true} {
+			result, err := testGateway.ImportDetectors(ctx, bytes.NewReader(exported.Bytes()), recreate)
+			assert.NoError(t, err)
+			assert.EqualValues(t, map[string]error{"test-detector": nil}, result)
+		}
+		assert.EqualValues(t, []string{`POST ["team-a-orders*"]`, `PUT ["team-a-orders*"]`}, imported)
 	})
 }
@@ -0,0 +1,79 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package ad
+
+import (
+	"context"
+	"encoding/json"
+)
+
+//DetectorIterator iterates lazily over search hits of detectors matching a query. Detectors are fetched
+//one page at a time using from and size, so at most one page is held in memory.
+//Like any from/size search, it can't go beyond index.max_result_window (10000 by default) hits.
+type DetectorIterator struct {
+	gateway  Gateway
+	query    interface{}
+	pageSize int
+	from     int
+	page     []json.RawMessage
+	done     bool
+}
+
+//NewDetectorIterator returns iterator over detectors matching query, fetched pageSize detectors per request.
+//If query is nil, every detector is returned. If pageSize is zero, default page size of 20 will be used.
+//Query should be sorted, else order of detectors may change between pages.
+func NewDetectorIterator(g Gateway, query interface{}, pageSize int) *DetectorIterator {
+	if pageSize == 0 {
+		pageSize = defaultSearchPageSize
+	}
+	return &DetectorIterator{
+		gateway:  g,
+		query:    query,
+		pageSize: pageSize,
+	}
+}
+
+//Next returns next search hit, and false once all detectors are returned. Next page is requested only
+//when current page is exhausted; if request fails, the same page is requested again on next call.
+func (it *DetectorIterator) Next(ctx context.Context) ([]byte, bool, error) {
+	if len(it.page) == 0 && !it.done {
+		if err := it.fetch(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(it.page) == 0 {
+		return nil, false, nil
+	}
+	hit := it.page[0]
+	it.page = it.page[1:]
+	return hit, true, nil
+}
+
+//fetch requests next page of detectors, iterator is done once a page has less than page size detectors
+func (it *DetectorIterator) fetch(ctx context.Context) error {
+	response, err := it.gateway.SearchDetectorPaged(ctx, it.query, it.from, it.pageSize)
+	if err != nil {
+		return err
+	}
+	var data struct {
+		Hits struct {
+			Hits []json.RawMessage `json:"hits"`
+		} `json:"hits"`
+	}
+	if err = json.Unmarshal(response, &data); err != nil {
+		return err
+	}
+	it.page = data.Hits.Hits
+	it.from += len(data.Hits.Hits)
+	it.done = len(data.Hits.Hits) < it.pageSize
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package ad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getIteratorTestGateway(t *testing.T, total int, failFrom int) (Gateway, *[]int, func()) {
+	var requested []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_plugins/_anomaly_detection/detectors/_search", r.URL.Path)
+		var body struct {
+			From int `json:"from"`
+			Size int `json:"size"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requested = append(requested, body.From)
+		if body.From == failFrom {
+			failFrom = -1
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`search failed`))
+			return
+		}
+		var hits []string
+		for i := body.From; i < total && i < body.From+body.Size; i++ {
+			hits = append(hits, fmt.Sprintf(`{"_id":"id-%d"}`, i))
+		}
+		_, _ = fmt.Fprintf(w, `{"hits":{"hits":[%s]}}`, strings.Join(hits, ","))
+	}))
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	testGateway, err := New(testClient, &entity.Profile{Name: "test", Endpoint: server.URL})
+	assert.NoError(t, err)
+	return testGateway, &requested, server.Close
+}
+
+func collectDetectorIDs(t *testing.T, iterator *DetectorIterator) []string {
+	var IDs []string
+	for {
+		hit, ok, err := iterator.Next(context.Background())
+		assert.NoError(t, err)
+		if !ok {
+			return IDs
+		}
+		var detector struct {
+			ID string `json:"_id"`
+		}
+		assert.NoError(t, json.Unmarshal(hit, &detector))
+		IDs = append(IDs, detector.ID)
+	}
+}
+
+func TestDetectorIterator(t *testing.T) {
+	t.Run("multiple pages", func(t *testing.T) {
+		testGateway, requested, closeServer := getIteratorTestGateway(t, 5, -1)
+		defer closeServer()
+		iterator := NewDetectorIterator(testGateway, nil, 2)
+		assert.EqualValues(t, []string{"id-0", "id-1", "id-2", "id-3", "id-4"}, collectDetectorIDs(t, iterator))
+		assert.EqualValues(t, []int{0, 2, 4}, *requested)
+		_, ok, err := iterator.Next(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Len(t, *requested, 3)
+	})
+	t.Run("last page is full", func(t *testing.T) {
+		testGateway, requested, closeServer := getIteratorTestGateway(t, 4, -1)
+		defer closeServer()
+		assert.Len(t, collectDetectorIDs(t, NewDetectorIterator(testGateway, nil, 2)), 4)
+		assert.EqualValues(t, []int{0, 2, 4}, *requested)
+	})
+	t.Run("empty result", func(t *testing.T) {
+		testGateway, requested, closeServer := getIteratorTestGateway(t, 0, -1)
+		defer closeServer()
+		assert.Empty(t, collectDetectorIDs(t, NewDetectorIterator(testGateway, nil, 0)))
+		assert.EqualValues(t, []int{0}, *requested)
+	})
+	t.Run("failed page is requested again", func(t *testing.T) {
+		testGateway, requested, closeServer := getIteratorTestGateway(t, 3, 2)
+		defer closeServer()
+		iterator := NewDetectorIterator(testGateway, nil, 2)
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			_, ok, err := iterator.Next(ctx)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		}
+		_, ok, err := iterator.Next(ctx)
+		assert.EqualError(t, err, "search failed")
+		assert.False(t, ok)
+		assert.EqualValues(t, []string{"id-2"}, collectDetectorIDs(t, iterator))
+		assert.EqualValues(t, []int{0, 2, 2}, *requested)
+	})
+}
@@ -6,7 +6,12 @@ package mocks
 
 import (
 	context "context"
+	jsontext "encoding/json/jsontext"
+	io "io"
+	ad "opensearch-cli/entity/ad"
+	ad0 "opensearch-cli/gateway/ad"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +39,35 @@ func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
 	return m.recorder
 }
 
+// AddDetectorFeature mocks base method
+func (m *MockGateway) AddDetectorFeature(arg0 context.Context, arg1 string, arg2 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDetectorFeature", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDetectorFeature indicates an expected call of AddDetectorFeature
+func (mr *MockGatewayMockRecorder) AddDetectorFeature(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDetectorFeature", reflect.TypeOf((*MockGateway)(nil).AddDetectorFeature), arg0, arg1, arg2)
+}
+
+// CountDetectors mocks base method
+func (m *MockGateway) CountDetectors(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDetectors", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDetectors indicates an expected call of CountDetectors
+func (mr *MockGatewayMockRecorder) CountDetectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDetectors", reflect.TypeOf((*MockGateway)(nil).CountDetectors), arg0, arg1)
+}
+
 // CreateDetector mocks base method
 func (m *MockGateway) CreateDetector(arg0 context.Context, arg1 interface{}) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +83,36 @@ func (mr *MockGatewayMockRecorder) CreateDetector(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDetector", reflect.TypeOf((*MockGateway)(nil).CreateDetector), arg0, arg1)
 }
 
+// CreateDetectorTyped mocks base method
+func (m *MockGateway) CreateDetectorTyped(arg0 context.Context, arg1 ad.CreateDetector) (*ad.CreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDetectorTyped", arg0, arg1)
+	ret0, _ := ret[0].(*ad.CreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDetectorTyped indicates an expected call of CreateDetectorTyped
+func (mr *MockGatewayMockRecorder) CreateDetectorTyped(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDetectorTyped", reflect.TypeOf((*MockGateway)(nil).CreateDetectorTyped), arg0, arg1)
+}
+
+// CreateOrUpdateDetector mocks base method
+func (m *MockGateway) CreateOrUpdateDetector(arg0 context.Context, arg1 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateDetector", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdateDetector indicates an expected call of CreateOrUpdateDetector
+func (mr *MockGatewayMockRecorder) CreateOrUpdateDetector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateDetector", reflect.TypeOf((*MockGateway)(nil).CreateOrUpdateDetector), arg0, arg1)
+}
+
 // DeleteDetector mocks base method
 func (m *MockGateway) DeleteDetector(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -63,6 +127,36 @@ func (mr *MockGatewayMockRecorder) DeleteDetector(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDetector", reflect.TypeOf((*MockGateway)(nil).DeleteDetector), arg0, arg1)
 }
 
+// DeleteDetectorsByQuery mocks base method
+func (m *MockGateway) DeleteDetectorsByQuery(arg0 context.Context, arg1 interface{}) ([]string, map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDetectorsByQuery", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(map[string]error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteDetectorsByQuery indicates an expected call of DeleteDetectorsByQuery
+func (mr *MockGatewayMockRecorder) DeleteDetectorsByQuery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDetectorsByQuery", reflect.TypeOf((*MockGateway)(nil).DeleteDetectorsByQuery), arg0, arg1)
+}
+
+// ExportDetectors mocks base method
+func (m *MockGateway) ExportDetectors(arg0 context.Context, arg1 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDetectors", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDetectors indicates an expected call of ExportDetectors
+func (mr *MockGatewayMockRecorder) ExportDetectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDetectors", reflect.TypeOf((*MockGateway)(nil).ExportDetectors), arg0, arg1)
+}
+
 // GetDetector mocks base method
 func (m *MockGateway) GetDetector(arg0 context.Context, arg1 string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +172,135 @@ func (mr *MockGatewayMockRecorder) GetDetector(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetector", reflect.TypeOf((*MockGateway)(nil).GetDetector), arg0, arg1)
 }
 
+// GetDetectorByName mocks base method
+func (m *MockGateway) GetDetectorByName(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorByName", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorByName indicates an expected call of GetDetectorByName
+func (mr *MockGatewayMockRecorder) GetDetectorByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorByName", reflect.TypeOf((*MockGateway)(nil).GetDetectorByName), arg0, arg1)
+}
+
+// GetDetectorState mocks base method
+func (m *MockGateway) GetDetectorState(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorState", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorState indicates an expected call of GetDetectorState
+func (mr *MockGatewayMockRecorder) GetDetectorState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorState", reflect.TypeOf((*MockGateway)(nil).GetDetectorState), arg0, arg1)
+}
+
+// GetDetectorTyped mocks base method
+func (m *MockGateway) GetDetectorTyped(arg0 context.Context, arg1 string) (*ad.DetectorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetectorTyped", arg0, arg1)
+	ret0, _ := ret[0].(*ad.DetectorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetectorTyped indicates an expected call of GetDetectorTyped
+func (mr *MockGatewayMockRecorder) GetDetectorTyped(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetectorTyped", reflect.TypeOf((*MockGateway)(nil).GetDetectorTyped), arg0, arg1)
+}
+
+// ImportDetectors mocks base method
+func (m *MockGateway) ImportDetectors(arg0 context.Context, arg1 io.Reader, arg2 bool) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportDetectors", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportDetectors indicates an expected call of ImportDetectors
+func (mr *MockGatewayMockRecorder) ImportDetectors(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportDetectors", reflect.TypeOf((*MockGateway)(nil).ImportDetectors), arg0, arg1, arg2)
+}
+
+// PreviewDetector mocks base method
+func (m *MockGateway) PreviewDetector(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewDetector", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewDetector indicates an expected call of PreviewDetector
+func (mr *MockGatewayMockRecorder) PreviewDetector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewDetector", reflect.TypeOf((*MockGateway)(nil).PreviewDetector), arg0, arg1, arg2)
+}
+
+// ProfileDetector mocks base method
+func (m *MockGateway) ProfileDetector(arg0 context.Context, arg1 string, arg2 ...string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProfileDetector", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileDetector indicates an expected call of ProfileDetector
+func (mr *MockGatewayMockRecorder) ProfileDetector(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileDetector", reflect.TypeOf((*MockGateway)(nil).ProfileDetector), varargs...)
+}
+
+// ProfileDetectorTyped mocks base method
+func (m *MockGateway) ProfileDetectorTyped(arg0 context.Context, arg1 string, arg2 ...string) (*ad.Profile, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProfileDetectorTyped", varargs...)
+	ret0, _ := ret[0].(*ad.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileDetectorTyped indicates an expected call of ProfileDetectorTyped
+func (mr *MockGatewayMockRecorder) ProfileDetectorTyped(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileDetectorTyped", reflect.TypeOf((*MockGateway)(nil).ProfileDetectorTyped), varargs...)
+}
+
+// RemoveDetectorFeature mocks base method
+func (m *MockGateway) RemoveDetectorFeature(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDetectorFeature", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDetectorFeature indicates an expected call of RemoveDetectorFeature
+func (mr *MockGatewayMockRecorder) RemoveDetectorFeature(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDetectorFeature", reflect.TypeOf((*MockGateway)(nil).RemoveDetectorFeature), arg0, arg1, arg2)
+}
+
 // SearchDetector mocks base method
 func (m *MockGateway) SearchDetector(arg0 context.Context, arg1 interface{}) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +316,52 @@ func (mr *MockGatewayMockRecorder) SearchDetector(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDetector", reflect.TypeOf((*MockGateway)(nil).SearchDetector), arg0, arg1)
 }
 
+// SearchDetectorPaged mocks base method
+func (m *MockGateway) SearchDetectorPaged(arg0 context.Context, arg1 interface{}, arg2, arg3 int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchDetectorPaged", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchDetectorPaged indicates an expected call of SearchDetectorPaged
+func (mr *MockGatewayMockRecorder) SearchDetectorPaged(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDetectorPaged", reflect.TypeOf((*MockGateway)(nil).SearchDetectorPaged), arg0, arg1, arg2, arg3)
+}
+
+// SearchResults mocks base method
+func (m *MockGateway) SearchResults(arg0 context.Context, arg1 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchResults", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchResults indicates an expected call of SearchResults
+func (mr *MockGatewayMockRecorder) SearchResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchResults", reflect.TypeOf((*MockGateway)(nil).SearchResults), arg0, arg1)
+}
+
+// SearchResultsAfter mocks base method
+func (m *MockGateway) SearchResultsAfter(arg0 context.Context, arg1 interface{}, arg2, arg3 []interface{}) ([]jsontext.Value, []interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchResultsAfter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]jsontext.Value)
+	ret1, _ := ret[1].([]interface{})
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchResultsAfter indicates an expected call of SearchResultsAfter
+func (mr *MockGatewayMockRecorder) SearchResultsAfter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchResultsAfter", reflect.TypeOf((*MockGateway)(nil).SearchResultsAfter), arg0, arg1, arg2, arg3)
+}
+
 // StartDetector mocks base method
 func (m *MockGateway) StartDetector(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -107,6 +376,35 @@ func (mr *MockGatewayMockRecorder) StartDetector(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDetector", reflect.TypeOf((*MockGateway)(nil).StartDetector), arg0, arg1)
 }
 
+// StartDetectors mocks base method
+func (m *MockGateway) StartDetectors(arg0 context.Context, arg1 []string) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartDetectors", arg0, arg1)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartDetectors indicates an expected call of StartDetectors
+func (mr *MockGatewayMockRecorder) StartDetectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDetectors", reflect.TypeOf((*MockGateway)(nil).StartDetectors), arg0, arg1)
+}
+
+// StartHistoricalDetector mocks base method
+func (m *MockGateway) StartHistoricalDetector(arg0 context.Context, arg1 string, arg2, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartHistoricalDetector", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartHistoricalDetector indicates an expected call of StartHistoricalDetector
+func (mr *MockGatewayMockRecorder) StartHistoricalDetector(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartHistoricalDetector", reflect.TypeOf((*MockGateway)(nil).StartHistoricalDetector), arg0, arg1, arg2, arg3)
+}
+
 // StopDetector mocks base method
 func (m *MockGateway) StopDetector(arg0 context.Context, arg1 string) (*string, error) {
 	m.ctrl.T.Helper()
@@ -122,16 +420,90 @@ func (mr *MockGatewayMockRecorder) StopDetector(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopDetector", reflect.TypeOf((*MockGateway)(nil).StopDetector), arg0, arg1)
 }
 
+// StopDetectors mocks base method
+func (m *MockGateway) StopDetectors(arg0 context.Context, arg1 []string) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopDetectors", arg0, arg1)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopDetectors indicates an expected call of StopDetectors
+func (mr *MockGatewayMockRecorder) StopDetectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopDetectors", reflect.TypeOf((*MockGateway)(nil).StopDetectors), arg0, arg1)
+}
+
+// StopHistoricalDetector mocks base method
+func (m *MockGateway) StopHistoricalDetector(arg0 context.Context, arg1 string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopHistoricalDetector", arg0, arg1)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopHistoricalDetector indicates an expected call of StopHistoricalDetector
+func (mr *MockGatewayMockRecorder) StopHistoricalDetector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopHistoricalDetector", reflect.TypeOf((*MockGateway)(nil).StopHistoricalDetector), arg0, arg1)
+}
+
+// TopAnomalies mocks base method
+func (m *MockGateway) TopAnomalies(arg0 context.Context, arg1 string, arg2 bool, arg3 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopAnomalies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopAnomalies indicates an expected call of TopAnomalies
+func (mr *MockGatewayMockRecorder) TopAnomalies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopAnomalies", reflect.TypeOf((*MockGateway)(nil).TopAnomalies), arg0, arg1, arg2, arg3)
+}
+
 // UpdateDetector mocks base method
-func (m *MockGateway) UpdateDetector(arg0 context.Context, arg1 string, arg2 interface{}) error {
+func (m *MockGateway) UpdateDetector(arg0 context.Context, arg1 string, arg2 interface{}, arg3 *ad0.SeqNoPrimaryTerm) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDetector", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateDetector", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateDetector indicates an expected call of UpdateDetector
-func (mr *MockGatewayMockRecorder) UpdateDetector(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockGatewayMockRecorder) UpdateDetector(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDetector", reflect.TypeOf((*MockGateway)(nil).UpdateDetector), arg0, arg1, arg2, arg3)
+}
+
+// ValidateDetector mocks base method
+func (m *MockGateway) ValidateDetector(arg0 context.Context, arg1 interface{}, arg2 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDetector", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDetector indicates an expected call of ValidateDetector
+func (mr *MockGatewayMockRecorder) ValidateDetector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDetector", reflect.TypeOf((*MockGateway)(nil).ValidateDetector), arg0, arg1, arg2)
+}
+
+// WaitForDetectorState mocks base method
+func (m *MockGateway) WaitForDetectorState(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForDetectorState", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForDetectorState indicates an expected call of WaitForDetectorState
+func (mr *MockGatewayMockRecorder) WaitForDetectorState(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDetector", reflect.TypeOf((*MockGateway)(nil).UpdateDetector), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDetectorState", reflect.TypeOf((*MockGateway)(nil).WaitForDetectorState), arg0, arg1, arg2, arg3)
 }
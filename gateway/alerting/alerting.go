@@ -0,0 +1,258 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	baseURL                = "_plugins/_alerting"
+	monitorsURL            = baseURL + "/monitors"
+	monitorURLTemplate     = monitorsURL + "/%s"
+	searchURL              = monitorsURL + "/_search"
+	acknowledgeURLTemplate = monitorURLTemplate + "/_acknowledge/alerts"
+	destinationsURL        = baseURL + "/destinations"
+	destinationURLTemplate = destinationsURL + "/%s"
+	alertsField            = "alerts"
+	monitorIDFieldName     = "monitor Id"
+	destinationIDFieldName = "destination Id"
+	emptyValueErrorMessage = "%s cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_alerting.go -package=mocks . Gateway
+
+// Gateway interface to Alerting Plugin
+type Gateway interface {
+	CreateMonitor(ctx context.Context, payload interface{}) ([]byte, error)
+	GetMonitor(ctx context.Context, ID string) ([]byte, error)
+	UpdateMonitor(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	DeleteMonitor(ctx context.Context, ID string) error
+	SearchMonitor(ctx context.Context, payload interface{}) ([]byte, error)
+	AcknowledgeAlerts(ctx context.Context, monitorID string, alertIDs []string) ([]byte, error)
+	CreateDestination(ctx context.Context, payload interface{}) ([]byte, error)
+	GetDestination(ctx context.Context, ID string) ([]byte, error)
+	UpdateDestination(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	DeleteDestination(ctx context.Context, ID string) error
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL returns url for given path on profile's endpoint
+func (g *gateway) buildURL(path string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	return endpoint, nil
+}
+
+//buildMonitorURL builds url from template for given monitor ID
+func (g *gateway) buildMonitorURL(template string, ID string) (*url.URL, error) {
+	return g.buildIDURL(template, monitorIDFieldName, ID)
+}
+
+//buildDestinationURL builds url for given destination ID
+func (g *gateway) buildDestinationURL(ID string) (*url.URL, error) {
+	return g.buildIDURL(destinationURLTemplate, destinationIDFieldName, ID)
+}
+
+//buildIDURL builds url from template for given ID of monitor or destination, named by field. ID is escaped
+//to prevent it from adding path segments or query parameters to the url
+func (g *gateway) buildIDURL(template string, field string, ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, fmt.Errorf(emptyValueErrorMessage, field)
+	}
+	endpoint, err := g.buildURL(fmt.Sprintf(template, ID))
+	if err != nil {
+		return nil, err
+	}
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(ID))
+	return endpoint, nil
+}
+
+//call sends request, urls of destinations are redacted from debug output since they contain secret tokens
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*CreateMonitor Creates a monitor.
+It calls http request: POST _plugins/_alerting/monitors
+Sample Input:
+{
+  "type": "monitor",
+  "name": "test-monitor",
+  "enabled": true,
+  "schedule": {
+    "period": {
+      "interval": 1,
+      "unit": "MINUTES"
+    }
+  },
+  "inputs": [{
+    "search": {
+      "indices": ["movies"],
+      "query": {
+        "size": 0,
+        "query": {"match_all": {}}
+      }
+    }
+  }],
+  "triggers": []
+}*/
+func (g *gateway) CreateMonitor(ctx context.Context, payload interface{}) ([]byte, error) {
+	createURL, err := g.buildURL(monitorsURL)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, createURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// GetMonitor Returns monitor with given monitor ID.
+// It calls http request: GET _plugins/_alerting/monitors/<monitor_id>
+func (g *gateway) GetMonitor(ctx context.Context, ID string) ([]byte, error) {
+	getURL, err := g.buildMonitorURL(monitorURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, getURL, "", http.StatusOK)
+}
+
+// UpdateMonitor Updates monitor with given monitor ID, payload replaces the whole monitor.
+// It calls http request: PUT _plugins/_alerting/monitors/<monitor_id>
+func (g *gateway) UpdateMonitor(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	updateURL, err := g.buildMonitorURL(monitorURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, updateURL, payload, http.StatusOK)
+}
+
+// DeleteMonitor Deletes monitor with given monitor ID.
+// It calls http request: DELETE _plugins/_alerting/monitors/<monitor_id>
+func (g *gateway) DeleteMonitor(ctx context.Context, ID string) error {
+	deleteURL, err := g.buildMonitorURL(monitorURLTemplate, ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, deleteURL, "", http.StatusOK)
+	return err
+}
+
+/*SearchMonitor Returns monitors matching search query.
+It calls http request: POST _plugins/_alerting/monitors/_search
+Sample Input:
+{
+  "query": {
+    "match": {
+      "monitor.name": "test-monitor"
+    }
+  }
+}*/
+func (g *gateway) SearchMonitor(ctx context.Context, payload interface{}) ([]byte, error) {
+	searchURL, err := g.buildURL(searchURL)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, searchURL, payload, http.StatusOK)
+}
+
+/*AcknowledgeAlerts Acknowledges alerts of monitor with given monitor ID.
+It calls http request: POST _plugins/_alerting/monitors/<monitor_id>/_acknowledge/alerts
+with body: {"alerts": ["<alert_id>", ...]}
+Sample Output:
+{
+  "success": ["eQURa3gBKo1jAh6qUo49"],
+  "failed": []
+}*/
+func (g *gateway) AcknowledgeAlerts(ctx context.Context, monitorID string, alertIDs []string) ([]byte, error) {
+	if len(alertIDs) < 1 {
+		return nil, fmt.Errorf("at least one alert Id is required")
+	}
+	acknowledgeURL, err := g.buildMonitorURL(acknowledgeURLTemplate, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, acknowledgeURL, map[string][]string{alertsField: alertIDs}, http.StatusOK)
+}
+
+/*CreateDestination Creates destination of alerts, like slack or chime channel, or custom webhook.
+Destinations are deprecated in favor of channels of Notifications plugin.
+It calls http request: POST _plugins/_alerting/destinations
+Sample Input:
+{
+  "name": "my-destination",
+  "type": "slack",
+  "slack": {
+    "url": "https://hooks.slack.com/services/..."
+  }
+}*/
+func (g *gateway) CreateDestination(ctx context.Context, payload interface{}) ([]byte, error) {
+	createURL, err := g.buildURL(destinationsURL)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, createURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// GetDestination Returns destination with given ID.
+// It calls http request: GET _plugins/_alerting/destinations/<destination_id>
+func (g *gateway) GetDestination(ctx context.Context, ID string) ([]byte, error) {
+	destinationURL, err := g.buildDestinationURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, destinationURL, "", http.StatusOK)
+}
+
+// UpdateDestination Replaces destination with given ID.
+// It calls http request: PUT _plugins/_alerting/destinations/<destination_id>
+func (g *gateway) UpdateDestination(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	destinationURL, err := g.buildDestinationURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, destinationURL, payload, http.StatusOK)
+}
+
+// DeleteDestination Deletes destination with given ID.
+// It calls http request: DELETE _plugins/_alerting/destinations/<destination_id>
+func (g *gateway) DeleteDestination(ctx context.Context, ID string) error {
+	destinationURL, err := g.buildDestinationURL(ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, destinationURL, "", http.StatusOK)
+	return err
+}
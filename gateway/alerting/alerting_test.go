@@ -0,0 +1,143 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	gw "opensearch-cli/gateway"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_AcknowledgeAlerts(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"success":["alert_1","alert_2"],"failed":[]}`)
+	t.Run("acknowledge alerts", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_alerting/monitors/monitor_1/_acknowledge/alerts", `{"alerts":["alert_1","alert_2"]}`, 200, response)
+		actual, err := getTestGateway(t, testClient).AcknowledgeAlerts(ctx, "monitor_1", []string{"alert_1", "alert_2"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("monitor id is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_alerting/monitors/monitor%2F_search/_acknowledge/alerts", `{"alerts":["alert_1"]}`, 200, response)
+		_, err := getTestGateway(t, testClient).AcknowledgeAlerts(ctx, "monitor/_search", []string{"alert_1"})
+		assert.NoError(t, err)
+	})
+	t.Run("no alerts", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).AcknowledgeAlerts(ctx, "monitor_1", nil)
+		assert.EqualError(t, err, "at least one alert Id is required")
+	})
+	t.Run("empty monitor id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).AcknowledgeAlerts(ctx, "", []string{"alert_1"})
+		assert.EqualError(t, err, "monitor Id cannot be empty")
+	})
+}
+
+func TestGateway_Monitors(t *testing.T) {
+	ctx := context.Background()
+	monitor := `{"type":"monitor","name":"test-monitor","enabled":true}`
+	t.Run("create monitor", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_alerting/monitors", monitor, 201, []byte(`{"_id":"monitor_1"}`))
+		actual, err := getTestGateway(t, testClient).CreateMonitor(ctx, json.RawMessage(monitor))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_id":"monitor_1"}`, string(actual))
+	})
+	t.Run("get monitor", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_alerting/monitors/monitor_1", "", 200, []byte(monitor))
+		actual, err := getTestGateway(t, testClient).GetMonitor(ctx, "monitor_1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, monitor, string(actual))
+	})
+	t.Run("get monitor not found", func(t *testing.T) {
+		notFound := []byte(`{"error":{"type":"status_exception","reason":"Monitor not found."},"status":404}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_alerting/monitors/unknown", "", 404, notFound)
+		_, err := getTestGateway(t, testClient).GetMonitor(ctx, "unknown")
+		var responseErr *gw.ResponseError
+		assert.True(t, errors.As(err, &responseErr))
+		assert.Equal(t, http.StatusNotFound, responseErr.StatusCode)
+		assert.Contains(t, err.Error(), "Monitor not found.")
+	})
+	t.Run("update monitor", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_alerting/monitors/monitor_1", monitor, 200, []byte(`{"_id":"monitor_1"}`))
+		_, err := getTestGateway(t, testClient).UpdateMonitor(ctx, "monitor_1", json.RawMessage(monitor))
+		assert.NoError(t, err)
+	})
+	t.Run("delete monitor", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_alerting/monitors/monitor_1", "", 200, []byte(`{"result":"deleted"}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteMonitor(ctx, "monitor_1"))
+	})
+	t.Run("delete monitor not found", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_alerting/monitors/unknown", "", 404, []byte("not found"))
+		assert.EqualError(t, getTestGateway(t, testClient).DeleteMonitor(ctx, "unknown"), "not found")
+	})
+	t.Run("search monitor", func(t *testing.T) {
+		query := `{"query":{"match":{"monitor.name":"test-monitor"}}}`
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_alerting/monitors/_search", query, 200, []byte(`{"hits":{"hits":[]}}`))
+		actual, err := getTestGateway(t, testClient).SearchMonitor(ctx, json.RawMessage(query))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"hits":{"hits":[]}}`, string(actual))
+	})
+}
+
+func TestGateway_Destinations(t *testing.T) {
+	ctx := context.Background()
+	webhook := "https://hooks.slack.com/services/T0/B0/secret-token"
+	destination := `{"name":"my-destination","type":"slack","slack":{"url":"` + webhook + `"}}`
+	t.Run("slack destination round trip is redacted in debug output", func(t *testing.T) {
+		var debug bytes.Buffer
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_alerting/destinations", destination, 201, []byte(`{"_id":"destination_1"}`))
+		testClient.Debug = &debug
+		actual, err := getTestGateway(t, testClient).CreateDestination(ctx, json.RawMessage(destination))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_id":"destination_1"}`, string(actual))
+
+		response := `{"_id":"destination_1","destination":` + destination + `}`
+		testClient = testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_alerting/destinations/destination_1", "", 200, []byte(response))
+		testClient.Debug = &debug
+		actual, err = getTestGateway(t, testClient).GetDestination(ctx, "destination_1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, string(actual), "response must not be redacted")
+
+		output := debug.String()
+		assert.Contains(t, output, "> POST http://localhost:9200/_plugins/_alerting/destinations\n")
+		assert.Contains(t, output, "> GET http://localhost:9200/_plugins/_alerting/destinations/destination_1\n")
+		assert.Contains(t, output, `"slack":{"url":"[REDACTED]"}`)
+		assert.NotContains(t, output, "secret-token")
+	})
+	t.Run("update destination", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_alerting/destinations/destination_1", destination, 200, []byte(`{"_id":"destination_1"}`))
+		_, err := getTestGateway(t, testClient).UpdateDestination(ctx, "destination_1", json.RawMessage(destination))
+		assert.NoError(t, err)
+	})
+	t.Run("delete destination", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_alerting/destinations/destination_1", "", 200, []byte(`{"result":"deleted"}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteDestination(ctx, "destination_1"))
+	})
+	t.Run("empty destination id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).GetDestination(ctx, "")
+		assert.EqualError(t, err, "destination Id cannot be empty")
+	})
+}
@@ -0,0 +1,143 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package asyncsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"time"
+)
+
+const (
+	asyncSearchURL                    = "_async_search"
+	submitURLTemplate                 = "%s/" + asyncSearchURL
+	searchURLTemplate                 = asyncSearchURL + "/%s"
+	waitForCompletionTimeoutParamName = "wait_for_completion_timeout"
+	emptyIDErrorMessage               = "async search Id cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_asyncsearch.go -package=mocks . Gateway
+
+// Gateway interface to async search API
+type Gateway interface {
+	Submit(ctx context.Context, index string, body interface{}, waitForCompletionTimeout time.Duration) ([]byte, error)
+	Get(ctx context.Context, ID string) ([]byte, error)
+	Delete(ctx context.Context, ID string) error
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL builds url from template for given value, like index or search ID. Value is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildURL(template string, value string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, value)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(value))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*Submit Submits search on given index, which can be a name, pattern or comma separated list. It waits up to
+waitForCompletionTimeout for search to complete, if timeout is zero, cluster's default is used. Response
+contains search ID, which can be used to Get results, and results if search completed within timeout.
+It calls http request: POST <index>/_async_search?wait_for_completion_timeout=<timeout>
+Sample Input:
+{
+  "query": {
+    "range": {"execution_end_time": {"gte": "now-1d"}}
+  }
+}
+Sample Output:
+{
+  "id": "FklfVlU4eFdIUTh1Q1hyM3ZnT19fUVEgQUVBNHFVSklRZ0pXRUVacS1zYVZIdzoxMDM0",
+  "is_partial": true,
+  "is_running": true,
+  "start_time_in_millis": 1583945890986,
+  "expiration_time_in_millis": 1584377890986,
+  "response": {
+    "took": 1122,
+    "timed_out": false,
+    "hits": {"total": {"value": 0, "relation": "gte"}, "hits": []}
+  }
+}*/
+func (g *gateway) Submit(ctx context.Context, index string, body interface{}, waitForCompletionTimeout time.Duration) ([]byte, error) {
+	if len(index) < 1 {
+		return nil, errors.New("index cannot be empty")
+	}
+	if waitForCompletionTimeout < 0 {
+		return nil, fmt.Errorf("wait for completion timeout: %v cannot be negative", waitForCompletionTimeout)
+	}
+	submitURL, err := g.buildURL(submitURLTemplate, index)
+	if err != nil {
+		return nil, err
+	}
+	if waitForCompletionTimeout > 0 {
+		submitURL.RawQuery = url.Values{
+			waitForCompletionTimeoutParamName: []string{fmt.Sprintf("%dms", waitForCompletionTimeout.Milliseconds())},
+		}.Encode()
+	}
+	return g.call(ctx, http.MethodPost, submitURL, body)
+}
+
+// Get Returns status of async search with given ID, and its results so far.
+// It calls http request: GET _async_search/<id>
+func (g *gateway) Get(ctx context.Context, ID string) ([]byte, error) {
+	if len(ID) < 1 {
+		return nil, errors.New(emptyIDErrorMessage)
+	}
+	searchURL, err := g.buildURL(searchURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, searchURL, "")
+}
+
+// Delete Deletes async search with given ID, search is cancelled if it is still running.
+// It calls http request: DELETE _async_search/<id>
+func (g *gateway) Delete(ctx context.Context, ID string) error {
+	if len(ID) < 1 {
+		return errors.New(emptyIDErrorMessage)
+	}
+	searchURL, err := g.buildURL(searchURLTemplate, ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, searchURL, "")
+	return err
+}
@@ -0,0 +1,129 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package asyncsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const searchID = "FklfVlU4eFdIUTh1Q1hyM3ZnT19fUVEgQUVBNHFVSklRZ0pXRUVacS1zYVZIdzoxMDM0"
+
+func getTestGateway(t *testing.T, endpoint string) Gateway {
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	zero := 0
+	g, err := New(testClient, &entity.Profile{
+		Name:     "test",
+		Endpoint: endpoint,
+		UserName: "admin",
+		Password: "admin",
+		MaxRetry: &zero,
+	})
+	assert.NoError(t, err)
+	return g
+}
+
+//getTestServer returns server which runs submitted search in background, search completes once it is read
+func getTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/results-*/_async_search":
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"query":{"match_all":{}}}`, string(body))
+			assert.Equal(t, "500ms", r.URL.Query().Get("wait_for_completion_timeout"))
+			_, _ = w.Write([]byte(`{"id":"` + searchID + `","is_partial":true,"is_running":true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/_async_search/"+searchID:
+			_, _ = w.Write([]byte(`{"id":"` + searchID + `","is_partial":false,"is_running":false,"response":{"hits":{"total":{"value":1}}}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/_async_search/"+searchID:
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		}
+	}))
+}
+
+type asyncSearchResponse struct {
+	ID        string `json:"id"`
+	IsRunning bool   `json:"is_running"`
+	Response  struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+	} `json:"response"`
+}
+
+func TestGateway_SubmitAndGet(t *testing.T) {
+	server := getTestServer(t)
+	defer server.Close()
+	ctx := context.Background()
+	g := getTestGateway(t, server.URL)
+
+	response, err := g.Submit(ctx, "results-*", json.RawMessage(`{"query":{"match_all":{}}}`), 500*time.Millisecond)
+	assert.NoError(t, err)
+	var submitted asyncSearchResponse
+	assert.NoError(t, json.Unmarshal(response, &submitted))
+	assert.True(t, submitted.IsRunning)
+
+	response, err = g.Get(ctx, submitted.ID)
+	assert.NoError(t, err)
+	var completed asyncSearchResponse
+	assert.NoError(t, json.Unmarshal(response, &completed))
+	assert.False(t, completed.IsRunning)
+	assert.EqualValues(t, 1, completed.Response.Hits.Total.Value)
+
+	assert.NoError(t, g.Delete(ctx, submitted.ID))
+	_, err = g.Get(ctx, "unknown")
+	assert.EqualError(t, err, "not found")
+}
+
+func TestGateway_Submit(t *testing.T) {
+	ctx := context.Background()
+	t.Run("default timeout", func(t *testing.T) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "http://localhost:9200/results/_async_search", req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		g, err := New(testClient, &entity.Profile{Name: "test", Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		_, err = g.Submit(ctx, "results", json.RawMessage(`{}`), 0)
+		assert.NoError(t, err)
+	})
+	t.Run("negative timeout", func(t *testing.T) {
+		_, err := getTestGateway(t, "http://localhost:9200").Submit(ctx, "results", json.RawMessage(`{}`), -time.Second)
+		assert.EqualError(t, err, "wait for completion timeout: -1s cannot be negative")
+	})
+	t.Run("empty id", func(t *testing.T) {
+		_, err := getTestGateway(t, "http://localhost:9200").Get(ctx, "")
+		assert.EqualError(t, err, "async search Id cannot be empty")
+	})
+}
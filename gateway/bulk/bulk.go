@@ -0,0 +1,79 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package bulk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	bulkURL           = "_bulk"
+	contentTypeHeader = "content-type"
+	ndjsonContentType = "application/x-ndjson"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_bulk.go -package=mocks . Gateway
+
+// Gateway interface to bulk API of OpenSearch
+type Gateway interface {
+	Bulk(ctx context.Context, r io.Reader) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+/*Bulk Performs actions read from r, which is newline delimited json, in a single request. Body is streamed
+to the cluster while it is read, hence, request is not retried. Response contains result of every action,
+in same order as actions, and "errors" is true if any of them failed.
+It calls http request: POST _bulk
+Sample Input:
+{"index": {"_index": "movies", "_id": "1"}}
+{"title": "Beauty and the Beast", "year": 1991}
+{"delete": {"_index": "movies", "_id": "2"}}
+Sample Output:
+{
+  "took": 11,
+  "errors": false,
+  "items": [
+    {"index": {"_index": "movies", "_id": "1", "result": "created", "status": 201}},
+    {"delete": {"_index": "movies", "_id": "2", "result": "not_found", "status": 404}}
+  ]
+}*/
+func (g *gateway) Bulk(ctx context.Context, r io.Reader) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = bulkURL
+	headers := gw.GetDefaultHeaders()
+	headers[contentTypeHeader] = ndjsonContentType
+	request, err := g.BuildStreamRequest(ctx, http.MethodPost, r, endpoint.String(), headers)
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
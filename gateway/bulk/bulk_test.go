@@ -0,0 +1,109 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkResponse struct {
+	Errors bool                         `json:"errors"`
+	Items  []map[string]json.RawMessage `json:"items"`
+}
+
+type itemResult struct {
+	Index  string `json:"_index"`
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+}
+
+//getTestServer returns server which replies result of every action of bulk request, as OpenSearch does
+func getTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		var response bulkResponse
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]itemResult
+			if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &action)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for name, item := range action {
+				item.Status = http.StatusCreated
+				if name != "index" && name != "create" {
+					item.Status = http.StatusOK
+				} else {
+					// skip source of document
+					scanner.Scan()
+				}
+				result, _ := json.Marshal(item)
+				response.Items = append(response.Items, map[string]json.RawMessage{name: result})
+			}
+		}
+		assert.NoError(t, scanner.Err())
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestGateway_Bulk(t *testing.T) {
+	server := getTestServer(t)
+	defer server.Close()
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	zero := 0
+	testGateway, err := New(testClient, &entity.Profile{
+		Name:     "test",
+		Endpoint: server.URL,
+		UserName: "admin",
+		Password: "admin",
+		MaxRetry: &zero,
+	})
+	assert.NoError(t, err)
+
+	reader, writer := io.Pipe()
+	go func() {
+		for i := 1; i <= 3; i++ {
+			_, _ = fmt.Fprintf(writer, "{\"index\":{\"_index\":\"movies\",\"_id\":\"%d\"}}\n{\"title\":\"movie %d\"}\n", i, i)
+		}
+		_, _ = fmt.Fprint(writer, "{\"delete\":{\"_index\":\"movies\",\"_id\":\"4\"}}\n")
+		_ = writer.Close()
+	}()
+	response, err := testGateway.Bulk(context.Background(), reader)
+	assert.NoError(t, err)
+
+	var actual bulkResponse
+	assert.NoError(t, json.Unmarshal(response, &actual))
+	assert.False(t, actual.Errors)
+	assert.Len(t, actual.Items, 4)
+	for i, item := range actual.Items[:3] {
+		var result itemResult
+		assert.NoError(t, json.Unmarshal(item["index"], &result))
+		assert.Equal(t, itemResult{Index: "movies", ID: fmt.Sprint(i + 1), Status: http.StatusCreated}, result)
+	}
+	var result itemResult
+	assert.NoError(t, json.Unmarshal(actual.Items[3]["delete"], &result))
+	assert.Equal(t, itemResult{Index: "movies", ID: "4", Status: http.StatusOK}, result)
+}
@@ -0,0 +1,135 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strings"
+)
+
+const (
+	baseURL               = "_cat"
+	indicesURL            = baseURL + "/indices"
+	nodesURL              = baseURL + "/nodes"
+	shardsURL             = baseURL + "/shards"
+	healthURL             = baseURL + "/health"
+	formatQueryParamName  = "format"
+	columnsQueryParamName = "h"
+	jsonFormat            = "json"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_cat.go -package=mocks . Gateway
+
+// Gateway interface to cat APIs of OpenSearch. Every method returns one object per row, keyed by column name,
+// only given columns are returned if columns is not empty
+type Gateway interface {
+	Indices(ctx context.Context, columns []string) ([]map[string]interface{}, error)
+	Nodes(ctx context.Context, columns []string) ([]map[string]interface{}, error)
+	Shards(ctx context.Context, columns []string) ([]map[string]interface{}, error)
+	Health(ctx context.Context, columns []string) ([]map[string]interface{}, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildCatURL builds url for given cat api, which returns json with given columns
+func (g *gateway) buildCatURL(path string, columns []string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	query := url.Values{
+		formatQueryParamName: []string{jsonFormat},
+	}
+	if len(columns) > 0 {
+		query.Set(columnsQueryParamName, strings.Join(columns, ","))
+	}
+	endpoint.RawQuery = query.Encode()
+	return endpoint, nil
+}
+
+//getRows calls given cat api and decodes its rows
+func (g *gateway) getRows(ctx context.Context, path string, columns []string) ([]map[string]interface{}, error) {
+	catURL, err := g.buildCatURL(path, columns)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, http.MethodGet, "", catURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Call(request, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(response, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+/*Indices Returns health, status, document count and size of every index.
+It calls http request: GET _cat/indices?format=json&h=<column1>,<column2>
+Sample Output:
+[
+  {
+    "health": "green",
+    "status": "open",
+    "index": "movies",
+    "uuid": "DwCk6Yf4RZ6d-GGNs2rdSw",
+    "pri": "1",
+    "rep": "0",
+    "docs.count": "2",
+    "docs.deleted": "0",
+    "store.size": "8.4kb",
+    "pri.store.size": "8.4kb"
+  }
+]*/
+func (g *gateway) Indices(ctx context.Context, columns []string) ([]map[string]interface{}, error) {
+	return g.getRows(ctx, indicesURL, columns)
+}
+
+// Nodes Returns roles and resource usage of every node of the cluster.
+// It calls http request: GET _cat/nodes?format=json&h=<column1>,<column2>
+func (g *gateway) Nodes(ctx context.Context, columns []string) ([]map[string]interface{}, error) {
+	return g.getRows(ctx, nodesURL, columns)
+}
+
+// Shards Returns state and node of every shard of the cluster.
+// It calls http request: GET _cat/shards?format=json&h=<column1>,<column2>
+func (g *gateway) Shards(ctx context.Context, columns []string) ([]map[string]interface{}, error) {
+	return g.getRows(ctx, shardsURL, columns)
+}
+
+// Health Returns health of the cluster as single row.
+// It calls http request: GET _cat/health?format=json&h=<column1>,<column2>
+func (g *gateway) Health(ctx context.Context, columns []string) ([]map[string]interface{}, error) {
+	return g.getRows(ctx, healthURL, columns)
+}
@@ -0,0 +1,86 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cat
+
+import (
+	"context"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Cat(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"index":"movies","health":"green"},{"index":"logs","health":"yellow"}]`)
+	expected := []map[string]interface{}{
+		{"index": "movies", "health": "green"},
+		{"index": "logs", "health": "yellow"},
+	}
+	tests := []struct {
+		name    string
+		call    func(Gateway, context.Context, []string) ([]map[string]interface{}, error)
+		columns []string
+		url     string
+	}{
+		{"indices", Gateway.Indices, nil, "http://localhost:9200/_cat/indices?format=json"},
+		{"indices with columns", Gateway.Indices, []string{"index", "health"}, "http://localhost:9200/_cat/indices?format=json&h=index%2Chealth"},
+		{"nodes", Gateway.Nodes, []string{"name"}, "http://localhost:9200/_cat/nodes?format=json&h=name"},
+		{"shards", Gateway.Shards, nil, "http://localhost:9200/_cat/shards?format=json"},
+		{"health", Gateway.Health, []string{"status"}, "http://localhost:9200/_cat/health?format=json&h=status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := testutil.NewExpectingClient(t, http.MethodGet, tt.url, "", 200, response)
+			actual, err := tt.call(getTestGateway(t, testClient), ctx, tt.columns)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestGateway_CatDecoding(t *testing.T) {
+	ctx := context.Background()
+	t.Run("unassigned shard", func(t *testing.T) {
+		response := []byte(`[{"index":"logs","shard":"0","prirep":"r","state":"UNASSIGNED","node":null}]`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_cat/shards?format=json", "", 200, response)
+		actual, err := getTestGateway(t, testClient).Shards(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{
+			{"index": "logs", "shard": "0", "prirep": "r", "state": "UNASSIGNED", "node": nil},
+		}, actual)
+	})
+	t.Run("no rows", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_cat/indices?format=json", "", 200, []byte(`[]`))
+		actual, err := getTestGateway(t, testClient).Indices(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("invalid response", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_cat/health?format=json", "", 200, []byte(`epoch timestamp cluster status`))
+		_, err := getTestGateway(t, testClient).Health(ctx, nil)
+		assert.Error(t, err)
+	})
+	t.Run("failed", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_cat/nodes?format=json&h=unknown", "", 400, []byte("unknown column"))
+		_, err := getTestGateway(t, testClient).Nodes(ctx, []string{"unknown"})
+		assert.EqualError(t, err, "unknown column")
+	})
+}
@@ -0,0 +1,106 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	healthURL       = "_cluster/health"
+	levelQueryParam = "level"
+)
+
+// Levels of details returned by Health
+const (
+	ClusterHealthLevel = "cluster"
+	IndicesHealthLevel = "indices"
+	ShardsHealthLevel  = "shards"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_cluster.go -package=mocks . Gateway
+
+// Gateway interface to cluster APIs
+type Gateway interface {
+	Health(ctx context.Context, level string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildHealthURL to construct url for cluster health with optional level of details
+func (g *gateway) buildHealthURL(level string) (*url.URL, error) {
+	switch level {
+	case "", ClusterHealthLevel, IndicesHealthLevel, ShardsHealthLevel:
+	default:
+		return nil, fmt.Errorf("invalid health level: %s, expected one of: %s, %s, %s",
+			level, ClusterHealthLevel, IndicesHealthLevel, ShardsHealthLevel)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = healthURL
+	if len(level) > 0 {
+		endpoint.RawQuery = url.Values{levelQueryParam: []string{level}}.Encode()
+	}
+	return endpoint, nil
+}
+
+/*Health Returns status of the cluster. If level is empty, cluster level health is returned,
+use indices or shards level to get health of every index or shard too.
+It calls http request: GET _cluster/health?level=<level>
+Sample Output:
+{
+  "cluster_name": "opensearch-cluster",
+  "status": "green",
+  "timed_out": false,
+  "number_of_nodes": 2,
+  "number_of_data_nodes": 2,
+  "active_primary_shards": 6,
+  "active_shards": 12,
+  "relocating_shards": 0,
+  "initializing_shards": 0,
+  "unassigned_shards": 0,
+  "delayed_unassigned_shards": 0,
+  "number_of_pending_tasks": 0,
+  "number_of_in_flight_fetch": 0,
+  "task_max_waiting_in_queue_millis": 0,
+  "active_shards_percent_as_number": 100.0
+}*/
+func (g *gateway) Health(ctx context.Context, level string) ([]byte, error) {
+	healthURL, err := g.buildHealthURL(level)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, http.MethodGet, "", healthURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
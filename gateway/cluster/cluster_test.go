@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayHealth(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"cluster_name":"opensearch-cluster","status":"green"}`)
+	tests := []struct {
+		name  string
+		level string
+		url   string
+	}{
+		{
+			name:  "default level",
+			level: "",
+			url:   "http://localhost:9200/_cluster/health",
+		},
+		{
+			name:  "cluster level",
+			level: ClusterHealthLevel,
+			url:   "http://localhost:9200/_cluster/health?level=cluster",
+		},
+		{
+			name:  "indices level",
+			level: IndicesHealthLevel,
+			url:   "http://localhost:9200/_cluster/health?level=indices",
+		},
+		{
+			name:  "shards level",
+			level: ShardsHealthLevel,
+			url:   "http://localhost:9200/_cluster/health?level=shards",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testGateway, err := New(testutil.NewExpectingClient(t, http.MethodGet, tt.url, "", 200, response), testutil.NewTestProfile())
+			assert.NoError(t, err)
+			actual, err := testGateway.Health(ctx, tt.level)
+			assert.NoError(t, err)
+			assert.EqualValues(t, response, actual)
+		})
+	}
+	t.Run("invalid level", func(t *testing.T) {
+		testGateway, err := New(testutil.NewExpectingClient(t, http.MethodGet, "", "", 200, nil), testutil.NewTestProfile())
+		assert.NoError(t, err)
+		_, err = testGateway.Health(ctx, "nodes")
+		assert.EqualError(t, err, "invalid health level: nodes, expected one of: cluster, indices, shards")
+	})
+	t.Run("health failed", func(t *testing.T) {
+		testGateway, err := New(testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_cluster/health", "", 403, []byte("no permissions")), testutil.NewTestProfile())
+		assert.NoError(t, err)
+		_, err = testGateway.Health(ctx, "")
+		assert.EqualError(t, err, "no permissions")
+	})
+}
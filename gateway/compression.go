@@ -0,0 +1,64 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const gzipEncoding = "gzip"
+
+//compressRequestBody replaces body of request with its gzip compressed value and sets "Content-Encoding: gzip".
+//It must be called before request is signed, since signature of AWS IAM profile covers body as it is sent.
+//Streamed body is sent uncompressed, since it would have to be buffered in memory to compress it here
+func compressRequestBody(req *retryablehttp.Request) error {
+	// body is compressed already, either by caller or by previous attempt
+	if isStreamed(req.Context()) || len(req.Header.Get("Content-Encoding")) > 0 {
+		return nil
+	}
+	body, err := req.BodyBytes()
+	if err != nil || len(body) == 0 {
+		return err
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err = writer.Write(body); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	if err = req.SetBody(compressed.Bytes()); err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", gzipEncoding)
+	return nil
+}
+
+//uncompressedBody returns body of request, decompressed if it was compressed by compressRequestBody
+func uncompressedBody(req *retryablehttp.Request) ([]byte, error) {
+	body, err := req.BodyBytes()
+	if err != nil || len(body) == 0 || !strings.EqualFold(req.Header.Get("Content-Encoding"), gzipEncoding) {
+		return body, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
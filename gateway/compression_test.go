@@ -0,0 +1,177 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func gunzip(t *testing.T, data []byte) []byte {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	result, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	return result
+}
+
+//setEnv sets environment variables and returns function to restore their previous values
+func setEnv(values map[string]string) func() {
+	previous := map[string]*string{}
+	for name, value := range values {
+		if old, ok := os.LookupEnv(name); ok {
+			previous[name] = &old
+		} else {
+			previous[name] = nil
+		}
+		_ = os.Setenv(name, value)
+	}
+	return func() {
+		for name, old := range previous {
+			if old == nil {
+				_ = os.Unsetenv(name)
+			} else {
+				_ = os.Setenv(name, *old)
+			}
+		}
+	}
+}
+
+//verifySignature signs request again with signed headers and body as received by server,
+//and compares its signature with request's signature
+func verifySignature(t *testing.T, r *http.Request, body []byte, accessKey, secretKey string) {
+	authorization := r.Header.Get("Authorization")
+	assert.NotEmpty(t, authorization)
+	signedHeaders := ""
+	for _, part := range strings.Split(authorization, ", ") {
+		if strings.HasPrefix(part, "SignedHeaders=") {
+			signedHeaders = strings.TrimPrefix(part, "SignedHeaders=")
+		}
+	}
+	signTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	assert.NoError(t, err)
+	req, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	assert.NoError(t, err)
+	for _, name := range strings.Split(signedHeaders, ";") {
+		if name != "host" && name != "content-length" {
+			req.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	req.ContentLength = r.ContentLength
+	signer := v4.NewSigner(credentials.NewStaticCredentials(accessKey, secretKey, ""))
+	_, err = signer.Sign(req, bytes.NewReader(body), "es", "us-west-2", signTime)
+	assert.NoError(t, err)
+	assert.EqualValues(t, req.Header.Get("Authorization"), authorization)
+}
+
+func TestGatewayCompressRequest(t *testing.T) {
+	payload := `{"name":"detector","password":"secret"}`
+	getServer := func(t *testing.T, verify func(r *http.Request, body []byte)) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			verify(r, body)
+			_, _ = w.Write([]byte("{}"))
+		}))
+	}
+	t.Run("compress body", func(t *testing.T) {
+		server := getServer(t, func(r *http.Request, body []byte) {
+			assert.EqualValues(t, "gzip", r.Header.Get("Content-Encoding"))
+			assert.EqualValues(t, payload, string(gunzip(t, body)))
+		})
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		var debug bytes.Buffer
+		testClient.Debug = &debug
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL, CompressRequest: true})
+		assert.NoError(t, err)
+		req, err := g.BuildCurlRequest(context.Background(), http.MethodPost, []byte(payload), server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		// debug log shows body before compression
+		assert.Contains(t, debug.String(), `"name":"detector"`)
+		assert.NotContains(t, debug.String(), "secret")
+	})
+	t.Run("sign compressed body", func(t *testing.T) {
+		restore := setEnv(map[string]string{
+			"AWS_ACCESS_KEY_ID":     "AKID",
+			"AWS_SECRET_ACCESS_KEY": "SECRET",
+			"AWS_SESSION_TOKEN":     "",
+			"AWS_REGION":            "us-west-2",
+		})
+		defer restore()
+		server := getServer(t, func(r *http.Request, body []byte) {
+			assert.EqualValues(t, "gzip", r.Header.Get("Content-Encoding"))
+			assert.EqualValues(t, payload, string(gunzip(t, body)))
+			verifySignature(t, r, body, "AKID", "SECRET")
+		})
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:        server.URL,
+			AWS:             &entity.AWSIAM{ServiceName: "es"},
+			CompressRequest: true,
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildCurlRequest(context.Background(), http.MethodPost, []byte(payload), server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+	})
+	t.Run("streamed body is not compressed", func(t *testing.T) {
+		server := getServer(t, func(r *http.Request, body []byte) {
+			assert.Empty(t, r.Header.Get("Content-Encoding"))
+			assert.EqualValues(t, payload, string(body))
+		})
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL, CompressRequest: true})
+		assert.NoError(t, err)
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, strings.NewReader(payload), server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+	})
+	t.Run("empty body is not compressed", func(t *testing.T) {
+		server := getServer(t, func(r *http.Request, body []byte) {
+			assert.Empty(t, r.Header.Get("Content-Encoding"))
+			assert.Empty(t, body)
+		})
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL, CompressRequest: true})
+		assert.NoError(t, err)
+		req, err := g.BuildCurlRequest(context.Background(), http.MethodGet, nil, server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"context"
+	"sync"
+)
+
+//ForEachConcurrent calls fn for every item using at most limit concurrent calls, limit less than 1 is treated as 1.
+//It stops scheduling new calls once ctx is done, those items get ctx's error. It returns error, which is nil
+//if fn succeeded, for every item once all scheduled calls are finished
+func ForEachConcurrent(ctx context.Context, items []string, limit int, fn func(context.Context, string) error) map[string]error {
+	if limit < 1 {
+		limit = 1
+	}
+	result := make(map[string]error, len(items))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	tokens := make(chan struct{}, limit)
+	for _, item := range items {
+		if err := acquire(ctx, tokens); err != nil {
+			mu.Lock()
+			result[item] = err
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(item string) {
+			defer func() {
+				<-tokens
+				wg.Done()
+			}()
+			err := fn(ctx, item)
+			mu.Lock()
+			result[item] = err
+			mu.Unlock()
+		}(item)
+	}
+	wg.Wait()
+	return result
+}
+
+//acquire waits for a free slot in tokens, it fails without holding any slot if ctx is done
+func acquire(ctx context.Context, tokens chan struct{}) error {
+	select {
+	case tokens <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	// both cases might be ready at the same time, don't schedule new work if ctx is done
+	if err := ctx.Err(); err != nil {
+		<-tokens
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachConcurrent(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	t.Run("limit is enforced", func(t *testing.T) {
+		var running, maxRunning int32
+		result := ForEachConcurrent(context.Background(), items, 3, func(ctx context.Context, item string) error {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if item == "b" {
+				return fmt.Errorf("failed %s", item)
+			}
+			return nil
+		})
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+		assert.Len(t, result, len(items))
+		for _, item := range items {
+			if item == "b" {
+				assert.EqualError(t, result[item], "failed b")
+				continue
+			}
+			assert.NoError(t, result[item])
+		}
+	})
+	t.Run("limit less than one runs sequentially", func(t *testing.T) {
+		var running, maxRunning int32
+		ForEachConcurrent(context.Background(), items, 0, func(ctx context.Context, item string) error {
+			if current := atomic.AddInt32(&running, 1); current > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, current)
+			}
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+		assert.EqualValues(t, 1, atomic.LoadInt32(&maxRunning))
+	})
+	t.Run("cancellation stops scheduling", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls int32
+		result := ForEachConcurrent(ctx, items, 2, func(ctx context.Context, item string) error {
+			if atomic.AddInt32(&calls, 1) == 2 {
+				cancel()
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+		assert.Len(t, result, len(items))
+		for _, item := range items {
+			assert.True(t, errors.Is(result[item], context.Canceled))
+		}
+	})
+}
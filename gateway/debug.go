@@ -0,0 +1,102 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	redacted                = "[REDACTED]"
+	streamedBodyPlaceholder = "[STREAMED BODY]"
+)
+
+//sensitiveHeaders are not logged since they contain credentials
+var sensitiveHeaders = map[string]bool{
+	"Authorization":        true,
+	"Proxy-Authorization":  true,
+	"Cookie":               true,
+	"Set-Cookie":           true,
+	"X-Amz-Security-Token": true,
+}
+
+//passwordFieldPattern matches json fields whose name contains password, like "password" or "new_password",
+//"hash" field of security plugin's internal users and "url" field of notification channels, since webhook
+//urls contain secret tokens
+var passwordFieldPattern = regexp.MustCompile(`("(?:[^"]*(?i:password)[^"]*|(?i:hash)|(?i:url))"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+//redactBody replaces value of password fields from body
+func redactBody(body []byte) string {
+	return passwordFieldPattern.ReplaceAllString(string(body), `$1"`+redacted+`"`)
+}
+
+//writeHeaders writes headers in sorted order, values of sensitive headers are redacted
+func writeHeaders(w io.Writer, prefix string, headers http.Header) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		_, _ = fmt.Fprintf(w, "%s%s: %s\n", prefix, name, value)
+	}
+}
+
+//logRequest writes method, url, headers and body of request, if debug is enabled
+func (g *HTTPGateway) logRequest(req *retryablehttp.Request) {
+	w := g.Client.Debug
+	if w == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "> %s %s\n", req.Method, req.URL.Redacted())
+	writeHeaders(w, "> ", req.Header)
+	// reading streamed body would consume it before it is sent
+	if isStreamed(req.Context()) {
+		_, _ = fmt.Fprintf(w, ">\n> %s\n", streamedBodyPlaceholder)
+		return
+	}
+	body, err := uncompressedBody(req)
+	if err != nil || len(body) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, ">\n> %s\n", redactBody(body))
+}
+
+//logResponse writes status and headers of response, if debug is enabled
+func (g *HTTPGateway) logResponse(response *http.Response) {
+	w := g.Client.Debug
+	if w == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "< %d %s\n", response.StatusCode, http.StatusText(response.StatusCode))
+	writeHeaders(w, "< ", response.Header)
+}
+
+//logResponseBody writes body of response, if debug is enabled
+func (g *HTTPGateway) logResponseBody(body []byte) {
+	w := g.Client.Debug
+	if w == nil || len(body) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "<\n< %s\n", redactBody(body))
+}
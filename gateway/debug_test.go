@@ -0,0 +1,108 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getDebugTestGateway(t *testing.T, code int, response string, p *entity.Profile) *HTTPGateway {
+	testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Request:    req,
+		}
+	})
+	g, err := NewHTTPGateway(testClient, p)
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGatewayDebug(t *testing.T) {
+	payload := map[string]interface{}{
+		"username": "admin",
+		"password": "secret",
+		"user": map[string]interface{}{
+			"new_password": "newsecret",
+		},
+	}
+	t.Run("log request and response with credentials redacted", func(t *testing.T) {
+		g := getDebugTestGateway(t, http.StatusOK, `{"status":"ok","password":"leaked"}`, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		var debug bytes.Buffer
+		g.Client.Debug = &debug
+		req, err := g.BuildRequest(context.Background(), http.MethodPut, payload, "http://localhost:9200/_plugins/_security/api/account", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		output := debug.String()
+		assert.Contains(t, output, "> PUT http://localhost:9200/_plugins/_security/api/account\n")
+		assert.Contains(t, output, "> Authorization: [REDACTED]\n")
+		assert.Contains(t, output, "> Content-Type: application/json\n")
+		assert.Contains(t, output, `"username":"admin"`)
+		assert.Contains(t, output, `"password":"[REDACTED]"`)
+		assert.Contains(t, output, `"new_password":"[REDACTED]"`)
+		assert.Contains(t, output, "< 200 OK\n")
+		assert.Contains(t, output, `{"status":"ok","password":"[REDACTED]"}`)
+		assert.NotContains(t, output, "secret")
+		assert.NotContains(t, output, "leaked")
+		assert.NotContains(t, output, "YWRtaW46YWRtaW4=")
+	})
+	t.Run("log error response", func(t *testing.T) {
+		g := getDebugTestGateway(t, http.StatusBadRequest, `{"error":"bad request"}`, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			Token:    "token",
+		})
+		var debug bytes.Buffer
+		g.Client.Debug = &debug
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.Error(t, err)
+		output := debug.String()
+		assert.Contains(t, output, "< 400 Bad Request\n")
+		assert.Contains(t, output, `{"error":"bad request"}`)
+		assert.NotContains(t, output, "Bearer token")
+	})
+	t.Run("silent by default", func(t *testing.T) {
+		g := getDebugTestGateway(t, http.StatusOK, `{}`, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+		})
+		assert.Nil(t, g.Client.Debug)
+		req, err := g.BuildRequest(context.Background(), http.MethodPost, payload, "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.EqualValues(t, `{"password" : "[REDACTED]", "name":"a"}`, redactBody([]byte(`{"password" : "p\"w", "name":"a"}`)))
+	assert.EqualValues(t, `{"Password":"[REDACTED]"}`, redactBody([]byte(`{"Password":"pw"}`)))
+	assert.EqualValues(t, "plain text", redactBody([]byte("plain text")))
+	assert.EqualValues(t, `{"hash":"[REDACTED]","hash_type":"bcrypt"}`, redactBody([]byte(`{"hash":"$2y$12$abc","hash_type":"bcrypt"}`)))
+	assert.EqualValues(t, `{"slack":{"url":"[REDACTED]"},"url_type":"slack"}`, redactBody([]byte(`{"slack":{"url":"https://hooks.slack.com/services/T0/B0/secret"},"url_type":"slack"}`)))
+}
@@ -0,0 +1,45 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+//dryRunResponse is returned instead of cluster's response for requests which are not sent
+const dryRunResponse = "{}"
+
+//dryRun writes method, url, headers and body of request to client's DryRun writer, with credentials redacted,
+//and returns successful response with empty json object without sending request to cluster
+func (g *HTTPGateway) dryRun(req *retryablehttp.Request) *http.Response {
+	w := g.Client.DryRun
+	_, _ = fmt.Fprintf(w, "%s %s\n", req.Method, req.URL.Redacted())
+	writeHeaders(w, "", req.Header)
+	// streamed body could be too large to print, and it is not replayable
+	if isStreamed(req.Context()) {
+		_, _ = fmt.Fprintf(w, "\n%s\n", streamedBodyPlaceholder)
+	} else if body, err := req.BodyBytes(); err == nil && len(body) > 0 {
+		_, _ = fmt.Fprintf(w, "\n%s\n", redactBody(body))
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(dryRunResponse)),
+		Request:    req.Request,
+	}
+}
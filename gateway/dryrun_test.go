@@ -0,0 +1,82 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayDryRun(t *testing.T) {
+	getDryRunGateway := func(t *testing.T) (*HTTPGateway, *bytes.Buffer) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			t.Errorf("request %s %s should not be sent", req.Method, req.URL)
+			return nil
+		})
+		var output bytes.Buffer
+		testClient.DryRun = &output
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
+		assert.NoError(t, err)
+		return g, &output
+	}
+	t.Run("delete by query is printed instead of being sent", func(t *testing.T) {
+		g, output := getDryRunGateway(t)
+		payload := map[string]interface{}{"query": map[string]interface{}{"term": map[string]string{"state": "disabled"}}}
+		req, err := g.BuildRequest(context.Background(), http.MethodPost, payload, "http://localhost:9200/.opendistro-anomaly-detectors/_delete_by_query", GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.Equal(t, "{}", string(response))
+		assert.Equal(t, "POST http://localhost:9200/.opendistro-anomaly-detectors/_delete_by_query\n"+
+			"Authorization: [REDACTED]\n"+
+			"Content-Type: application/json\n"+
+			"User-Agent: "+GetDefaultUserAgent()+"\n"+
+			"\n"+
+			`{"query":{"term":{"state":"disabled"}}}`+"\n", output.String())
+	})
+	t.Run("expected status code is not checked", func(t *testing.T) {
+		g, output := getDryRunGateway(t)
+		req, err := g.BuildRequest(context.Background(), http.MethodDelete, "", "http://localhost:9200/_plugins/_anomaly_detection/detectors/detectorID", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.CallExpecting(req, http.StatusCreated)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output.String(), "DELETE http://localhost:9200/_plugins/_anomaly_detection/detectors/detectorID\n"))
+	})
+	t.Run("passwords are redacted", func(t *testing.T) {
+		g, output := getDryRunGateway(t)
+		req, err := g.BuildRequest(context.Background(), http.MethodPut, map[string]string{"password": "secret"}, "http://localhost:9200/_plugins/_security/api/account", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.Contains(t, output.String(), `{"password":"[REDACTED]"}`)
+		assert.NotContains(t, output.String(), "secret")
+	})
+	t.Run("streamed body is not printed", func(t *testing.T) {
+		g, output := getDryRunGateway(t)
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, strings.NewReader(`{"index":{}}`+"\n"), "http://localhost:9200/_bulk", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.Contains(t, output.String(), "\n"+streamedBodyPlaceholder+"\n")
+	})
+}
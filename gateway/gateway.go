@@ -26,13 +26,17 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"opensearch-cli/client"
@@ -40,60 +44,260 @@ import (
 	"opensearch-cli/entity/platform"
 	"opensearch-cli/environment"
 	"opensearch-cli/gateway/aws/signer"
+	"opensearch-cli/logger"
+	"opensearch-cli/version"
 	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
+const (
+	userAgentHeader     = "User-Agent"
+	userAgentPrefix     = "opensearch-cli/"
+	authorizationHeader = "Authorization"
+	bearerAuthPrefix    = "Bearer "
+	apiKeyAuthPrefix    = "ApiKey "
+	opaqueIDHeader      = "X-Opaque-Id"
+)
+
+//ErrTimeout is returned if cluster didn't respond within timeout configured for the profile
+var ErrTimeout = errors.New("request timed out")
+
+//ErrResponseTooLarge is returned if response body is larger than maximum response size of the client
+var ErrResponseTooLarge = errors.New("response too large")
+
+//ResponseError is returned by Call if response's status code is not expected, it contains
+//response from OpenSearch which usually explains what went wrong
+type ResponseError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Method     string
+	URL        string
+}
+
+//Response is returned by CallWithResponse, it keeps status code and headers of response along with its body
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+//Error returns response body from OpenSearch, formatted if body is json
+func (r *ResponseError) Error() string {
+	var data map[string]interface{}
+	if err := json.Unmarshal(r.Body, &data); err != nil {
+		return string(r.Body)
+	}
+	formattedResponse, _ := json.MarshalIndent(data, "", "  ")
+	return string(formattedResponse)
+}
+
+//ReasonError replaces message of error returned by cluster with reason parsed from its response, error
+//is kept, so that callers can still find status code of response
+type ReasonError struct {
+	Reason string
+	Err    error
+}
+
+func (r *ReasonError) Error() string {
+	return r.Reason
+}
+
+func (r *ReasonError) Unwrap() error {
+	return r.Err
+}
+
 //HTTPGateway type for gateway client
 type HTTPGateway struct {
 	Client  *client.Client
 	Profile *entity.Profile
+	// PluginPathFallback calls plugin APIs again with legacy _opendistro prefix if cluster responds with 404 to
+	// _plugins prefix, unless profile sets plugin path prefix
+	PluginPathFallback bool
+	// cluster is shared by copies of the gateway, like gateways of plugins which embed it
+	cluster *clusterState
+}
+
+//clusterState remembers endpoint that answered after failover, and plugin path prefix that cluster answered to,
+//so that remaining requests of the gateway don't try them again
+type clusterState struct {
+	sync.Mutex
+	endpoint         string
+	pluginPathPrefix string
+}
+
+func (s *clusterState) getEndpoint() string {
+	if s == nil {
+		return ""
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.endpoint
+}
+
+func (s *clusterState) setEndpoint(endpoint string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.endpoint = endpoint
+}
+
+func (s *clusterState) getPluginPathPrefix() string {
+	if s == nil {
+		return ""
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.pluginPathPrefix
+}
+
+func (s *clusterState) setPluginPathPrefix(prefix string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.pluginPathPrefix = prefix
 }
 
 //GetDefaultHeaders returns common headers
 func GetDefaultHeaders() map[string]string {
 	return map[string]string{
-		"content-type": "application/json",
+		"content-type":  "application/json",
+		userAgentHeader: GetDefaultUserAgent(),
 	}
 }
+
+//MergeHeaders returns headers of every map, header names are compared ignoring case,
+//and value from later map wins over earlier ones
+func MergeHeaders(headers ...map[string]string) map[string]string {
+	result := map[string]string{}
+	for _, h := range headers {
+		for key, value := range h {
+			result[http.CanonicalHeaderKey(key)] = value
+		}
+	}
+	return result
+}
+
+//GetDefaultUserAgent returns user agent which identifies opensearch-cli and its version
+func GetDefaultUserAgent() string {
+	return userAgentPrefix + version.Version
+}
+
+//GetTLSConfig builds tls config from client certificate, client key and CA certificate. Client certificate and key
+//must be provided together, they are presented to cluster which verifies client certificates (mTLS)
 func GetTLSConfig(trust *entity.Trust) (*tls.Config, error) {
 	config := &tls.Config{}
-	if trust.ClientCertificateFilePath != nil && trust.ClientKeyFilePath != nil {
+	cert, err := getClientCertificate(trust)
+	if err != nil {
+		return nil, err
+	}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+	caCert, err := readPEM(trust.CAFilePath, trust.CAPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error opening certificate file %s, error: %s", *trust.CAFilePath, err)
+	}
+	if caCert != nil {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid certificate found in CA certificate")
+		}
+		config.RootCAs = caCertPool
+	}
+	return config, nil
+}
+
+//getClientCertificate loads client certificate and key, either from file or PEM encoded values
+func getClientCertificate(trust *entity.Trust) (*tls.Certificate, error) {
+	hasCertificate := trust.ClientCertificateFilePath != nil || trust.ClientCertificatePEM != nil
+	hasKey := trust.ClientKeyFilePath != nil || trust.ClientKeyPEM != nil
+	if !hasCertificate && !hasKey {
+		return nil, nil
+	}
+	if hasCertificate != hasKey {
+		return nil, fmt.Errorf("client certificate and client key must be provided together")
+	}
+	if trust.ClientCertificatePEM == nil && trust.ClientKeyPEM == nil {
 		cert, err := tls.LoadX509KeyPair(*trust.ClientCertificateFilePath, *trust.ClientKeyFilePath)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"error creating x509 keypair from client cert file %s and client key file %s",
 				*trust.ClientCertificateFilePath, *trust.ClientKeyFilePath)
 		}
-		config.Certificates = []tls.Certificate{cert}
+		return &cert, nil
+	}
+	certPEM, err := readPEM(trust.ClientCertificateFilePath, trust.ClientCertificatePEM)
+	if err != nil {
+		return nil, fmt.Errorf("error opening client certificate file %s, error: %s", *trust.ClientCertificateFilePath, err)
 	}
-	caCertPool := x509.NewCertPool()
-	if trust.CAFilePath != nil {
-		caCert, err := ioutil.ReadFile(*trust.CAFilePath)
+	keyPEM, err := readPEM(trust.ClientKeyFilePath, trust.ClientKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error opening client key file %s, error: %s", *trust.ClientKeyFilePath, err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error creating x509 keypair from client certificate and client key: %v", err)
+	}
+	return &cert, nil
+}
+
+//readPEM returns PEM encoded value if provided, else, reads it from file path
+func readPEM(filePath *string, value *string) ([]byte, error) {
+	if value != nil {
+		return []byte(*value), nil
+	}
+	if filePath == nil {
+		return nil, nil
+	}
+	return ioutil.ReadFile(*filePath)
+}
+
+//configureTransport configures transport based on profile's certificate, proxy and connection pool settings
+func configureTransport(transport *http.Transport, p *entity.Profile) error {
+	tlsConfig := &tls.Config{}
+	if p.Certificate != nil {
+		config, err := GetTLSConfig(p.Certificate)
 		if err != nil {
-			return nil, fmt.Errorf("error opening certificate file %s, error: %s", *trust.CAFilePath, err)
+			return err
 		}
-		caCertPool.AppendCertsFromPEM(caCert)
-		config.RootCAs = caCertPool
+		tlsConfig = config
 	}
-
-	return config, nil
+	tlsConfig.InsecureSkipVerify = p.Insecure
+	transport.TLSClientConfig = tlsConfig
+	if len(p.Proxy) > 0 {
+		proxyURL, err := url.Parse(p.Proxy)
+		if err != nil || len(proxyURL.Scheme) == 0 || len(proxyURL.Host) == 0 {
+			return fmt.Errorf("invalid proxy: %s", p.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if p.MaxIdleConns != nil {
+		transport.MaxIdleConns = *p.MaxIdleConns
+	}
+	if p.MaxIdleConnsPerHost != nil {
+		transport.MaxIdleConnsPerHost = *p.MaxIdleConnsPerHost
+	}
+	return nil
 }
 
 //NewHTTPGateway creates new HTTPGateway instance
 func NewHTTPGateway(c *client.Client, p *entity.Profile) (*HTTPGateway, error) {
 
-	if p.Certificate != nil {
-		tlsConfig, err := GetTLSConfig(p.Certificate)
-		if err != nil {
+	if p.Certificate != nil || p.Insecure || len(p.Proxy) > 0 || p.MaxIdleConns != nil || p.MaxIdleConnsPerHost != nil {
+		// configure client's own clone of transport, which gateways created from same client share
+		if err := configureTransport(client.CloneTransport(c), p); err != nil {
 			return nil, err
 		}
-		c.HTTPClient.HTTPClient.Transport = &http.Transport{
-			TLSClientConfig: tlsConfig,
-		}
 	}
 
 	// set max retry if provided by command
@@ -114,12 +318,121 @@ func NewHTTPGateway(c *client.Client, p *entity.Profile) (*HTTPGateway, error) {
 		c.HTTPClient.HTTPClient.Timeout = time.Duration(*duration) * time.Second
 	}
 
+	if len(p.RedirectPolicy) > 0 {
+		if err := client.SetRedirectPolicy(c, client.RedirectPolicy(p.RedirectPolicy)); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.MaxResponseSize != nil {
+		c.MaxResponseSize = *p.MaxResponseSize
+	}
+
+	if p.OpaqueID && len(c.OpaqueID) == 0 {
+		opaqueID, err := getInvocationOpaqueID()
+		if err != nil {
+			return nil, err
+		}
+		c.OpaqueID = opaqueID
+	}
+
+	if p.Compression || p.CompressRequest {
+		client.EnableCompression(c)
+	}
+
+	c.HTTPClient.CheckRetry = getRetryPolicy(c)
+	if p.JitterBackoff {
+		c.HTTPClient.Backoff = JitterBackoff
+	}
+	// pass last response to caller once retries are exhausted, to report status code from cluster
+	c.HTTPClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
 	return &HTTPGateway{
 		Client:  c,
 		Profile: p,
+		cluster: &clusterState{},
 	}, nil
 }
 
+//requestAttempts counts attempts of a request, it is stored in request's context
+type requestAttempts struct {
+	method string
+	path   string
+	count  int32
+}
+
+type requestAttemptsKey struct{}
+
+//getRetryPolicy returns retry policy which doesn't retry requests that are not safe to send again unless client's
+//retry policy allows it. Client's retry predicate decides remaining requests if set, else, default policy retries
+//connection errors, throttled requests and other server errors
+func getRetryPolicy(c *client.Client) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		// do not retry if request is cancelled or deadline is exceeded
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		// streamed body was already sent and cannot be sent again
+		if isStreamed(ctx) {
+			return false, nil
+		}
+		// redirect policy doesn't change between attempts
+		if errors.Is(err, client.ErrRedirectNotFollowed) {
+			return false, nil
+		}
+		attempt := 1
+		if attempts, ok := ctx.Value(requestAttemptsKey{}).(*requestAttempts); ok {
+			attempt = int(atomic.AddInt32(&attempts.count, 1))
+			if !c.RetryPolicy.CanRetry(attempts.method, attempts.path) {
+				return false, nil
+			}
+		}
+		if c.RetryPolicy.Predicate != nil {
+			return c.RetryPolicy.Predicate(resp, err, attempt), nil
+		}
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+}
+
+//getRetryAfter parses Retry-After header which is either delay in seconds or http date
+func getRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+//JitterBackoff waits as long as server asked for using Retry-After header, up to max, else,
+//performs exponential backoff with random jitter between half and full duration
+func JitterBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if delay, ok := getRetryAfter(resp); ok {
+		// do not let server keep the command waiting longer than any other backoff
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+	backoff := retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return backoff
+	}
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
 func overrideValue(p *entity.Profile, envVariable string) (*int, bool) {
 	if val, ok := os.LookupEnv(envVariable); ok {
 		//ignore error from non positive number
@@ -138,64 +451,309 @@ func (g *HTTPGateway) isValidResponse(response *http.Response) error {
 	// client error if 400 <= status code < 500
 	if response.StatusCode >= http.StatusBadRequest && response.StatusCode < http.StatusInternalServerError {
 
-		return platform.NewRequestError(
-			response.StatusCode,
-			response.Body,
+		return platform.NewRequestErrorFromResponse(
+			response,
 			fmt.Errorf("%d Client Error: %s for url: %s", response.StatusCode, response.Status, response.Request.URL))
 	}
 	// server error if status code >= 500
 	if response.StatusCode >= http.StatusInternalServerError {
 
-		return platform.NewRequestError(
-			response.StatusCode,
-			response.Body,
+		return platform.NewRequestErrorFromResponse(
+			response,
 			fmt.Errorf("%d Server Error: %s for url: %s", response.StatusCode, response.Status, response.Request.URL))
 	}
 	return nil
 }
 
-//Execute calls request using http and check if status code is ok or not
-func (g *HTTPGateway) Execute(req *retryablehttp.Request) ([]byte, error) {
+//toTimeoutError reports cancellation or deadline of request's context instead of http client's error,
+//and wraps ErrTimeout if cluster didn't respond within client's timeout
+func (g *HTTPGateway) toTimeoutError(req *retryablehttp.Request, err error) error {
+	if ctxErr := req.Context().Err(); ctxErr != nil {
+		return ctxErr
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%w: no response from %s within %v", ErrTimeout, req.URL.Redacted(), g.Client.HTTPClient.HTTPClient.Timeout)
+	}
+	return err
+}
+
+//shouldFailover returns true if request failed because cluster is unavailable,
+//either connection failed or it responded with server error
+func shouldFailover(req *retryablehttp.Request, err error) bool {
+	if req.Context().Err() != nil || isStreamed(req.Context()) || errors.Is(err, client.ErrRedirectNotFollowed) {
+		return false
+	}
+	if r, ok := err.(*platform.RequestError); ok {
+		return r.StatusCode() >= http.StatusInternalServerError
+	}
+	return true
+}
+
+//send calls request using http and check if status code is ok or not, caller must close response's body.
+//Plugin APIs are called with plugin path prefix of the profile, and with legacy _opendistro prefix if
+//cluster doesn't know _plugins prefix. Body is compressed here if profile asks for it, before request is signed
+func (g *HTTPGateway) send(req *retryablehttp.Request) (*http.Response, error) {
+	isPluginRequest := setPluginPathPrefix(req.URL, g.getPluginPathPrefix())
+	if g.Client.DryRun != nil {
+		return g.dryRun(req), nil
+	}
+	if g.Profile.CompressRequest {
+		if err := compressRequestBody(req); err != nil {
+			return nil, err
+		}
+	}
+	response, err := g.sendWithFailover(req)
+	if !isPluginRequest || !g.PluginPathFallback || !shouldFallbackToOpenDistro(g.Profile, req, err) {
+		return response, err
+	}
+	setPluginPathPrefix(req.URL, entity.OpenDistroPathPrefix)
+	logger.Verbosef("%s is not found, trying legacy %s prefix", req.URL.Redacted(), entity.OpenDistroPathPrefix)
+	fallbackResponse, fallbackErr := g.sendWithFailover(req)
+	if isNotFound(fallbackErr) {
+		// cluster doesn't know legacy prefix either, hence, resource is not found
+		return response, err
+	}
+	if fallbackErr == nil {
+		g.cluster.setPluginPathPrefix(entity.OpenDistroPathPrefix)
+	}
+	return fallbackResponse, fallbackErr
+}
+
+//sendWithFailover calls request using http and check if status code is ok or not, caller must close response's body.
+//If cluster is unavailable, request is sent to remaining endpoints of the profile in order, and endpoint
+//that answered is used for remaining requests
+func (g *HTTPGateway) sendWithFailover(req *retryablehttp.Request) (*http.Response, error) {
+	g.useActiveEndpoint(req)
+	response, err := g.sendOnce(req)
+	if err == nil || !shouldFailover(req, err) {
+		return response, err
+	}
+	tried := map[string]bool{req.URL.Scheme + "://" + req.URL.Host: true}
+	for _, endpoint := range g.Profile.GetEndpoints() {
+		u, parseErr := url.ParseRequestURI(endpoint)
+		if parseErr != nil || tried[u.Scheme+"://"+u.Host] {
+			continue
+		}
+		tried[u.Scheme+"://"+u.Host] = true
+		logger.Verbosef("%s://%s is unavailable, trying %s", req.URL.Scheme, req.URL.Host, endpoint)
+		setEndpoint(req, u)
+		response, err = g.sendOnce(req)
+		if err == nil || !shouldFailover(req, err) {
+			g.cluster.setEndpoint(endpoint)
+			return response, err
+		}
+	}
+	return nil, err
+}
+
+//sendOnce calls request using http and check if status code is ok or not, caller must close response's body
+func (g *HTTPGateway) sendOnce(req *retryablehttp.Request) (*http.Response, error) {
 	if g.Profile.AWS != nil {
 		//sign request
 		if err := signer.SignRequest(req, *g.Profile.AWS, signer.GetV4Signer); err != nil {
 			return nil, err
 		}
 	}
+	g.logRequest(req)
 	response, err := g.Client.HTTPClient.Do(req)
+	if err != nil {
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		return nil, g.toTimeoutError(req, err)
+	}
+	g.logResponse(response)
+	logWarnings(response)
+	if err = g.isValidResponse(response); err != nil {
+		_ = response.Body.Close()
+		if r, ok := err.(*platform.RequestError); ok {
+			g.logResponseBody(r.GetResponseBody())
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+//Execute calls request using http and check if status code is ok or not
+func (g *HTTPGateway) Execute(req *retryablehttp.Request) ([]byte, error) {
+	response, err := g.send(req)
 	if err != nil {
 		return nil, err
 	}
+	return g.readResponse(req, response)
+}
+
+//readResponse reads and closes body of response
+func (g *HTTPGateway) readResponse(req *retryablehttp.Request, response *http.Response) ([]byte, error) {
 	defer func() {
 		err := response.Body.Close()
 		if err != nil {
 			return
 		}
 	}()
-	if err = g.isValidResponse(response); err != nil {
+	resBytes, err := g.readBody(response.Body)
+	if err != nil {
+		return nil, g.toTimeoutError(req, err)
+	}
+	g.logResponseBody(resBytes)
+	return resBytes, nil
+}
+
+//readBody reads body up to client's maximum response size, and returns ErrResponseTooLarge if body is larger,
+//so that unexpectedly large response does not exhaust memory
+func (g *HTTPGateway) readBody(body io.Reader) ([]byte, error) {
+	limit := g.Client.MaxResponseSize
+	if limit <= 0 {
+		return ioutil.ReadAll(body)
+	}
+	resBytes, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
 		return nil, err
 	}
-	return ioutil.ReadAll(response.Body)
+	if int64(len(resBytes)) > limit {
+		return nil, fmt.Errorf("%w: response exceeded %d bytes, increase max_response_size of the profile to read it", ErrResponseTooLarge, limit)
+	}
+	return resBytes, nil
 }
 
 //Call calls request using http and return error if status code is not expected
 func (g *HTTPGateway) Call(req *retryablehttp.Request, statusCode int) ([]byte, error) {
-	resBytes, err := g.Execute(req)
+	return g.CallExpecting(req, statusCode)
+}
+
+//CallExpecting calls request using http and return error if status code is not one of accepted status codes.
+//Empty or whitespace only response body is returned as nil
+func (g *HTTPGateway) CallExpecting(req *retryablehttp.Request, accepted ...int) ([]byte, error) {
+	response, err := g.CallWithResponse(req, accepted...)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+//CallWithResponse calls request using http and returns response's status code, headers and body, so that
+//callers can inspect headers like rate limits. It returns error if status code is neither successful
+//nor one of accepted status codes. Empty or whitespace only response body is returned as nil
+func (g *HTTPGateway) CallWithResponse(req *retryablehttp.Request, accepted ...int) (*Response, error) {
+	response, err := g.send(req)
+	if err != nil {
+		return checkStatus(req, err, accepted)
+	}
+	resBytes, err := g.readResponse(req, response)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       normalizeBody(resBytes),
+	}, nil
+}
+
+//normalizeBody returns nil if body is empty or has only whitespace, so that callers can distinguish
+//empty response from response with content
+func normalizeBody(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return body
+}
+
+//Ping checks whether cluster is reachable using profile's endpoint and credentials.
+//It calls http request: GET /
+func (g *HTTPGateway) Ping(ctx context.Context) error {
+	endpoint, err := GetValidEndpoint(g.Profile)
+	if err != nil {
+		return err
+	}
+	endpoint.Path = "/"
+	req, err := g.BuildRequest(ctx, http.MethodGet, "", endpoint.String(), GetDefaultHeaders())
+	if err != nil {
+		return err
+	}
+	_, err = g.Call(req, http.StatusOK)
+	return toPingError(endpoint, err)
+}
+
+//toPingError explains why cluster couldn't be reached
+func toPingError(endpoint *url.URL, err error) error {
+	if err == nil {
+		return nil
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return fmt.Errorf("failed to resolve host %s of endpoint %s: %w", endpoint.Hostname(), endpoint.Redacted(), err)
+	}
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateErr x509.CertificateInvalidError
+	var recordHeaderErr tls.RecordHeaderError
+	if errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &certificateErr) || errors.As(err, &recordHeaderErr) {
+		return fmt.Errorf("TLS handshake with %s failed: %w", endpoint.Redacted(), err)
+	}
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) {
+		switch responseErr.StatusCode {
+		case http.StatusUnauthorized:
+			return fmt.Errorf("authentication failed for %s, check credentials of the profile: %w", endpoint.Redacted(), err)
+		case http.StatusForbidden:
+			return fmt.Errorf("user is not allowed to access %s: %w", endpoint.Redacted(), err)
+		}
+		return fmt.Errorf("cluster %s responded with status %d: %w", endpoint.Redacted(), responseErr.StatusCode, err)
+	}
+	return fmt.Errorf("failed to connect to %s: %w", endpoint.Redacted(), err)
+}
+
+//CallStream calls request using http and returns response body without reading it, so that caller can
+//decode large responses incrementally. Caller must close returned body.
+//It returns error if status code is not one of accepted status codes
+func (g *HTTPGateway) CallStream(req *retryablehttp.Request, accepted ...int) (io.ReadCloser, error) {
+	response, err := g.send(req)
 	if err == nil {
-		return resBytes, nil
+		return response.Body, nil
 	}
+	errResponse, err := checkStatus(req, err, accepted)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(errResponse.Body)), nil
+}
+
+//checkStatus returns response if request failed with one of accepted status codes, else ResponseError
+func checkStatus(req *retryablehttp.Request, err error, accepted []int) (*Response, error) {
 	r, ok := err.(*platform.RequestError)
 	if !ok {
 		return nil, err
 	}
-	if r.StatusCode() != statusCode {
-		return nil, fmt.Errorf(r.GetResponse())
+	if isAccepted(r.StatusCode(), accepted) {
+		return &Response{
+			StatusCode: r.StatusCode(),
+			Header:     r.Header(),
+			Body:       normalizeBody(r.GetResponseBody()),
+		}, nil
 	}
-	return nil, err
+	return nil, &ResponseError{
+		StatusCode: r.StatusCode(),
+		Header:     r.Header(),
+		Body:       r.GetResponseBody(),
+		Method:     req.Method,
+		URL:        req.URL.String(),
+	}
+}
 
+func isAccepted(statusCode int, accepted []int) bool {
+	for _, code := range accepted {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
 }
 
-//BuildRequest builds request based on method and appends payload for given url with headers
+//BuildRequest builds request based on method and appends payload for given url with headers,
+//headers are merged over default headers, hence, caller can override Accept or Content-Type
 // TODO: Deprecate this method by replace this with BuildCurlRequest
 func (g *HTTPGateway) BuildRequest(ctx context.Context, method string, payload interface{}, url string, headers map[string]string) (*retryablehttp.Request, error) {
 	reqBytes, err := json.Marshal(payload)
@@ -207,28 +765,95 @@ func (g *HTTPGateway) BuildRequest(ctx context.Context, method string, payload i
 
 //BuildCurlRequest builds request based on method and add payload (in byte)
 func (g *HTTPGateway) BuildCurlRequest(ctx context.Context, method string, payload []byte, url string, headers map[string]string) (*retryablehttp.Request, error) {
-	r, err := retryablehttp.NewRequest(method, url, payload)
+	return g.newRequest(ctx, method, payload, url, headers)
+}
+
+//newRequest builds request with given body, which is any body supported by retryablehttp, and sets headers
+//merged over default headers, credentials and user agent
+func (g *HTTPGateway) newRequest(ctx context.Context, method string, body interface{}, url string, headers map[string]string) (*retryablehttp.Request, error) {
+	r, err := retryablehttp.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
 	}
-	req := r.WithContext(ctx)
-	if len(g.Profile.UserName) != 0 {
-		req.SetBasicAuth(g.Profile.UserName, g.Profile.Password)
-	}
-	if len(headers) == 0 {
-		return req, nil
+	req := r.WithContext(context.WithValue(ctx, requestAttemptsKey{}, &requestAttempts{method: method, path: r.URL.Path}))
+	// body must be replayable to follow 307 and 308 redirects
+	if payload, ok := body.([]byte); ok {
+		req.Request.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(payload)), nil
+		}
 	}
-	for key, value := range headers {
+	for key, value := range MergeHeaders(GetDefaultHeaders(), headers) {
 		req.Header.Set(key, value)
 	}
+	// authorization header provided explicitly for this request is not overridden by profile's credentials
+	if len(req.Header.Get(authorizationHeader)) == 0 {
+		setAuthorization(req, g.Profile)
+	}
+	setUserAgent(req, g.Profile)
+	if len(g.Client.OpaqueID) > 0 && len(req.Header.Get(opaqueIDHeader)) == 0 {
+		req.Header.Set(opaqueIDHeader, g.Client.OpaqueID)
+	}
 	return req, nil
 }
 
-//GetValidEndpoint get url based on user config
+//setUserAgent sets user agent from profile if provided, else, default user agent.
+//User agent provided explicitly for this request is not overridden
+func setUserAgent(req *retryablehttp.Request, p *entity.Profile) {
+	userAgent := req.Header.Get(userAgentHeader)
+	if len(userAgent) != 0 && userAgent != GetDefaultUserAgent() {
+		return
+	}
+	if len(p.UserAgent) != 0 {
+		req.Header.Set(userAgentHeader, p.UserAgent)
+		return
+	}
+	req.Header.Set(userAgentHeader, GetDefaultUserAgent())
+}
+
+//setAuthorization sets authorization header from profile, bearer token takes precedence over api key,
+//and api key takes precedence over basic authentication
+func setAuthorization(req *retryablehttp.Request, p *entity.Profile) {
+	switch {
+	case len(p.Token) != 0:
+		req.Header.Set(authorizationHeader, bearerAuthPrefix+p.Token)
+	case len(p.APIKey) != 0:
+		req.Header.Set(authorizationHeader, apiKeyAuthPrefix+p.APIKey)
+	case len(p.UserName) != 0:
+		req.SetBasicAuth(p.UserName, p.Password)
+	}
+}
+
+//useActiveEndpoint sends request to endpoint that answered after failover, if request is built for
+//one of the endpoints of the profile
+func (g *HTTPGateway) useActiveEndpoint(req *retryablehttp.Request) {
+	active, err := url.ParseRequestURI(g.cluster.getEndpoint())
+	if err != nil {
+		return
+	}
+	for _, endpoint := range g.Profile.GetEndpoints() {
+		if u, err := url.ParseRequestURI(endpoint); err == nil && u.Scheme == req.URL.Scheme && u.Host == req.URL.Host {
+			setEndpoint(req, active)
+			return
+		}
+	}
+}
+
+//setEndpoint replaces scheme and host of request with endpoint's
+func setEndpoint(req *retryablehttp.Request, endpoint *url.URL) {
+	req.URL.Scheme = endpoint.Scheme
+	req.URL.Host = endpoint.Host
+	req.Host = endpoint.Host
+}
+
+//GetValidEndpoint get url based on user config, which is first endpoint of the profile
 func GetValidEndpoint(profile *entity.Profile) (*url.URL, error) {
-	u, err := url.ParseRequestURI(profile.Endpoint)
+	var endpoint string
+	if endpoints := profile.GetEndpoints(); len(endpoints) > 0 {
+		endpoint = endpoints[0]
+	}
+	u, err := url.ParseRequestURI(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("invalid endpoint: %v due to %v", profile.Endpoint, err)
+		return nil, fmt.Errorf("invalid endpoint: %v due to %v", endpoint, err)
 	}
 	return u, nil
 }
@@ -26,11 +26,33 @@
 package gateway
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
 	"opensearch-cli/client/mocks"
 	"opensearch-cli/entity"
 	"opensearch-cli/environment"
 	"opensearch-cli/mapper"
+	"opensearch-cli/version"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -195,3 +217,1165 @@ func TestGatewayTLSConnection(t *testing.T) {
 		assert.EqualError(t, err, "error creating x509 keypair from client cert file testdata/client1.cert and client key file testdata/client.key")
 	})
 }
+
+func TestGatewayRetryThrottled(t *testing.T) {
+	getFlakyServer := func(throttledCount int, retryAfter string) (*httptest.Server, *int32) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if int(atomic.AddInt32(&attempts, 1)) <= throttledCount {
+				w.Header().Set("Retry-After", retryAfter)
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte("too many requests"))
+				return
+			}
+			_, _ = w.Write([]byte("success"))
+		}))
+		return server, &attempts
+	}
+	t.Run("retry throttled request until success", func(t *testing.T) {
+		server, attempts := getFlakyServer(2, "0")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: server.URL,
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(response))
+		assert.EqualValues(t, 3, atomic.LoadInt32(attempts))
+	})
+	t.Run("retry throttled request with jitter backoff", func(t *testing.T) {
+		server, attempts := getFlakyServer(2, "0")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:      server.URL,
+			JitterBackoff: true,
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(response))
+		assert.EqualValues(t, 3, atomic.LoadInt32(attempts))
+	})
+	t.Run("retries are limited by max retry", func(t *testing.T) {
+		server, attempts := getFlakyServer(5, "0")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		maxRetry := 1
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: server.URL,
+			MaxRetry: &maxRetry,
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.EqualError(t, err, "too many requests")
+		assert.EqualValues(t, 2, atomic.LoadInt32(attempts))
+	})
+	t.Run("stop retrying if context is cancelled", func(t *testing.T) {
+		server, attempts := getFlakyServer(5, "10")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: server.URL,
+		})
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, err := g.BuildRequest(ctx, http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.EqualError(t, err, context.DeadlineExceeded.Error())
+		assert.EqualValues(t, 1, atomic.LoadInt32(attempts))
+	})
+}
+
+func TestGatewayRetryPolicy(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	call := func(t *testing.T, method string, path string, policy client.RetryPolicy) error {
+		atomic.StoreInt32(&hits, 0)
+		retry := 2
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testClient.RetryPolicy = policy
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL, MaxRetry: &retry})
+		assert.NoError(t, err)
+		testClient.HTTPClient.RetryWaitMin = time.Millisecond
+		testClient.HTTPClient.RetryWaitMax = time.Millisecond
+		req, err := g.BuildRequest(context.Background(), method, map[string]string{}, server.URL+path, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		return err
+	}
+	t.Run("idempotent request is retried", func(t *testing.T) {
+		assert.Error(t, call(t, http.MethodDelete, "/_plugins/_anomaly_detection/detectors/id", client.RetryPolicy{}))
+		assert.EqualValues(t, 3, atomic.LoadInt32(&hits))
+	})
+	t.Run("search is retried", func(t *testing.T) {
+		assert.Error(t, call(t, http.MethodPost, "/_plugins/_anomaly_detection/detectors/_search", client.RetryPolicy{}))
+		assert.EqualValues(t, 3, atomic.LoadInt32(&hits))
+	})
+	t.Run("create is not retried", func(t *testing.T) {
+		assert.Error(t, call(t, http.MethodPost, "/_plugins/_anomaly_detection/detectors", client.RetryPolicy{}))
+		assert.EqualValues(t, 1, atomic.LoadInt32(&hits))
+	})
+	t.Run("create is retried if allowed", func(t *testing.T) {
+		assert.Error(t, call(t, http.MethodPost, "/_plugins/_anomaly_detection/detectors", client.RetryPolicy{RetryNonIdempotent: true}))
+		assert.EqualValues(t, 3, atomic.LoadInt32(&hits))
+	})
+	t.Run("custom predicate", func(t *testing.T) {
+		var attempts []int
+		policy := client.RetryPolicy{Predicate: func(resp *http.Response, err error, attempt int) bool {
+			attempts = append(attempts, attempt)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			return attempt < 2
+		}}
+		assert.Error(t, call(t, http.MethodGet, "/", policy))
+		assert.EqualValues(t, 2, atomic.LoadInt32(&hits))
+		assert.Equal(t, []int{1, 2}, attempts)
+	})
+	t.Run("custom predicate is not consulted for non idempotent request", func(t *testing.T) {
+		policy := client.RetryPolicy{Predicate: func(*http.Response, error, int) bool {
+			t.Error("predicate must not be called")
+			return true
+		}}
+		assert.Error(t, call(t, http.MethodPost, "/_plugins/_anomaly_detection/detectors", policy))
+		assert.EqualValues(t, 1, atomic.LoadInt32(&hits))
+	})
+}
+
+func TestJitterBackoff(t *testing.T) {
+	t.Run("honor retry after in seconds", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+		assert.EqualValues(t, 3*time.Second, JitterBackoff(time.Second, 30*time.Second, 0, resp))
+	})
+	t.Run("retry after is capped", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+		assert.EqualValues(t, 30*time.Second, JitterBackoff(time.Second, 30*time.Second, 0, resp))
+		resp = &http.Response{Header: http.Header{"Retry-After": []string{time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)}}}
+		assert.EqualValues(t, 30*time.Second, JitterBackoff(time.Second, 30*time.Second, 0, resp))
+	})
+	t.Run("honor retry after as date", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}}}
+		assert.EqualValues(t, 0, JitterBackoff(time.Second, 30*time.Second, 0, resp))
+	})
+	t.Run("exponential backoff with jitter", func(t *testing.T) {
+		for attempt := 0; attempt < 3; attempt++ {
+			expected := time.Second << uint(attempt)
+			actual := JitterBackoff(time.Second, 30*time.Second, attempt, nil)
+			assert.GreaterOrEqual(t, int64(actual), int64(expected/2))
+			assert.LessOrEqual(t, int64(actual), int64(expected))
+		}
+	})
+	t.Run("backoff is capped", func(t *testing.T) {
+		actual := JitterBackoff(time.Second, 5*time.Second, 10, nil)
+		assert.LessOrEqual(t, int64(actual), int64(5*time.Second))
+	})
+}
+
+func TestGatewayCallResponseError(t *testing.T) {
+	getGateway := func(t *testing.T, code int, body string) *HTTPGateway {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: code,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("unexpected status code returns response error", func(t *testing.T) {
+		g := getGateway(t, http.StatusNotFound, `{"error":"not found"}`)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200/detector", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		var responseErr *ResponseError
+		assert.True(t, errors.As(err, &responseErr))
+		assert.EqualValues(t, http.StatusNotFound, responseErr.StatusCode)
+		assert.EqualValues(t, `{"error":"not found"}`, string(responseErr.Body))
+		assert.EqualValues(t, http.MethodGet, responseErr.Method)
+		assert.EqualValues(t, "http://localhost:9200/detector", responseErr.URL)
+		assert.EqualError(t, err, "{\n  \"error\": \"not found\"\n}")
+	})
+	t.Run("plain text response is returned as it is", func(t *testing.T) {
+		g := getGateway(t, http.StatusBadRequest, "100% invalid")
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.EqualError(t, err, "100% invalid")
+	})
+}
+
+func TestGatewayCallExpecting(t *testing.T) {
+	getGateway := func(t *testing.T, code int, body string) *HTTPGateway {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: code,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("accept any of given status codes", func(t *testing.T) {
+		for _, code := range []int{http.StatusOK, http.StatusCreated} {
+			g := getGateway(t, code, "created")
+			req, err := g.BuildRequest(context.Background(), http.MethodPost, "", "http://localhost:9200", GetDefaultHeaders())
+			assert.NoError(t, err)
+			response, err := g.CallExpecting(req, http.StatusOK, http.StatusCreated)
+			assert.NoError(t, err)
+			assert.EqualValues(t, "created", string(response))
+		}
+	})
+	t.Run("accept error status code", func(t *testing.T) {
+		g := getGateway(t, http.StatusNotFound, "not found")
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.CallExpecting(req, http.StatusOK, http.StatusNotFound)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "not found", string(response))
+	})
+	t.Run("status code not accepted", func(t *testing.T) {
+		g := getGateway(t, http.StatusConflict, "conflict")
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.CallExpecting(req, http.StatusOK, http.StatusNotFound)
+		var responseErr *ResponseError
+		assert.True(t, errors.As(err, &responseErr))
+		assert.EqualValues(t, http.StatusConflict, responseErr.StatusCode)
+		assert.EqualError(t, err, "conflict")
+	})
+	t.Run("empty body", func(t *testing.T) {
+		for _, body := range []string{"", " \n\t"} {
+			g := getGateway(t, http.StatusOK, body)
+			req, err := g.BuildRequest(context.Background(), http.MethodPost, "", "http://localhost:9200", GetDefaultHeaders())
+			assert.NoError(t, err)
+			response, err := g.CallExpecting(req, http.StatusOK)
+			assert.NoError(t, err)
+			assert.Nil(t, response)
+		}
+	})
+	t.Run("empty body of accepted error status code", func(t *testing.T) {
+		g := getGateway(t, http.StatusNotFound, "\n")
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.CallExpecting(req, http.StatusOK, http.StatusNotFound)
+		assert.NoError(t, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestGatewayCallWithResponse(t *testing.T) {
+	getGateway := func(t *testing.T, code int, body string) *HTTPGateway {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			header := make(http.Header)
+			header.Set("X-Opaque-Id", req.Header.Get("X-Opaque-Id"))
+			header.Set("X-Rate-Limit-Remaining", "42")
+			return &http.Response{
+				StatusCode: code,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     header,
+				Request:    req,
+			}
+		})
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		return g
+	}
+	headers := map[string]string{"X-Opaque-Id": "test-id"}
+	t.Run("headers are preserved", func(t *testing.T) {
+		g := getGateway(t, http.StatusCreated, `{"acknowledged":true}`)
+		req, err := g.BuildRequest(context.Background(), http.MethodPut, "", "http://localhost:9200", headers)
+		assert.NoError(t, err)
+		response, err := g.CallWithResponse(req)
+		assert.NoError(t, err)
+		assert.EqualValues(t, http.StatusCreated, response.StatusCode)
+		assert.EqualValues(t, "test-id", response.Header.Get("X-Opaque-Id"))
+		assert.EqualValues(t, "42", response.Header.Get("X-Rate-Limit-Remaining"))
+		assert.EqualValues(t, `{"acknowledged":true}`, string(response.Body))
+	})
+	t.Run("headers of accepted error status code are preserved", func(t *testing.T) {
+		g := getGateway(t, http.StatusNotFound, "not found")
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", headers)
+		assert.NoError(t, err)
+		response, err := g.CallWithResponse(req, http.StatusNotFound)
+		assert.NoError(t, err)
+		assert.EqualValues(t, http.StatusNotFound, response.StatusCode)
+		assert.EqualValues(t, "test-id", response.Header.Get("X-Opaque-Id"))
+		assert.EqualValues(t, "not found", string(response.Body))
+	})
+	t.Run("response error has headers", func(t *testing.T) {
+		g := getGateway(t, http.StatusTooManyRequests, "too many requests")
+		g.Client.HTTPClient.RetryMax = 0
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", headers)
+		assert.NoError(t, err)
+		_, err = g.CallWithResponse(req, http.StatusNotFound)
+		var responseErr *ResponseError
+		assert.True(t, errors.As(err, &responseErr))
+		assert.EqualValues(t, "42", responseErr.Header.Get("X-Rate-Limit-Remaining"))
+	})
+	t.Run("call returns body", func(t *testing.T) {
+		g := getGateway(t, http.StatusOK, "ok")
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", headers)
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "ok", string(response))
+	})
+}
+
+func TestGatewayBuildRequest(t *testing.T) {
+	payload := map[string]interface{}{
+		"description": "partial update",
+	}
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+				assert.EqualValues(t, method, req.Method)
+				assert.EqualValues(t, "application/json", req.Header.Get("content-type"))
+				assert.EqualValues(t, 3, len(req.Header))
+				user, password, ok := req.BasicAuth()
+				assert.True(t, ok)
+				assert.EqualValues(t, "admin", user)
+				assert.EqualValues(t, "admin", password)
+				body, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"description":"partial update"}`, string(body))
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+					Header:     make(http.Header),
+					Request:    req,
+				}
+			})
+			g, err := NewHTTPGateway(testClient, &entity.Profile{
+				Endpoint: "http://localhost:9200",
+				UserName: "admin",
+				Password: "admin",
+			})
+			assert.NoError(t, err)
+			req, err := g.BuildRequest(context.Background(), method, payload, "http://localhost:9200/index/_doc/1", GetDefaultHeaders())
+			assert.NoError(t, err)
+			response, err := g.Call(req, http.StatusOK)
+			assert.NoError(t, err)
+			assert.EqualValues(t, "{}", string(response))
+		})
+	}
+}
+
+func TestGatewayBuildRequestHeaders(t *testing.T) {
+	g, err := NewHTTPGateway(mocks.NewTestClient(nil), &entity.Profile{Endpoint: "http://localhost:9200"})
+	assert.NoError(t, err)
+	t.Run("caller headers override defaults", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			headers := MergeHeaders(GetDefaultHeaders(), map[string]string{"Accept": "text/csv", "Content-Type": "text/plain"})
+			req, err := g.BuildRequest(context.Background(), http.MethodPost, "", "http://localhost:9200/_plugins/_sql", headers)
+			assert.NoError(t, err)
+			assert.EqualValues(t, []string{"text/csv"}, req.Header.Values("Accept"))
+			assert.EqualValues(t, []string{"text/plain"}, req.Header.Values("Content-Type"))
+		}
+	})
+	t.Run("header names are case insensitive", func(t *testing.T) {
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200/_cat/indices",
+			map[string]string{"accept": "text/plain", "CONTENT-TYPE": "text/plain"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "text/plain", req.Header.Get("Accept"))
+		assert.EqualValues(t, []string{"text/plain"}, req.Header.Values("Content-Type"))
+	})
+	t.Run("defaults are used without headers", func(t *testing.T) {
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "application/json", req.Header.Get("Content-Type"))
+		assert.EqualValues(t, GetDefaultUserAgent(), req.Header.Get("User-Agent"))
+	})
+}
+
+func TestMergeHeaders(t *testing.T) {
+	assert.EqualValues(t, map[string]string{
+		"Content-Type": "text/csv",
+		"Accept":       "text/csv",
+		"User-Agent":   GetDefaultUserAgent(),
+	}, MergeHeaders(GetDefaultHeaders(), map[string]string{"content-TYPE": "text/csv"}, map[string]string{"accept": "text/csv"}))
+	assert.Empty(t, MergeHeaders())
+}
+
+func TestGatewayCallStream(t *testing.T) {
+	t.Run("stream response before it is completely sent", func(t *testing.T) {
+		const totalHits = 100000
+		proceed := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"hits":[`))
+			w.(http.Flusher).Flush()
+			// rest of the response is sent only after client started reading it
+			<-proceed
+			for i := 0; i < totalHits; i++ {
+				if i > 0 {
+					_, _ = w.Write([]byte(","))
+				}
+				_, _ = w.Write([]byte(`{"_id":"id","_source":{"name":"detector"}}`))
+			}
+			_, _ = w.Write([]byte("]}"))
+		}))
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodPost, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		body, err := g.CallStream(req, http.StatusOK)
+		assert.NoError(t, err)
+		defer body.Close()
+		close(proceed)
+		decoder := json.NewDecoder(body)
+		for _, expected := range []json.Token{json.Delim('{'), "hits", json.Delim('[')} {
+			token, err := decoder.Token()
+			assert.NoError(t, err)
+			assert.EqualValues(t, expected, token)
+		}
+		count := 0
+		for decoder.More() {
+			var hit map[string]interface{}
+			assert.NoError(t, decoder.Decode(&hit))
+			count++
+		}
+		assert.EqualValues(t, totalHits, count)
+	})
+	t.Run("status code is checked before reading", func(t *testing.T) {
+		testClient := mocks.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("index not found")),
+				Header:     make(http.Header),
+				Request:    req,
+			}
+		})
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: "http://localhost:9200"})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.CallStream(req, http.StatusOK)
+		assert.EqualError(t, err, "index not found")
+		body, err := g.CallStream(req, http.StatusOK, http.StatusNotFound)
+		assert.NoError(t, err)
+		response, err := ioutil.ReadAll(body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "index not found", string(response))
+	})
+}
+
+func TestGatewayBuildRequestAuthorization(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  entity.Profile
+		headers  map[string]string
+		expected string
+	}{
+		{
+			name:     "basic authentication",
+			profile:  entity.Profile{UserName: "admin", Password: "admin"},
+			headers:  GetDefaultHeaders(),
+			expected: "Basic YWRtaW46YWRtaW4=",
+		},
+		{
+			name:     "api key takes precedence over basic authentication",
+			profile:  entity.Profile{UserName: "admin", Password: "admin", APIKey: "key"},
+			headers:  GetDefaultHeaders(),
+			expected: "ApiKey key",
+		},
+		{
+			name:     "token takes precedence over api key and basic authentication",
+			profile:  entity.Profile{UserName: "admin", Password: "admin", APIKey: "key", Token: "token"},
+			headers:  GetDefaultHeaders(),
+			expected: "Bearer token",
+		},
+		{
+			name:     "authorization header from request is not overridden",
+			profile:  entity.Profile{Token: "token"},
+			headers:  map[string]string{"authorization": "Bearer other"},
+			expected: "Bearer other",
+		},
+		{
+			name:     "no authentication",
+			profile:  entity.Profile{},
+			headers:  GetDefaultHeaders(),
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			profile.Endpoint = "http://localhost:9200"
+			g, err := NewHTTPGateway(mocks.NewTestClient(nil), &profile)
+			assert.NoError(t, err)
+			req, err := g.BuildRequest(context.Background(), http.MethodGet, "", profile.Endpoint, tt.headers)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.expected, req.Header.Get("Authorization"))
+		})
+	}
+}
+
+//testCertificates contains PEM encoded CA, and server and client certificates signed by that CA
+type testCertificates struct {
+	CA         string
+	ServerCert string
+	ServerKey  string
+	ClientCert string
+	ClientKey  string
+}
+
+func generateTestCertificates(t *testing.T) testCertificates {
+	newKey := func() (*ecdsa.PrivateKey, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		der, err := x509.MarshalECPrivateKey(key)
+		assert.NoError(t, err)
+		return key, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	}
+	sign := func(template, parent *x509.Certificate, key *ecdsa.PrivateKey, parentKey *ecdsa.PrivateKey) (*x509.Certificate, string) {
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		assert.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		assert.NoError(t, err)
+		return cert, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(time.Hour)
+	caKey, _ := newKey()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	ca, caPEM := sign(caTemplate, caTemplate, caKey, caKey)
+	serverKey, serverKeyPEM := newKey()
+	_, serverPEM := sign(&x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, serverKey, caKey)
+	clientKey, clientKeyPEM := newKey()
+	_, clientPEM := sign(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, clientKey, caKey)
+	return testCertificates{
+		CA:         caPEM,
+		ServerCert: serverPEM,
+		ServerKey:  serverKeyPEM,
+		ClientCert: clientPEM,
+		ClientKey:  clientKeyPEM,
+	}
+}
+
+//newTLSServer starts https server with certificate signed by test CA, server verifies client certificate if clientAuth is true
+func newTLSServer(t *testing.T, certs testCertificates, clientAuth bool) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("success"))
+	}))
+	serverCert, err := tls.X509KeyPair([]byte(certs.ServerCert), []byte(certs.ServerKey))
+	assert.NoError(t, err)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	if clientAuth {
+		clientCAs := x509.NewCertPool()
+		clientCAs.AppendCertsFromPEM([]byte(certs.CA))
+		server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		server.TLS.ClientCAs = clientCAs
+	}
+	server.StartTLS()
+	return server
+}
+
+func writeTestFile(t *testing.T, dir string, name string, content string) *string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return &path
+}
+
+func TestGatewayMutualTLS(t *testing.T) {
+	certs := generateTestCertificates(t)
+	server := newTLSServer(t, certs, true)
+	defer server.Close()
+	noRetry := 0
+	call := func(t *testing.T, trust *entity.Trust) ([]byte, error) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:    server.URL,
+			Certificate: trust,
+			MaxRetry:    &noRetry,
+		})
+		if err != nil {
+			return nil, err
+		}
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		return g.Call(req, http.StatusOK)
+	}
+	t.Run("client certificate from file", func(t *testing.T) {
+		dir := t.TempDir()
+		response, err := call(t, &entity.Trust{
+			CAFilePath:                writeTestFile(t, dir, "ca.pem", certs.CA),
+			ClientCertificateFilePath: writeTestFile(t, dir, "client.pem", certs.ClientCert),
+			ClientKeyFilePath:         writeTestFile(t, dir, "client-key.pem", certs.ClientKey),
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(response))
+	})
+	t.Run("client certificate from PEM", func(t *testing.T) {
+		response, err := call(t, &entity.Trust{
+			CAPEM:                &certs.CA,
+			ClientCertificatePEM: &certs.ClientCert,
+			ClientKeyPEM:         &certs.ClientKey,
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(response))
+	})
+	t.Run("missing client certificate", func(t *testing.T) {
+		_, err := call(t, &entity.Trust{
+			CAPEM: &certs.CA,
+		})
+		assert.Error(t, err)
+	})
+	t.Run("client key without certificate", func(t *testing.T) {
+		_, err := call(t, &entity.Trust{
+			CAPEM:        &certs.CA,
+			ClientKeyPEM: &certs.ClientKey,
+		})
+		assert.EqualError(t, err, "client certificate and client key must be provided together")
+	})
+	t.Run("client certificate without key", func(t *testing.T) {
+		_, err := call(t, &entity.Trust{
+			CAPEM:                     &certs.CA,
+			ClientCertificateFilePath: writeTestFile(t, t.TempDir(), "client.pem", certs.ClientCert),
+		})
+		assert.EqualError(t, err, "client certificate and client key must be provided together")
+	})
+}
+
+func TestGatewaySelfSignedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("success"))
+	}))
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	noRetry := 0
+	call := func(t *testing.T, profile *entity.Profile) ([]byte, error) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		profile.Endpoint = server.URL
+		profile.MaxRetry = &noRetry
+		g, err := NewHTTPGateway(testClient, profile)
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		return g.Call(req, http.StatusOK)
+	}
+	t.Run("certificate is verified by default", func(t *testing.T) {
+		_, err := call(t, &entity.Profile{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+	t.Run("certificate is verified with CA from file", func(t *testing.T) {
+		response, err := call(t, &entity.Profile{
+			Certificate: &entity.Trust{
+				CAFilePath: writeTestFile(t, t.TempDir(), "ca.pem", caPEM),
+			},
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(response))
+	})
+	t.Run("skip verification if insecure", func(t *testing.T) {
+		response, err := call(t, &entity.Profile{Insecure: true})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(response))
+	})
+}
+
+func TestGatewayProxy(t *testing.T) {
+	t.Run("request is sent through proxy", func(t *testing.T) {
+		var proxiedURL string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxiedURL = r.URL.String()
+			_, _ = w.Write([]byte("proxied"))
+		}))
+		defer proxy.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: "http://opensearch.example.com:9200",
+			Proxy:    proxy.URL,
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", "http://opensearch.example.com:9200/_cat/indices", GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "proxied", string(response))
+		assert.EqualValues(t, "http://opensearch.example.com:9200/_cat/indices", proxiedURL)
+	})
+	t.Run("invalid proxy", func(t *testing.T) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		_, err = NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			Proxy:    "localhost",
+		})
+		assert.EqualError(t, err, "invalid proxy: localhost")
+	})
+	t.Run("proxy from environment by default", func(t *testing.T) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		_, err = NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+		})
+		assert.NoError(t, err)
+		transport, ok := testClient.HTTPClient.HTTPClient.Transport.(*http.Transport)
+		assert.True(t, ok)
+		assert.NotNil(t, transport.Proxy)
+	})
+}
+
+func TestGatewayTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+	noRetry := 0
+	getGateway := func(t *testing.T, timeout time.Duration) *HTTPGateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testClient.HTTPClient.HTTPClient.Timeout = timeout
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: server.URL,
+			MaxRetry: &noRetry,
+		})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("client timeout", func(t *testing.T) {
+		g := getGateway(t, 100*time.Millisecond)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		start := time.Now()
+		_, err = g.Call(req, http.StatusOK)
+		assert.True(t, errors.Is(err, ErrTimeout))
+		assert.EqualError(t, err, fmt.Sprintf("request timed out: no response from %s within 100ms", server.URL))
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+	t.Run("context timeout overrides client timeout", func(t *testing.T) {
+		g := getGateway(t, 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, err := g.BuildRequest(ctx, http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		start := time.Now()
+		_, err = g.Call(req, http.StatusOK)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+}
+
+func TestGatewayMaxResponseSize(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/small" {
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+			return
+		}
+		// stream response until client stops reading
+		chunk := bytes.Repeat([]byte("a"), 1024)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+	getGateway := func(t *testing.T, maxResponseSize *int64) *HTTPGateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:        server.URL,
+			MaxResponseSize: maxResponseSize,
+		})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("default limit", func(t *testing.T) {
+		g := getGateway(t, nil)
+		assert.EqualValues(t, client.DefaultMaxResponseSize, g.Client.MaxResponseSize)
+	})
+	t.Run("response within limit", func(t *testing.T) {
+		limit := int64(1024)
+		g := getGateway(t, &limit)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL+"/small", GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"acknowledged":true}`, string(response))
+	})
+	t.Run("streamed response exceeds limit", func(t *testing.T) {
+		limit := int64(10 * 1024)
+		g := getGateway(t, &limit)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL+"/large", GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.True(t, errors.Is(err, ErrResponseTooLarge))
+		assert.EqualError(t, err, "response too large: response exceeded 10240 bytes, increase max_response_size of the profile to read it")
+	})
+}
+
+func TestGatewayRedirectPolicy(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		http.Redirect(w, r, "/other", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+	t.Run("redirect is not retried", func(t *testing.T) {
+		retry := 3
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:       server.URL,
+			MaxRetry:       &retry,
+			RedirectPolicy: string(client.RedirectNone),
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.True(t, errors.Is(err, client.ErrRedirectNotFollowed))
+		assert.EqualValues(t, 1, atomic.LoadInt32(&hits))
+	})
+	t.Run("request body is sent again after redirect", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}))
+		defer target.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:       server.URL,
+			RedirectPolicy: string(client.RedirectAll),
+		})
+		assert.NoError(t, err)
+		redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+		defer redirect.Close()
+		req, err := g.BuildRequest(context.Background(), http.MethodPost, map[string]string{"name": "detector"}, redirect.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"detector"}`, string(response))
+	})
+	t.Run("invalid policy", func(t *testing.T) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		_, err = NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL, RedirectPolicy: "always"})
+		assert.EqualError(t, err, "invalid redirect policy: always, supported policies are: same-host, all, none")
+	})
+}
+
+func TestGatewayTransportReuse(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("success"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	transport, ok := client.GetTransport(testClient)
+	assert.True(t, ok)
+	maxIdleConnsPerHost := 2
+	var configured *http.Transport
+	for i := 0; i < 10; i++ {
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:            server.URL,
+			MaxIdleConnsPerHost: &maxIdleConnsPerHost,
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		if configured == nil {
+			configured, ok = client.GetTransport(testClient)
+			assert.True(t, ok)
+		}
+	}
+	reused, ok := client.GetTransport(testClient)
+	assert.True(t, ok)
+	assert.True(t, configured == reused)
+	// transport which client was created with is not changed by profile
+	assert.False(t, transport == reused)
+	assert.EqualValues(t, client.DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.EqualValues(t, maxIdleConnsPerHost, reused.MaxIdleConnsPerHost)
+	assert.EqualValues(t, client.DefaultMaxIdleConns, reused.MaxIdleConns)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&connections))
+}
+
+func TestGatewayBuildRequestUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  entity.Profile
+		headers  map[string]string
+		expected string
+	}{
+		{
+			name:     "default user agent",
+			headers:  GetDefaultHeaders(),
+			expected: "opensearch-cli/" + version.Version,
+		},
+		{
+			name:     "default user agent without default headers",
+			headers:  nil,
+			expected: "opensearch-cli/" + version.Version,
+		},
+		{
+			name:     "user agent from profile",
+			profile:  entity.Profile{UserAgent: "audit-tool/2.0"},
+			headers:  GetDefaultHeaders(),
+			expected: "audit-tool/2.0",
+		},
+		{
+			name:     "user agent from request is not overridden",
+			profile:  entity.Profile{UserAgent: "audit-tool/2.0"},
+			headers:  map[string]string{"user-agent": "curl/7.0"},
+			expected: "curl/7.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			profile.Endpoint = "http://localhost:9200"
+			g, err := NewHTTPGateway(mocks.NewTestClient(nil), &profile)
+			assert.NoError(t, err)
+			req, err := g.BuildRequest(context.Background(), http.MethodGet, "", profile.Endpoint, tt.headers)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.expected, req.Header.Get("User-Agent"))
+		})
+	}
+}
+
+func TestGatewayFailover(t *testing.T) {
+	getClosedEndpoint := func(t *testing.T) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		endpoint := "http://" + listener.Addr().String()
+		assert.NoError(t, listener.Close())
+		return endpoint
+	}
+	getServer := func(status int, body string) (*httptest.Server, *int32) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		return server, &attempts
+	}
+	call := func(t *testing.T, g *HTTPGateway) ([]byte, error) {
+		endpoint, err := GetValidEndpoint(g.Profile)
+		assert.NoError(t, err)
+		endpoint.Path = "_cluster/health"
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", endpoint.String(), GetDefaultHeaders())
+		assert.NoError(t, err)
+		return g.Call(req, http.StatusOK)
+	}
+	maxRetry := 0
+	t.Run("first endpoint refuses connection", func(t *testing.T) {
+		server, attempts := getServer(http.StatusOK, "green")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:  getClosedEndpoint(t),
+			Endpoints: []string{server.URL},
+			MaxRetry:  &maxRetry,
+		})
+		assert.NoError(t, err)
+		response, err := call(t, g)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "green", string(response))
+		assert.EqualValues(t, server.URL, g.cluster.getEndpoint())
+		// url is still built for first endpoint, and gateway sends it to answering endpoint
+		endpoint, err := GetValidEndpoint(g.Profile)
+		assert.NoError(t, err)
+		assert.EqualValues(t, g.Profile.Endpoint, endpoint.String())
+		assert.EqualValues(t, 1, atomic.LoadInt32(attempts))
+	})
+	t.Run("answering endpoint is used for remaining requests", func(t *testing.T) {
+		unavailable, unavailableAttempts := getServer(http.StatusServiceUnavailable, "unavailable")
+		defer unavailable.Close()
+		server, attempts := getServer(http.StatusOK, "green")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:  unavailable.URL,
+			Endpoints: []string{getClosedEndpoint(t), server.URL},
+			MaxRetry:  &maxRetry,
+		})
+		assert.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			response, err := call(t, g)
+			assert.NoError(t, err)
+			assert.EqualValues(t, "green", string(response))
+		}
+		// copy of gateway, like the one embedded by plugin gateways, shares answering endpoint
+		copied := *g
+		response, err := call(t, &copied)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "green", string(response))
+		assert.EqualValues(t, 1, atomic.LoadInt32(unavailableAttempts))
+		assert.EqualValues(t, 3, atomic.LoadInt32(attempts))
+		// answering endpoint is not shared with gateways of other profiles
+		otherProfile := *g.Profile
+		other, err := NewHTTPGateway(testClient, &otherProfile)
+		assert.NoError(t, err)
+		assert.Empty(t, other.cluster.getEndpoint())
+	})
+	t.Run("client error does not fail over", func(t *testing.T) {
+		badRequest, _ := getServer(http.StatusBadRequest, "bad request")
+		defer badRequest.Close()
+		server, attempts := getServer(http.StatusOK, "green")
+		defer server.Close()
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:  badRequest.URL,
+			Endpoints: []string{server.URL},
+			MaxRetry:  &maxRetry,
+		})
+		assert.NoError(t, err)
+		_, err = call(t, g)
+		assert.EqualError(t, err, "bad request")
+		assert.EqualValues(t, 0, atomic.LoadInt32(attempts))
+	})
+	t.Run("all endpoints unavailable", func(t *testing.T) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint:  getClosedEndpoint(t),
+			Endpoints: []string{getClosedEndpoint(t)},
+			MaxRetry:  &maxRetry,
+		})
+		assert.NoError(t, err)
+		_, err = call(t, g)
+		assert.Error(t, err)
+	})
+}
+
+func TestGatewayPing(t *testing.T) {
+	maxRetry := 0
+	getGateway := func(t *testing.T, endpoint string) *HTTPGateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Name:     "test",
+			Endpoint: endpoint,
+			UserName: "admin",
+			Password: "admin",
+			MaxRetry: &maxRetry,
+		})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("cluster is reachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/", r.URL.Path)
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "admin", username)
+			assert.Equal(t, "admin", password)
+			_, _ = w.Write([]byte(`{"cluster_name":"opensearch","version":{"number":"1.0.0"}}`))
+		}))
+		defer server.Close()
+		assert.NoError(t, getGateway(t, server.URL).Ping(context.Background()))
+	})
+	t.Run("authentication failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`Unauthorized`))
+		}))
+		defer server.Close()
+		err := getGateway(t, server.URL).Ping(context.Background())
+		assert.EqualError(t, err, "authentication failed for "+server.URL+"/, check credentials of the profile: Unauthorized")
+		var responseErr *ResponseError
+		assert.True(t, errors.As(err, &responseErr))
+		assert.Equal(t, http.StatusUnauthorized, responseErr.StatusCode)
+	})
+	t.Run("untrusted certificate", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		err := getGateway(t, server.URL).Ping(context.Background())
+		assert.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "TLS handshake with "+server.URL+"/ failed"), err.Error())
+	})
+	t.Run("connection refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		endpoint := "http://" + listener.Addr().String()
+		assert.NoError(t, listener.Close())
+		err = getGateway(t, endpoint).Ping(context.Background())
+		assert.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "failed to connect to "+endpoint+"/"), err.Error())
+	})
+}
@@ -0,0 +1,184 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strings"
+)
+
+const (
+	indexURLTemplate    = "%s"
+	mappingURLTemplate  = "%s/_mapping"
+	settingsURLTemplate = "%s/_settings"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_index.go -package=mocks . Gateway
+
+// Gateway interface to index APIs of OpenSearch
+type Gateway interface {
+	CreateIndex(ctx context.Context, index string, payload interface{}) ([]byte, error)
+	DeleteIndex(ctx context.Context, indices []string) error
+	GetIndex(ctx context.Context, indices []string) ([]byte, error)
+	PutMapping(ctx context.Context, indices []string, payload interface{}) ([]byte, error)
+	GetMapping(ctx context.Context, indices []string) ([]byte, error)
+	PutSettings(ctx context.Context, indices []string, payload interface{}) ([]byte, error)
+	GetSettings(ctx context.Context, indices []string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildIndexURL builds url from template for given indices, joined by comma, with profile's index prefix.
+//Every index is escaped to prevent it from adding path segments or query parameters to the url
+func (g *gateway) buildIndexURL(template string, indices []string) (*url.URL, error) {
+	if len(indices) < 1 {
+		return nil, errors.New("at least one index is required")
+	}
+	indices = g.Profile.PrefixIndices(indices)
+	escaped := make([]string, len(indices))
+	for i, index := range indices {
+		if len(index) < 1 {
+			return nil, errors.New("index cannot be empty")
+		}
+		escaped[i] = url.PathEscape(index)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, strings.Join(indices, ","))
+	endpoint.RawPath = fmt.Sprintf(template, strings.Join(escaped, ","))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*CreateIndex Creates index with given name, payload can contain settings, mappings and aliases of the index.
+It calls http request: PUT <index>
+Sample Input:
+{
+  "settings": {
+    "index": {"number_of_shards": 2, "number_of_replicas": 1}
+  },
+  "mappings": {
+    "properties": {
+      "timestamp": {"type": "date"}
+    }
+  },
+  "aliases": {
+    "sample-alias": {}
+  }
+}*/
+func (g *gateway) CreateIndex(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	indexURL, err := g.buildIndexURL(indexURLTemplate, []string{index})
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, indexURL, payload)
+}
+
+// DeleteIndex Deletes given indices.
+// It calls http request: DELETE <index1>,<index2>
+func (g *gateway) DeleteIndex(ctx context.Context, indices []string) error {
+	indexURL, err := g.buildIndexURL(indexURLTemplate, indices)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, indexURL, "")
+	return err
+}
+
+// GetIndex Returns aliases, mappings and settings of given indices, keyed by index name.
+// It calls http request: GET <index1>,<index2>
+func (g *gateway) GetIndex(ctx context.Context, indices []string) ([]byte, error) {
+	indexURL, err := g.buildIndexURL(indexURLTemplate, indices)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, indexURL, "")
+}
+
+/*PutMapping Adds fields to mappings of given indices.
+It calls http request: PUT <index1>,<index2>/_mapping
+Sample Input:
+{
+  "properties": {
+    "value": {"type": "double"}
+  }
+}*/
+func (g *gateway) PutMapping(ctx context.Context, indices []string, payload interface{}) ([]byte, error) {
+	mappingURL, err := g.buildIndexURL(mappingURLTemplate, indices)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, mappingURL, payload)
+}
+
+// GetMapping Returns mappings of given indices, keyed by index name.
+// It calls http request: GET <index1>,<index2>/_mapping
+func (g *gateway) GetMapping(ctx context.Context, indices []string) ([]byte, error) {
+	mappingURL, err := g.buildIndexURL(mappingURLTemplate, indices)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, mappingURL, "")
+}
+
+/*PutSettings Updates dynamic settings of given indices.
+It calls http request: PUT <index1>,<index2>/_settings
+Sample Input:
+{
+  "index": {
+    "number_of_replicas": 2
+  }
+}*/
+func (g *gateway) PutSettings(ctx context.Context, indices []string, payload interface{}) ([]byte, error) {
+	settingsURL, err := g.buildIndexURL(settingsURLTemplate, indices)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, settingsURL, payload)
+}
+
+// GetSettings Returns settings of given indices, keyed by index name.
+// It calls http request: GET <index1>,<index2>/_settings
+func (g *gateway) GetSettings(ctx context.Context, indices []string) ([]byte, error) {
+	settingsURL, err := g.buildIndexURL(settingsURLTemplate, indices)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, settingsURL, "")
+}
@@ -0,0 +1,141 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Mapping(t *testing.T) {
+	ctx := context.Background()
+	mapping := `{"properties":{"value":{"type":"double"}}}`
+	t.Run("put mapping", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/logs-1/_mapping", mapping, 200, []byte(`{"acknowledged":true}`))
+		actual, err := getTestGateway(t, testClient).PutMapping(ctx, []string{"logs-1"}, json.RawMessage(mapping))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+	})
+	t.Run("put mapping of multiple indices", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/logs-1,logs-%2A/_mapping", mapping, 200, []byte(`{"acknowledged":true}`))
+		_, err := getTestGateway(t, testClient).PutMapping(ctx, []string{"logs-1", "logs-*"}, json.RawMessage(mapping))
+		assert.NoError(t, err)
+	})
+	t.Run("put conflicting mapping", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/logs-1/_mapping", mapping, 400, []byte("mapper [value] cannot be changed from type [long] to [double]"))
+		_, err := getTestGateway(t, testClient).PutMapping(ctx, []string{"logs-1"}, json.RawMessage(mapping))
+		assert.EqualError(t, err, "mapper [value] cannot be changed from type [long] to [double]")
+	})
+	t.Run("get mapping", func(t *testing.T) {
+		response := []byte(`{"logs-1":{"mappings":` + mapping + `}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/logs-1/_mapping", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetMapping(ctx, []string{"logs-1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+}
+
+func TestGateway_Settings(t *testing.T) {
+	ctx := context.Background()
+	settings := `{"index":{"number_of_replicas":2}}`
+	t.Run("put settings", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/logs-1,logs-2/_settings", settings, 200, []byte(`{"acknowledged":true}`))
+		actual, err := getTestGateway(t, testClient).PutSettings(ctx, []string{"logs-1", "logs-2"}, json.RawMessage(settings))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+	})
+	t.Run("get settings", func(t *testing.T) {
+		response := []byte(`{"logs-1":{"settings":{"index":{"number_of_replicas":"2"}}}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/logs-1/_settings", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetSettings(ctx, []string{"logs-1"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("index name is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/logs%2F_doc%3F/_settings", "", 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).GetSettings(ctx, []string{"logs/_doc?"})
+		assert.NoError(t, err)
+	})
+	t.Run("no indices", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).PutSettings(ctx, nil, json.RawMessage(settings))
+		assert.EqualError(t, err, "at least one index is required")
+	})
+	t.Run("empty index", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).GetSettings(ctx, []string{"logs-1", ""})
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+}
+
+func TestGateway_Index(t *testing.T) {
+	ctx := context.Background()
+	t.Run("create index", func(t *testing.T) {
+		body := `{"settings":{"index":{"number_of_shards":2}}}`
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/logs-1", body, 200, []byte(`{"acknowledged":true,"index":"logs-1"}`))
+		_, err := getTestGateway(t, testClient).CreateIndex(ctx, "logs-1", json.RawMessage(body))
+		assert.NoError(t, err)
+	})
+	t.Run("get index", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/logs-1,logs-2", "", 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).GetIndex(ctx, []string{"logs-1", "logs-2"})
+		assert.NoError(t, err)
+	})
+	t.Run("delete index", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/logs-1", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteIndex(ctx, []string{"logs-1"}))
+	})
+	t.Run("delete unknown index", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/unknown", "", 404, []byte("no such index [unknown]"))
+		assert.EqualError(t, getTestGateway(t, testClient).DeleteIndex(ctx, []string{"unknown"}), "no such index [unknown]")
+	})
+}
+
+func TestGateway_IndexPrefix(t *testing.T) {
+	ctx := context.Background()
+	getGateway := func(t *testing.T, c *client.Client) Gateway {
+		g, err := New(c, &entity.Profile{
+			Name:        "test",
+			Endpoint:    "http://localhost:9200",
+			IndexPrefix: "team-a-",
+		})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("prefix is added to index names", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/team-a-logs-1,team-a-team-a-logs-2/_mapping", "", 200, []byte(`{}`))
+		_, err := getGateway(t, testClient).GetMapping(ctx, []string{"logs-1", "team-a-logs-2"})
+		assert.NoError(t, err)
+	})
+	t.Run("patterns and cross cluster names", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/team-a-logs-%2A%2C-team-a-logs-old,remote:team-a-logs/_settings", "", 200, []byte(`{}`))
+		_, err := getGateway(t, testClient).GetSettings(ctx, []string{"logs-*,-logs-old", "remote:logs"})
+		assert.NoError(t, err)
+	})
+	t.Run("absolute index name bypasses prefix", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/shared-logs", "", 200, []byte(`{"acknowledged":true}`))
+		_, err := getGateway(t, testClient).CreateIndex(ctx, "/shared-logs", nil)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package ism
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strconv"
+)
+
+const (
+	baseURL                = "_plugins/_ism"
+	policiesURL            = baseURL + "/policies"
+	policyURLTemplate      = policiesURL + "/%s"
+	addURLTemplate         = baseURL + "/add/%s"
+	removeURLTemplate      = baseURL + "/remove/%s"
+	explainURLTemplate     = baseURL + "/explain/%s"
+	seqNoQueryParam        = "if_seq_no"
+	primaryTermQueryParam  = "if_primary_term"
+	queryStringQueryParam  = "queryString"
+	fromQueryParam         = "from"
+	sizeQueryParam         = "size"
+	policyIDField          = "policy_id"
+	defaultSearchPageSize  = 20
+	policyIDFieldName      = "policy Id"
+	indexFieldName         = "index"
+	emptyValueErrorMessage = "%s cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_ism.go -package=mocks . Gateway
+
+// Gateway interface to Index State Management Plugin
+type Gateway interface {
+	CreatePolicy(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	GetPolicy(ctx context.Context, ID string) ([]byte, error)
+	UpdatePolicy(ctx context.Context, ID string, seqNo int64, primaryTerm int64, payload interface{}) ([]byte, error)
+	DeletePolicy(ctx context.Context, ID string) error
+	SearchPolicies(ctx context.Context, queryString string, from int, size int) ([]byte, error)
+	AddPolicy(ctx context.Context, index string, policyID string) ([]byte, error)
+	RemovePolicy(ctx context.Context, index string) ([]byte, error)
+	ExplainIndex(ctx context.Context, index string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL builds url from template for given value, like policy ID or index. Value is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildURL(template string, name string, value string) (*url.URL, error) {
+	if len(value) < 1 {
+		return nil, fmt.Errorf(emptyValueErrorMessage, name)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, value)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(value))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*CreatePolicy Creates an ISM policy with given policy ID.
+It calls http request: PUT _plugins/_ism/policies/<policy_id>
+Sample Input:
+{
+  "policy": {
+    "description": "hot warm delete workflow",
+    "default_state": "hot",
+    "states": [
+      {
+        "name": "hot",
+        "actions": [{"rollover": {"min_index_age": "1d"}}],
+        "transitions": [{"state_name": "delete", "conditions": {"min_index_age": "30d"}}]
+      },
+      {
+        "name": "delete",
+        "actions": [{"delete": {}}],
+        "transitions": []
+      }
+    ]
+  }
+}*/
+func (g *gateway) CreatePolicy(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	policyURL, err := g.buildURL(policyURLTemplate, policyIDFieldName, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, policyURL, payload, http.StatusCreated)
+}
+
+// GetPolicy Returns ISM policy with given policy ID, including its _seq_no and _primary_term.
+// It calls http request: GET _plugins/_ism/policies/<policy_id>
+func (g *gateway) GetPolicy(ctx context.Context, ID string) ([]byte, error) {
+	policyURL, err := g.buildURL(policyURLTemplate, policyIDFieldName, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, policyURL, "", http.StatusOK)
+}
+
+// UpdatePolicy Updates ISM policy. seqNo and primaryTerm of the policy, returned by GetPolicy, are required
+// to prevent overwriting changes made by others since policy was read.
+// It calls http request: PUT _plugins/_ism/policies/<policy_id>?if_seq_no=<seq_no>&if_primary_term=<primary_term>
+func (g *gateway) UpdatePolicy(ctx context.Context, ID string, seqNo int64, primaryTerm int64, payload interface{}) ([]byte, error) {
+	policyURL, err := g.buildURL(policyURLTemplate, policyIDFieldName, ID)
+	if err != nil {
+		return nil, err
+	}
+	policyURL.RawQuery = url.Values{
+		seqNoQueryParam:       []string{strconv.FormatInt(seqNo, 10)},
+		primaryTermQueryParam: []string{strconv.FormatInt(primaryTerm, 10)},
+	}.Encode()
+	return g.call(ctx, http.MethodPut, policyURL, payload, http.StatusOK)
+}
+
+// DeletePolicy Deletes ISM policy with given policy ID.
+// It calls http request: DELETE _plugins/_ism/policies/<policy_id>
+func (g *gateway) DeletePolicy(ctx context.Context, ID string) error {
+	policyURL, err := g.buildURL(policyURLTemplate, policyIDFieldName, ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, policyURL, "", http.StatusOK)
+	return err
+}
+
+// SearchPolicies Returns a page of ISM policies matching query string, starting at from, with at most size policies.
+// If query string is empty, all policies are matched. If size is zero, default page size of 20 will be used.
+// It calls http request: GET _plugins/_ism/policies?queryString=<query>&from=<from>&size=<size>
+func (g *gateway) SearchPolicies(ctx context.Context, queryString string, from int, size int) ([]byte, error) {
+	if from < 0 {
+		return nil, fmt.Errorf("from: %d cannot be negative", from)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("size: %d cannot be negative", size)
+	}
+	if size == 0 {
+		size = defaultSearchPageSize
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = policiesURL
+	query := url.Values{
+		fromQueryParam: []string{strconv.Itoa(from)},
+		sizeQueryParam: []string{strconv.Itoa(size)},
+	}
+	if len(queryString) > 0 {
+		query.Set(queryStringQueryParam, queryString)
+	}
+	endpoint.RawQuery = query.Encode()
+	return g.call(ctx, http.MethodGet, endpoint, "", http.StatusOK)
+}
+
+/*AddPolicy Applies ISM policy to indices matching index, which can be a name, a pattern or comma separated list.
+It calls http request: POST _plugins/_ism/add/<index>
+with body: {"policy_id": "<policy_id>"}
+Sample Output:
+{
+  "updated_indices": 2,
+  "failures": false,
+  "failed_indices": []
+}*/
+func (g *gateway) AddPolicy(ctx context.Context, index string, policyID string) ([]byte, error) {
+	if len(policyID) < 1 {
+		return nil, fmt.Errorf(emptyValueErrorMessage, policyIDFieldName)
+	}
+	addURL, err := g.buildURL(addURLTemplate, indexFieldName, index)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, addURL, map[string]string{policyIDField: policyID}, http.StatusOK)
+}
+
+// RemovePolicy Removes ISM policy from indices matching index.
+// It calls http request: POST _plugins/_ism/remove/<index>
+func (g *gateway) RemovePolicy(ctx context.Context, index string) ([]byte, error) {
+	removeURL, err := g.buildURL(removeURLTemplate, indexFieldName, index)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, removeURL, "", http.StatusOK)
+}
+
+/*ExplainIndex Returns current state of ISM policy for indices matching index.
+It calls http request: GET _plugins/_ism/explain/<index>
+Sample Output:
+{
+  "index_1": {
+    "index.plugins.index_state_management.policy_id": "policy_1",
+    "index": "index_1",
+    "policy_id": "policy_1",
+    "state": {"name": "hot", "start_time": 1614853484311},
+    "action": {"name": "rollover", "start_time": 1614853484311}
+  },
+  "total_managed_indices": 1
+}*/
+func (g *gateway) ExplainIndex(ctx context.Context, index string) ([]byte, error) {
+	explainURL, err := g.buildURL(explainURLTemplate, indexFieldName, index)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, explainURL, "", http.StatusOK)
+}
@@ -0,0 +1,130 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package ism
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_ExplainIndex(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"index_1":{"index":"index_1","policy_id":"policy_1"},"total_managed_indices":1}`)
+	t.Run("explain index", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/explain/index_1", "", 200, response)
+		actual, err := getTestGateway(t, testClient).ExplainIndex(ctx, "index_1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("explain index pattern", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/explain/logs-%2A%2Cindex_1", "", 200, response)
+		_, err := getTestGateway(t, testClient).ExplainIndex(ctx, "logs-*,index_1")
+		assert.NoError(t, err)
+	})
+	t.Run("index is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/explain/index%2F_search%3Fpretty", "", 200, response)
+		_, err := getTestGateway(t, testClient).ExplainIndex(ctx, "index/_search?pretty")
+		assert.NoError(t, err)
+	})
+	t.Run("empty index", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).ExplainIndex(ctx, "")
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+	t.Run("explain failed", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/explain/index_1", "", 404, []byte("no such index"))
+		_, err := getTestGateway(t, testClient).ExplainIndex(ctx, "index_1")
+		assert.EqualError(t, err, "no such index")
+	})
+}
+
+func TestGateway_AddPolicy(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"updated_indices":2,"failures":false,"failed_indices":[]}`)
+	t.Run("add policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_ism/add/logs-%2A", `{"policy_id":"policy_1"}`, 200, response)
+		actual, err := getTestGateway(t, testClient).AddPolicy(ctx, "logs-*", "policy_1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("empty policy id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).AddPolicy(ctx, "logs-*", "")
+		assert.EqualError(t, err, "policy Id cannot be empty")
+	})
+	t.Run("empty index", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).AddPolicy(ctx, "", "policy_1")
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+	t.Run("remove policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_ism/remove/logs-%2A", "", 200, response)
+		actual, err := getTestGateway(t, testClient).RemovePolicy(ctx, "logs-*")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+}
+
+func TestGateway_Policies(t *testing.T) {
+	ctx := context.Background()
+	policy := `{"policy":{"description":"delete workflow","default_state":"delete","states":[]}}`
+	t.Run("create policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_ism/policies/policy_1", policy, 201, []byte(`{"_id":"policy_1"}`))
+		actual, err := getTestGateway(t, testClient).CreatePolicy(ctx, "policy_1", json.RawMessage(policy))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_id":"policy_1"}`, string(actual))
+	})
+	t.Run("get policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/policies/policy_1", "", 200, json.RawMessage(policy))
+		actual, err := getTestGateway(t, testClient).GetPolicy(ctx, "policy_1")
+		assert.NoError(t, err)
+		assert.EqualValues(t, policy, string(actual))
+	})
+	t.Run("update policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_ism/policies/policy_1?if_primary_term=1&if_seq_no=7", policy, 200, []byte(`{"_id":"policy_1"}`))
+		_, err := getTestGateway(t, testClient).UpdatePolicy(ctx, "policy_1", 7, 1, json.RawMessage(policy))
+		assert.NoError(t, err)
+	})
+	t.Run("delete policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_ism/policies/policy_1", "", 200, []byte(`{"result":"deleted"}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeletePolicy(ctx, "policy_1"))
+	})
+	t.Run("empty policy id", func(t *testing.T) {
+		err := getTestGateway(t, mocks.NewTestClient(nil)).DeletePolicy(ctx, "")
+		assert.EqualError(t, err, "policy Id cannot be empty")
+	})
+	t.Run("search policies", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/policies?from=20&queryString=delete%2A&size=10", "", 200, []byte(`{"policies":[]}`))
+		actual, err := getTestGateway(t, testClient).SearchPolicies(ctx, "delete*", 20, 10)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"policies":[]}`, string(actual))
+	})
+	t.Run("search all policies with default page size", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_ism/policies?from=0&size=20", "", 200, []byte(`{"policies":[]}`))
+		_, err := getTestGateway(t, testClient).SearchPolicies(ctx, "", 0, 0)
+		assert.NoError(t, err)
+	})
+	t.Run("search with negative from", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).SearchPolicies(ctx, "", -1, 0)
+		assert.EqualError(t, err, "from: -1 cannot be negative")
+	})
+}
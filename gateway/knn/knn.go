@@ -28,7 +28,6 @@ package knn
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -36,21 +35,22 @@ import (
 	"opensearch-cli/entity"
 	"opensearch-cli/entity/knn"
 	gw "opensearch-cli/gateway"
+	"strings"
 )
 
 const (
 	baseURL                  = "_plugins/_knn"
 	statsURL                 = baseURL + "/stats"
-	nodeStatsURLTemplate     = baseURL + "/%s/stats/%s"
 	warmupIndicesURLTemplate = baseURL + "/warmup/%s"
+	nodesStatsURLTemplate    = baseURL + "/%s/stats"
 )
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_knn.go -package=mocks . Gateway
 
 // Gateway interface to k-NN Plugin
 type Gateway interface {
-	GetStatistics(ctx context.Context, nodes string, names string) ([]byte, error)
-	WarmupIndices(ctx context.Context, indices string) ([]byte, error)
+	GetStatistics(ctx context.Context, nodeIDs []string, statNames []string) ([]byte, error)
+	WarmupIndices(ctx context.Context, indices []string) ([]byte, error)
 }
 
 type gateway struct {
@@ -67,28 +67,48 @@ func New(c *client.Client, p *entity.Profile) (Gateway, error) {
 
 }
 
-//buildStatsURL to construct url for stats
-func (g *gateway) buildStatsURL(nodes string, names string) (*url.URL, error) {
+//joinPath joins values by comma, returns both joined path and escaped path where every value
+//is escaped to prevent it from adding path segments or query parameters to the url
+func joinPath(values []string) (string, string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = url.PathEscape(value)
+	}
+	return strings.Join(values, ","), strings.Join(escaped, ",")
+}
+
+//buildStatsURL to construct url for stats filtered by node ids and stat names, any of the filters can be empty
+func (g *gateway) buildStatsURL(nodeIDs []string, statNames []string) (*url.URL, error) {
 	endpoint, err := gw.GetValidEndpoint(g.Profile)
 	if err != nil {
 		return nil, err
 	}
-	path := statsURL
-	// if either of filter parameters are non-empty, use filter template
-	if nodes != "" || names != "" {
-		path = fmt.Sprintf(nodeStatsURLTemplate, nodes, names)
+	path, rawPath := statsURL, statsURL
+	if len(nodeIDs) > 0 {
+		nodes, rawNodes := joinPath(nodeIDs)
+		path, rawPath = fmt.Sprintf(nodesStatsURLTemplate, nodes), fmt.Sprintf(nodesStatsURLTemplate, rawNodes)
+	}
+	if len(statNames) > 0 {
+		names, rawNames := joinPath(statNames)
+		path, rawPath = path+"/"+names, rawPath+"/"+rawNames
 	}
 	endpoint.Path = path
+	endpoint.RawPath = rawPath
 	return endpoint, nil
 }
 
-//buildWarmupURL to construct url for warming up indices
-func (g *gateway) buildWarmupURL(indices string) (*url.URL, error) {
+//buildWarmupURL to construct url for warming up list of indices
+func (g *gateway) buildWarmupURL(indices []string) (*url.URL, error) {
+	if len(indices) < 1 {
+		return nil, fmt.Errorf("at least one index is required")
+	}
 	endpoint, err := gw.GetValidEndpoint(g.Profile)
 	if err != nil {
 		return nil, err
 	}
-	endpoint.Path = fmt.Sprintf(warmupIndicesURLTemplate, indices)
+	path, rawPath := joinPath(indices)
+	endpoint.Path = fmt.Sprintf(warmupIndicesURLTemplate, path)
+	endpoint.RawPath = fmt.Sprintf(warmupIndicesURLTemplate, rawPath)
 	return endpoint, nil
 }
 
@@ -132,23 +152,15 @@ GET /_plugins/_knn/stats
         }
     }
 }
-To filter stats query by nodeID and statName:
+To filter stats query by nodeID and statName, any of the filters can be empty:
 GET /_plugins/_knn/nodeId1,nodeId2/stats/statName1,statName2
 */
-func (g gateway) GetStatistics(ctx context.Context, nodes string, names string) ([]byte, error) {
-	statsURL, err := g.buildStatsURL(nodes, names)
-	if err != nil {
-		return nil, err
-	}
-	request, err := g.BuildRequest(ctx, http.MethodGet, "", statsURL.String(), gw.GetDefaultHeaders())
+func (g gateway) GetStatistics(ctx context.Context, nodeIDs []string, statNames []string) ([]byte, error) {
+	statsURL, err := g.buildStatsURL(nodeIDs, statNames)
 	if err != nil {
 		return nil, err
 	}
-	response, err := g.Call(request, http.StatusOK)
-	if err != nil {
-		return nil, processKNNError(err)
-	}
-	return response, nil
+	return g.get(ctx, statsURL)
 }
 
 func processKNNError(err error) error {
@@ -159,7 +171,7 @@ func processKNNError(err error) error {
 		return err
 	}
 	if len(k.KNNError.RootCause) > 0 {
-		return errors.New(k.KNNError.RootCause[0].Reason)
+		return &gw.ReasonError{Reason: k.KNNError.RootCause[0].Reason, Err: err}
 	}
 	return err
 }
@@ -174,12 +186,16 @@ GET /_plugins/_knn/warmup/index1,index2,index3?pretty
 	}
 }
 */
-func (g gateway) WarmupIndices(ctx context.Context, indices string) ([]byte, error) {
+func (g gateway) WarmupIndices(ctx context.Context, indices []string) ([]byte, error) {
 	warmupURL, err := g.buildWarmupURL(indices)
 	if err != nil {
 		return nil, err
 	}
-	request, err := g.BuildRequest(ctx, http.MethodGet, "", warmupURL.String(), gw.GetDefaultHeaders())
+	return g.get(ctx, warmupURL)
+}
+
+func (g gateway) get(ctx context.Context, endpoint *url.URL) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, http.MethodGet, "", endpoint.String(), gw.GetDefaultHeaders())
 	if err != nil {
 		return nil, err
 	}
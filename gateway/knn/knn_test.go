@@ -34,7 +34,7 @@ func getTestClient(t *testing.T, url string, code int, response []byte) *client.
 	return mocks.NewTestClient(func(req *http.Request) *http.Response {
 		// Test request parameters
 		assert.Equal(t, req.URL.String(), url)
-		assert.EqualValues(t, len(req.Header), 2)
+		assert.EqualValues(t, len(req.Header), 3)
 		return &http.Response{
 			StatusCode: code,
 			// Send response to be tested
@@ -49,58 +49,32 @@ func getTestClient(t *testing.T, url string, code int, response []byte) *client.
 
 func TestGatewayGetStatistics(t *testing.T) {
 	ctx := context.Background()
-	t.Run("full stats succeeded", func(t *testing.T) {
-
-		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/stats", 200, []byte("success"))
-		testGateway, err := New(testClient, &entity.Profile{
-			Endpoint: "http://localhost:9200",
-			UserName: "admin",
-			Password: "admin",
-		})
-		assert.NoError(t, err)
-		actual, err := testGateway.GetStatistics(ctx, "", "")
-		assert.NoError(t, err)
-		assert.EqualValues(t, string(actual), "success")
-	})
-	t.Run("filtered node and stats succeeded", func(t *testing.T) {
-
-		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/node1,node2/stats/stat1", 200, []byte("success"))
-		testGateway, err := New(testClient, &entity.Profile{
-			Endpoint: "http://localhost:9200",
-			UserName: "admin",
-			Password: "admin",
-		})
-		assert.NoError(t, err)
-		actual, err := testGateway.GetStatistics(ctx, "node1,node2", "stat1")
-		assert.NoError(t, err)
-		assert.EqualValues(t, string(actual), "success")
-	})
-	t.Run("filtered node succeeded", func(t *testing.T) {
-
-		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/node1,node2/stats/", 200, []byte("success"))
-		testGateway, err := New(testClient, &entity.Profile{
-			Endpoint: "http://localhost:9200",
-			UserName: "admin",
-			Password: "admin",
-		})
-		assert.NoError(t, err)
-		actual, err := testGateway.GetStatistics(ctx, "node1,node2", "")
-		assert.NoError(t, err)
-		assert.EqualValues(t, string(actual), "success")
-	})
-	t.Run("filtered stats succeeded", func(t *testing.T) {
-
-		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn//stats/stat1,stat2", 200, []byte("success"))
-		testGateway, err := New(testClient, &entity.Profile{
-			Endpoint: "http://localhost:9200",
-			UserName: "admin",
-			Password: "admin",
+	tests := []struct {
+		name      string
+		nodeIDs   []string
+		statNames []string
+		url       string
+	}{
+		{"without filters", nil, nil, "http://localhost:9200/_plugins/_knn/stats"},
+		{"filtered by nodes", []string{"node1", "node2"}, nil, "http://localhost:9200/_plugins/_knn/node1,node2/stats"},
+		{"filtered by stat names", nil, []string{"hit_count", "miss_count"}, "http://localhost:9200/_plugins/_knn/stats/hit_count,miss_count"},
+		{"filtered by nodes and stat names", []string{"node1"}, []string{"hit_count"}, "http://localhost:9200/_plugins/_knn/node1/stats/hit_count"},
+		{"values are escaped", []string{"node/1"}, []string{"hit count?"}, "http://localhost:9200/_plugins/_knn/node%2F1/stats/hit%20count%3F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := getTestClient(t, tt.url, 200, []byte("success"))
+			testGateway, err := New(testClient, &entity.Profile{
+				Endpoint: "http://localhost:9200",
+				UserName: "admin",
+				Password: "admin",
+			})
+			assert.NoError(t, err)
+			actual, err := testGateway.GetStatistics(ctx, tt.nodeIDs, tt.statNames)
+			assert.NoError(t, err)
+			assert.EqualValues(t, "success", string(actual))
 		})
-		assert.NoError(t, err)
-		actual, err := testGateway.GetStatistics(ctx, "", "stat1,stat2")
-		assert.NoError(t, err)
-		assert.EqualValues(t, string(actual), "success")
-	})
+	}
 	t.Run("gateway failed due to gateway user config", func(t *testing.T) {
 
 		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/stats", 400, []byte("failed"))
@@ -110,7 +84,7 @@ func TestGatewayGetStatistics(t *testing.T) {
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		_, err = testGateway.GetStatistics(ctx, "", "")
+		_, err = testGateway.GetStatistics(ctx, nil, nil)
 		assert.Error(t, err)
 	})
 	t.Run("failed due to invalid stat names", func(t *testing.T) {
@@ -133,7 +107,7 @@ func TestGatewayGetStatistics(t *testing.T) {
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		_, err = testGateway.GetStatistics(ctx, "index1", "invalid-stats")
+		_, err = testGateway.GetStatistics(ctx, []string{"index1"}, []string{"invalid-stats"})
 		assert.EqualErrorf(t, err, reason, "failed to parse error")
 	})
 }
@@ -141,17 +115,26 @@ func TestGatewayGetStatistics(t *testing.T) {
 func TestGatewayWarmupIndices(t *testing.T) {
 	ctx := context.Background()
 	t.Run("warmup indices", func(t *testing.T) {
-
-		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/warmup/index1,index2", 200, []byte("success"))
+		testClient := getTestClient(t, "http://localhost:9200/_plugins/_knn/warmup/index1,index%2F2", 200, []byte("success"))
 		testGateway, err := New(testClient, &entity.Profile{
 			Endpoint: "http://localhost:9200",
 			UserName: "admin",
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		actual, err := testGateway.WarmupIndices(ctx, "index1,index2")
+		actual, err := testGateway.WarmupIndices(ctx, []string{"index1", "index/2"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, "success", string(actual))
+	})
+	t.Run("no indices", func(t *testing.T) {
+		testGateway, err := New(mocks.NewTestClient(nil), &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			UserName: "admin",
+			Password: "admin",
+		})
 		assert.NoError(t, err)
-		assert.EqualValues(t, string(actual), "success")
+		_, err = testGateway.WarmupIndices(ctx, nil)
+		assert.EqualError(t, err, "at least one index is required")
 	})
 	t.Run("failed due to invalid index", func(t *testing.T) {
 
@@ -173,7 +156,7 @@ func TestGatewayWarmupIndices(t *testing.T) {
 			Password: "admin",
 		})
 		assert.NoError(t, err)
-		_, err = testGateway.WarmupIndices(ctx, "index1")
+		_, err = testGateway.WarmupIndices(ctx, []string{"index1"})
 		assert.EqualErrorf(t, err, "no such index", "failed to parse error")
 	})
 }
@@ -35,7 +35,7 @@ func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
 }
 
 // GetStatistics mocks base method
-func (m *MockGateway) GetStatistics(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+func (m *MockGateway) GetStatistics(arg0 context.Context, arg1, arg2 []string) ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatistics", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]byte)
@@ -50,7 +50,7 @@ func (mr *MockGatewayMockRecorder) GetStatistics(arg0, arg1, arg2 interface{}) *
 }
 
 // WarmupIndices mocks base method
-func (m *MockGateway) WarmupIndices(arg0 context.Context, arg1 string) ([]byte, error) {
+func (m *MockGateway) WarmupIndices(arg0 context.Context, arg1 []string) ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WarmupIndices", arg0, arg1)
 	ret0, _ := ret[0].([]byte)
@@ -0,0 +1,107 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strings"
+)
+
+const (
+	infoURLTemplate  = "_nodes/%s"
+	statsURLTemplate = "_nodes/%s/stats/%s"
+	all              = "_all"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_nodes.go -package=mocks . Gateway
+
+// Gateway interface to nodes APIs of OpenSearch
+type Gateway interface {
+	Stats(ctx context.Context, nodeIDs []string, metrics []string) ([]byte, error)
+	Info(ctx context.Context, nodeIDs []string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//joinPath joins values by comma, returns both joined path and escaped path where every value
+//is escaped to prevent it from adding path segments or query parameters to the url. If values are
+//empty, _all is returned
+func joinPath(values []string) (string, string) {
+	if len(values) < 1 {
+		return all, all
+	}
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = url.PathEscape(value)
+	}
+	return strings.Join(values, ","), strings.Join(escaped, ",")
+}
+
+func (g *gateway) get(ctx context.Context, path string, rawPath string) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	endpoint.RawPath = rawPath
+	request, err := g.BuildRequest(ctx, http.MethodGet, "", endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*Stats Returns statistics of given nodes, limited to given metrics, like jvm or breaker. Statistics of all
+nodes, or all metrics, are returned if nodeIDs, or metrics, are empty.
+It calls http request: GET _nodes/<node1>,<node2>/stats/<metric1>,<metric2>
+Sample Output:
+{
+  "_nodes": {"total": 1, "successful": 1, "failed": 0},
+  "cluster_name": "opensearch-cluster",
+  "nodes": {
+    "Mgqdm0r9SEGClWxp_RbnaQ": {
+      "name": "opensearch-node1",
+      "jvm": {
+        "mem": {"heap_used_in_bytes": 279459840, "heap_used_percent": 26}
+      }
+    }
+  }
+}*/
+func (g *gateway) Stats(ctx context.Context, nodeIDs []string, metrics []string) ([]byte, error) {
+	nodes, rawNodes := joinPath(nodeIDs)
+	names, rawNames := joinPath(metrics)
+	return g.get(ctx, fmt.Sprintf(statsURLTemplate, nodes, names), fmt.Sprintf(statsURLTemplate, rawNodes, rawNames))
+}
+
+// Info Returns settings, version and plugins of given nodes, or of all nodes if nodeIDs are empty.
+// It calls http request: GET _nodes/<node1>,<node2>
+func (g *gateway) Info(ctx context.Context, nodeIDs []string) ([]byte, error) {
+	nodes, rawNodes := joinPath(nodeIDs)
+	return g.get(ctx, fmt.Sprintf(infoURLTemplate, nodes), fmt.Sprintf(infoURLTemplate, rawNodes))
+}
@@ -0,0 +1,70 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package nodes
+
+import (
+	"context"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Stats(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		nodeIDs []string
+		metrics []string
+		url     string
+	}{
+		{"all nodes and metrics by default", nil, nil, "http://localhost:9200/_nodes/_all/stats/_all"},
+		{"filtered by metrics", nil, []string{"jvm", "breaker"}, "http://localhost:9200/_nodes/_all/stats/jvm,breaker"},
+		{"filtered by nodes and metrics", []string{"node1", "node2"}, []string{"jvm"}, "http://localhost:9200/_nodes/node1,node2/stats/jvm"},
+		{"values are escaped", []string{"node/1"}, []string{"jvm?"}, "http://localhost:9200/_nodes/node%2F1/stats/jvm%3F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := testutil.NewExpectingClient(t, http.MethodGet, tt.url, "", 200, []byte(`{"nodes":{}}`))
+			actual, err := getTestGateway(t, testClient).Stats(ctx, tt.nodeIDs, tt.metrics)
+			assert.NoError(t, err)
+			assert.EqualValues(t, `{"nodes":{}}`, string(actual))
+		})
+	}
+	t.Run("unknown metric", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_nodes/_all/stats/unknown", "", 400, []byte("request contains unrecognized metric: [unknown]"))
+		_, err := getTestGateway(t, testClient).Stats(ctx, nil, []string{"unknown"})
+		assert.EqualError(t, err, "request contains unrecognized metric: [unknown]")
+	})
+}
+
+func TestGateway_Info(t *testing.T) {
+	ctx := context.Background()
+	t.Run("all nodes by default", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_nodes/_all", "", 200, []byte(`{"nodes":{}}`))
+		_, err := getTestGateway(t, testClient).Info(ctx, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("filtered by nodes", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_nodes/node1,node2", "", 200, []byte(`{"nodes":{}}`))
+		_, err := getTestGateway(t, testClient).Info(ctx, []string{"node1", "node2"})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,186 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	baseURL             = "_plugins/_notifications"
+	configsURL          = baseURL + "/configs"
+	configURLTemplate   = configsURL + "/%s"
+	testURLTemplate     = baseURL + "/feature/test/%s"
+	emptyIDErrorMessage = "config Id cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_notifications.go -package=mocks . Gateway
+
+// Gateway interface to Notifications Plugin
+type Gateway interface {
+	CreateConfig(ctx context.Context, payload interface{}) ([]byte, error)
+	GetConfig(ctx context.Context, ID string) ([]byte, error)
+	UpdateConfig(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	DeleteConfig(ctx context.Context, ID string) error
+	ListConfigs(ctx context.Context) ([]byte, error)
+	SendTest(ctx context.Context, configID string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL returns url for given path on profile's endpoint
+func (g *gateway) buildURL(path string) (*url.URL, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = path
+	return endpoint, nil
+}
+
+//buildConfigURL builds url from template for given config ID. ID is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildConfigURL(template string, ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, errors.New(emptyIDErrorMessage)
+	}
+	endpoint, err := g.buildURL(fmt.Sprintf(template, ID))
+	if err != nil {
+		return nil, err
+	}
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(ID))
+	return endpoint, nil
+}
+
+//call sends request, webhook urls of channels are redacted from debug output since they contain secret tokens
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*CreateConfig Creates notification channel config, config_id is generated if it is not provided.
+It calls http request: POST _plugins/_notifications/configs
+Sample Input:
+{
+  "config_id": "sample-id",
+  "config": {
+    "name": "Sample Slack Channel",
+    "description": "This is a Slack channel",
+    "config_type": "slack",
+    "is_enabled": true,
+    "slack": {
+      "url": "https://hooks.slack.com/services/..."
+    }
+  }
+}
+Sample Output:
+{
+  "config_id": "sample-id"
+}*/
+func (g *gateway) CreateConfig(ctx context.Context, payload interface{}) ([]byte, error) {
+	createURL, err := g.buildURL(configsURL)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, createURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// GetConfig Returns notification channel config with given ID.
+// It calls http request: GET _plugins/_notifications/configs/<config_id>
+func (g *gateway) GetConfig(ctx context.Context, ID string) ([]byte, error) {
+	configURL, err := g.buildConfigURL(configURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, configURL, "", http.StatusOK)
+}
+
+// UpdateConfig Replaces notification channel config with given ID.
+// It calls http request: PUT _plugins/_notifications/configs/<config_id>
+func (g *gateway) UpdateConfig(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	configURL, err := g.buildConfigURL(configURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, configURL, payload, http.StatusOK)
+}
+
+// DeleteConfig Deletes notification channel config with given ID.
+// It calls http request: DELETE _plugins/_notifications/configs/<config_id>
+func (g *gateway) DeleteConfig(ctx context.Context, ID string) error {
+	configURL, err := g.buildConfigURL(configURLTemplate, ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, configURL, "", http.StatusOK)
+	return err
+}
+
+// ListConfigs Returns all notification channel configs.
+// It calls http request: GET _plugins/_notifications/configs
+func (g *gateway) ListConfigs(ctx context.Context) ([]byte, error) {
+	listURL, err := g.buildURL(configsURL)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, listURL, "", http.StatusOK)
+}
+
+/*SendTest Sends test message to notification channel with given config ID.
+It calls http request: POST _plugins/_notifications/feature/test/<config_id>
+Sample Output:
+{
+  "event_source": {
+    "title": "Test Message Title-sample-id",
+    "reference_id": "sample-id",
+    "severity": "info",
+    "tags": []
+  },
+  "status_list": [{
+    "config_id": "sample-id",
+    "config_type": "slack",
+    "config_name": "Sample Slack Channel",
+    "email_recipient_status": [],
+    "delivery_status": {
+      "status_code": "200",
+      "status_text": "ok"
+    }
+  }]
+}*/
+func (g *gateway) SendTest(ctx context.Context, configID string) ([]byte, error) {
+	testURL, err := g.buildConfigURL(testURLTemplate, configID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, testURL, "", http.StatusOK)
+}
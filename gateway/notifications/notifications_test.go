@@ -0,0 +1,97 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_SendTest(t *testing.T) {
+	ctx := context.Background()
+	t.Run("send test message", func(t *testing.T) {
+		response := []byte(`{"event_source":{"reference_id":"sample-id"},"status_list":[{"config_id":"sample-id","delivery_status":{"status_code":"200","status_text":"ok"}}]}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_notifications/feature/test/sample-id", "", 200, response)
+		actual, err := getTestGateway(t, testClient).SendTest(ctx, "sample-id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("config id is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_notifications/feature/test/sample%2F..%3F", "", 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).SendTest(ctx, "sample/..?")
+		assert.NoError(t, err)
+	})
+	t.Run("send test message failed", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_notifications/feature/test/sample-id", "", 400, []byte("failed to send message"))
+		_, err := getTestGateway(t, testClient).SendTest(ctx, "sample-id")
+		assert.EqualError(t, err, "failed to send message")
+	})
+	t.Run("empty config id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).SendTest(ctx, "")
+		assert.EqualError(t, err, "config Id cannot be empty")
+	})
+}
+
+func TestGateway_Configs(t *testing.T) {
+	ctx := context.Background()
+	config := `{"config_id":"sample-id","config":{"name":"Sample Slack Channel","config_type":"slack","is_enabled":true,"slack":{"url":"https://hooks.slack.com/services/T0/B0/secret"}}}`
+	t.Run("create config", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_notifications/configs", config, 200, []byte(`{"config_id":"sample-id"}`))
+		actual, err := getTestGateway(t, testClient).CreateConfig(ctx, json.RawMessage(config))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"config_id":"sample-id"}`, string(actual))
+	})
+	t.Run("create config is redacted in debug output", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_notifications/configs", "", 200, []byte(`{"config_id":"sample-id"}`))
+		var debug bytes.Buffer
+		testClient.Debug = &debug
+		_, err := getTestGateway(t, testClient).CreateConfig(ctx, json.RawMessage(config))
+		assert.NoError(t, err)
+		assert.Contains(t, debug.String(), `"slack":{"url":"[REDACTED]"}`)
+		assert.NotContains(t, debug.String(), "secret")
+	})
+	t.Run("get config", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_notifications/configs/sample-id", "", 200, []byte(config))
+		actual, err := getTestGateway(t, testClient).GetConfig(ctx, "sample-id")
+		assert.NoError(t, err)
+		assert.EqualValues(t, config, string(actual))
+	})
+	t.Run("update config", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_notifications/configs/sample-id", config, 200, []byte(`{"config_id":"sample-id"}`))
+		_, err := getTestGateway(t, testClient).UpdateConfig(ctx, "sample-id", json.RawMessage(config))
+		assert.NoError(t, err)
+	})
+	t.Run("delete config", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_notifications/configs/sample-id", "", 200, []byte(`{"delete_response_list":{"sample-id":"OK"}}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteConfig(ctx, "sample-id"))
+	})
+	t.Run("list configs", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_notifications/configs", "", 200, []byte(`{"config_list":[]}`))
+		actual, err := getTestGateway(t, testClient).ListConfigs(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"config_list":[]}`, string(actual))
+	})
+}
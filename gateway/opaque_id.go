@@ -0,0 +1,44 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+//invocationOpaqueID is generated once, so that every request of this invocation has same X-Opaque-Id
+var invocationOpaqueID struct {
+	sync.Once
+	value string
+	err   error
+}
+
+//getInvocationOpaqueID returns random UUID which identifies requests of this invocation
+func getInvocationOpaqueID() (string, error) {
+	invocationOpaqueID.Do(func() {
+		invocationOpaqueID.value, invocationOpaqueID.err = newUUID()
+	})
+	return invocationOpaqueID.value, invocationOpaqueID.err
+}
+
+//newUUID returns random (version 4) UUID
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate opaque id: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,87 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpaqueID(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(opaqueIDHeader))
+	}))
+	defer server.Close()
+	call := func(t *testing.T, opaqueID string, profile *entity.Profile) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testClient.OpaqueID = opaqueID
+		g, err := NewHTTPGateway(testClient, profile)
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+	}
+	t.Run("disabled", func(t *testing.T) {
+		received = nil
+		call(t, "", &entity.Profile{Endpoint: server.URL})
+		assert.Equal(t, []string{""}, received)
+	})
+	t.Run("provided by client", func(t *testing.T) {
+		received = nil
+		call(t, "nightly-job", &entity.Profile{Endpoint: server.URL})
+		call(t, "nightly-job", &entity.Profile{Endpoint: server.URL, OpaqueID: true})
+		assert.Equal(t, []string{"nightly-job", "nightly-job"}, received)
+	})
+	t.Run("generated once per invocation", func(t *testing.T) {
+		received = nil
+		profile := &entity.Profile{Endpoint: server.URL, OpaqueID: true}
+		call(t, "", profile)
+		call(t, "", profile)
+		assert.Len(t, received, 2)
+		assert.Regexp(t, regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"), received[0])
+		assert.Equal(t, received[0], received[1])
+	})
+	t.Run("request header is not overridden", func(t *testing.T) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		testClient.OpaqueID = "nightly-job"
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL})
+		assert.NoError(t, err)
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL, map[string]string{opaqueIDHeader: "custom"})
+		assert.NoError(t, err)
+		assert.Equal(t, "custom", req.Header.Get(opaqueIDHeader))
+	})
+}
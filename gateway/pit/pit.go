@@ -0,0 +1,144 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package pit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	pitURL                  = "_search/point_in_time"
+	createURLTemplate       = "%s/" + pitURL
+	listURL                 = pitURL + "/_all"
+	keepAliveQueryParamName = "keep_alive"
+	pitIDField              = "pit_id"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_pit.go -package=mocks . Gateway
+
+// Gateway interface to point in time API of OpenSearch
+type Gateway interface {
+	CreatePIT(ctx context.Context, index string, keepAlive string) (string, error)
+	DeletePIT(ctx context.Context, IDs []string) ([]byte, error)
+	ListPITs(ctx context.Context) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*CreatePIT Creates point in time on given index, which can be a name, pattern or comma separated list,
+and returns its ID. Point in time is kept alive for keepAlive, like 1h, since it was last used.
+It calls http request: POST <index>/_search/point_in_time?keep_alive=<keep_alive>
+Sample Output:
+{
+  "pit_id": "o463QQEPbXktaW5kZXgtMDAwMDAxFnNOWU43ckt3U3IyaFVpbGE1UWEtMncAFjFyeXBsRGJmVFM2RTB6eVg1aVVqQncAAAAAAAAAAAIWcDVrM3ZIX0pRNS1XejE5YXRPRFhzUQEWc05ZTjdyS3dTcjJoVWlsYTVRYS0ydwAA",
+  "_shards": {
+    "total": 1,
+    "successful": 1,
+    "skipped": 0,
+    "failed": 0
+  },
+  "creation_time": 1658146050064
+}*/
+func (g *gateway) CreatePIT(ctx context.Context, index string, keepAlive string) (string, error) {
+	if len(index) < 1 {
+		return "", errors.New("index cannot be empty")
+	}
+	if len(keepAlive) < 1 {
+		return "", errors.New("keep alive cannot be empty")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return "", err
+	}
+	endpoint.Path = fmt.Sprintf(createURLTemplate, index)
+	endpoint.RawPath = fmt.Sprintf(createURLTemplate, url.PathEscape(index))
+	endpoint.RawQuery = url.Values{keepAliveQueryParamName: []string{keepAlive}}.Encode()
+	response, err := g.call(ctx, http.MethodPost, endpoint, "")
+	if err != nil {
+		return "", err
+	}
+	var pit map[string]interface{}
+	if err := json.Unmarshal(response, &pit); err != nil {
+		return "", err
+	}
+	ID, ok := pit[pitIDField].(string)
+	if !ok || len(ID) < 1 {
+		return "", fmt.Errorf("response does not contain point in time Id: %s", string(response))
+	}
+	return ID, nil
+}
+
+/*DeletePIT Deletes points in time with given IDs in single request.
+It calls http request: DELETE _search/point_in_time
+with body: {"pit_id": ["<pit_id1>", "<pit_id2>"]}
+Sample Output:
+{
+  "pits": [
+    {"successful": true, "pit_id": "<pit_id1>"},
+    {"successful": true, "pit_id": "<pit_id2>"}
+  ]
+}*/
+func (g *gateway) DeletePIT(ctx context.Context, IDs []string) ([]byte, error) {
+	if len(IDs) < 1 {
+		return nil, errors.New("at least one point in time Id is required")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = pitURL
+	return g.call(ctx, http.MethodDelete, endpoint, map[string][]string{pitIDField: IDs})
+}
+
+/*ListPITs Returns all points in time of the cluster.
+It calls http request: GET _search/point_in_time/_all
+Sample Output:
+{
+  "pits": [
+    {"pit_id": "<pit_id>", "creation_time": 1658146048666, "keep_alive": 6000000}
+  ]
+}*/
+func (g *gateway) ListPITs(ctx context.Context) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = listURL
+	return g.call(ctx, http.MethodGet, endpoint, "")
+}
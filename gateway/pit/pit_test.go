@@ -0,0 +1,77 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package pit
+
+import (
+	"context"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_CreatePIT(t *testing.T) {
+	ctx := context.Background()
+	t.Run("create pit", func(t *testing.T) {
+		response := []byte(`{"pit_id":"o463QQEPbXktaW5kZXg","_shards":{"total":1,"successful":1},"creation_time":1658146050064}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/results-%2A/_search/point_in_time?keep_alive=1h", "", 200, response)
+		actual, err := getTestGateway(t, testClient).CreatePIT(ctx, "results-*", "1h")
+		assert.NoError(t, err)
+		assert.EqualValues(t, "o463QQEPbXktaW5kZXg", actual)
+	})
+	t.Run("response without pit id", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/results/_search/point_in_time?keep_alive=1h", "", 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).CreatePIT(ctx, "results", "1h")
+		assert.EqualError(t, err, "response does not contain point in time Id: {}")
+	})
+	t.Run("unknown index", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/unknown/_search/point_in_time?keep_alive=1h", "", 404, []byte("no such index [unknown]"))
+		_, err := getTestGateway(t, testClient).CreatePIT(ctx, "unknown", "1h")
+		assert.EqualError(t, err, "no such index [unknown]")
+	})
+	t.Run("empty keep alive", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).CreatePIT(ctx, "results", "")
+		assert.EqualError(t, err, "keep alive cannot be empty")
+	})
+}
+
+func TestGateway_DeletePIT(t *testing.T) {
+	ctx := context.Background()
+	t.Run("delete multiple pits", func(t *testing.T) {
+		response := []byte(`{"pits":[{"successful":true,"pit_id":"pit1"},{"successful":true,"pit_id":"pit2"}]}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_search/point_in_time", `{"pit_id":["pit1","pit2"]}`, 200, response)
+		actual, err := getTestGateway(t, testClient).DeletePIT(ctx, []string{"pit1", "pit2"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("no pit ids", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).DeletePIT(ctx, nil)
+		assert.EqualError(t, err, "at least one point in time Id is required")
+	})
+}
+
+func TestGateway_ListPITs(t *testing.T) {
+	response := []byte(`{"pits":[{"pit_id":"pit1","creation_time":1658146048666,"keep_alive":6000000}]}`)
+	testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_search/point_in_time/_all", "", 200, response)
+	actual, err := getTestGateway(t, testClient).ListPITs(context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, response, actual)
+}
@@ -104,12 +104,8 @@ func (g *gateway) Curl(ctx context.Context, request platform.CurlRequest) ([]byt
 	if err != nil {
 		return nil, err
 	}
-	//append request headers with gateway default headers
-	headers := gw.GetDefaultHeaders()
-	for k, v := range request.Headers {
-		headers[k] = v
-	}
-	curlRequest, err := g.BuildCurlRequest(ctx, request.Action, request.Data, requestURL.String(), headers)
+	//request headers are merged over gateway default headers
+	curlRequest, err := g.BuildCurlRequest(ctx, request.Action, request.Data, requestURL.String(), request.Headers)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,7 @@ func getTestClient(t *testing.T, responseData string, code int) *client.Client {
 		assert.NoError(t, err)
 		assert.EqualValues(t, body.Size, 0)
 		assert.EqualValues(t, body.Agg.Group.Term.Field, "day_of_week")
-		assert.EqualValues(t, len(req.Header), 2)
+		assert.EqualValues(t, len(req.Header), 3)
 		return &http.Response{
 			StatusCode: code,
 			// Send response to be tested
@@ -204,6 +204,24 @@ func TestGatewayCurl(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, string(actual), "OK")
 	})
+	t.Run("curl headers override default headers", func(t *testing.T) {
+		expectedHeader := map[string]string{
+			"Accept":       "text/csv",
+			"Content-Type": "text/plain",
+		}
+		for i := 0; i < 10; i++ {
+			testClient := getCurlTestClient(t, "http://localhost:9200/_cat/indices", []byte(``), expectedHeader, "OK", 200)
+			testGateway, err := New(testClient, p)
+			assert.NoError(t, err)
+			_, err = testGateway.Curl(ctx, platform.CurlRequest{
+				Action:  http.MethodGet,
+				Path:    "_cat/indices",
+				Headers: expectedHeader,
+				Data:    []byte(``),
+			})
+			assert.NoError(t, err)
+		}
+	})
 	t.Run("curl failed due to client error", func(t *testing.T) {
 		expectedData := []byte(`{"data": 1}`)
 		expectedHeader := map[string]string{
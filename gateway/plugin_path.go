@@ -0,0 +1,62 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/url"
+	"opensearch-cli/entity"
+	"opensearch-cli/entity/platform"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+//getPluginPathPrefix returns plugin path prefix of the profile if set, else, prefix which cluster answered to
+//in previous requests of the gateway, default is _plugins
+func (g *HTTPGateway) getPluginPathPrefix() string {
+	if len(g.Profile.PluginPathPrefix) > 0 {
+		return g.Profile.PluginPathPrefix
+	}
+	if prefix := g.cluster.getPluginPathPrefix(); len(prefix) > 0 {
+		return prefix
+	}
+	return entity.PluginsPathPrefix
+}
+
+//setPluginPathPrefix replaces _plugins prefix of u's path with prefix. It returns false, without changing u,
+//if u is not a plugin API with _plugins prefix, paths with _opendistro prefix are requested explicitly
+func setPluginPathPrefix(u *url.URL, prefix string) bool {
+	current := "/" + entity.PluginsPathPrefix + "/"
+	if !strings.HasPrefix(u.Path, current) {
+		return false
+	}
+	u.Path = "/" + prefix + "/" + strings.TrimPrefix(u.Path, current)
+	if len(u.RawPath) > 0 {
+		u.RawPath = "/" + prefix + "/" + strings.TrimPrefix(u.RawPath, current)
+	}
+	return true
+}
+
+//shouldFallbackToOpenDistro returns true if request with _plugins prefix is not found, and profile
+//doesn't set plugin path prefix explicitly
+func shouldFallbackToOpenDistro(profile *entity.Profile, req *retryablehttp.Request, err error) bool {
+	if len(profile.PluginPathPrefix) > 0 || isStreamed(req.Context()) {
+		return false
+	}
+	return isNotFound(err)
+}
+
+func isNotFound(err error) bool {
+	r, ok := err.(*platform.RequestError)
+	return ok && r.StatusCode() == http.StatusNotFound
+}
@@ -0,0 +1,137 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginPathPrefix(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	// legacy cluster knows only _opendistro prefix, and has no detector "missing"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if !strings.HasPrefix(r.URL.Path, "/_opendistro/") && r.URL.Path != "/" || strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(r.URL.Path + " not found"))
+			return
+		}
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+	call := func(t *testing.T, g *HTTPGateway, path string) (string, error) {
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL+path, GetDefaultHeaders())
+		assert.NoError(t, err)
+		response, err := g.Call(req, http.StatusOK)
+		return string(response), err
+	}
+	getGateway := func(t *testing.T, prefix string, fallback bool) *HTTPGateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL, PluginPathPrefix: prefix})
+		assert.NoError(t, err)
+		g.PluginPathFallback = fallback
+		paths = nil
+		return g
+	}
+	t.Run("plugins prefix", func(t *testing.T) {
+		g := getGateway(t, entity.PluginsPathPrefix, true)
+		_, err := call(t, g, "/_plugins/_anomaly_detection/detectors/id")
+		assert.EqualError(t, err, "/_plugins/_anomaly_detection/detectors/id not found")
+		assert.Equal(t, []string{"/_plugins/_anomaly_detection/detectors/id"}, paths)
+	})
+	t.Run("opendistro prefix", func(t *testing.T) {
+		g := getGateway(t, entity.OpenDistroPathPrefix, false)
+		response, err := call(t, g, "/_plugins/_anomaly_detection/detectors/id")
+		assert.NoError(t, err)
+		assert.Equal(t, "/_opendistro/_anomaly_detection/detectors/id", response)
+		assert.Equal(t, []string{"/_opendistro/_anomaly_detection/detectors/id"}, paths)
+	})
+	t.Run("other paths are not changed", func(t *testing.T) {
+		g := getGateway(t, entity.OpenDistroPathPrefix, true)
+		response, err := call(t, g, "/")
+		assert.NoError(t, err)
+		assert.Equal(t, "/", response)
+	})
+	t.Run("explicit opendistro prefix is not changed", func(t *testing.T) {
+		g := getGateway(t, entity.PluginsPathPrefix, true)
+		response, err := call(t, g, "/_opendistro/_security/api/roles")
+		assert.NoError(t, err)
+		assert.Equal(t, "/_opendistro/_security/api/roles", response)
+	})
+	t.Run("fallback on 404", func(t *testing.T) {
+		g := getGateway(t, "", true)
+		response, err := call(t, g, "/_plugins/_anomaly_detection/detectors/id")
+		assert.NoError(t, err)
+		assert.Equal(t, "/_opendistro/_anomaly_detection/detectors/id", response)
+		// detected prefix is used for remaining requests
+		response, err = call(t, g, "/_plugins/_anomaly_detection/detectors/id/_profile")
+		assert.NoError(t, err)
+		assert.Equal(t, "/_opendistro/_anomaly_detection/detectors/id/_profile", response)
+		assert.Equal(t, []string{
+			"/_plugins/_anomaly_detection/detectors/id",
+			"/_opendistro/_anomaly_detection/detectors/id",
+			"/_opendistro/_anomaly_detection/detectors/id/_profile",
+		}, paths)
+	})
+	t.Run("detected prefix is shared by copies of gateway", func(t *testing.T) {
+		g := getGateway(t, "", true)
+		_, err := call(t, g, "/_plugins/_anomaly_detection/detectors/id")
+		assert.NoError(t, err)
+		copied := *g
+		response, err := call(t, &copied, "/_plugins/_anomaly_detection/detectors/id/_profile")
+		assert.NoError(t, err)
+		assert.Equal(t, "/_opendistro/_anomaly_detection/detectors/id/_profile", response)
+		assert.Len(t, paths, 3)
+	})
+	t.Run("fallback not found", func(t *testing.T) {
+		g := getGateway(t, "", true)
+		_, err := call(t, g, "/_plugins/_anomaly_detection/detectors/missing")
+		assert.EqualError(t, err, "/_plugins/_anomaly_detection/detectors/missing not found")
+		assert.Equal(t, []string{
+			"/_plugins/_anomaly_detection/detectors/missing",
+			"/_opendistro/_anomaly_detection/detectors/missing",
+		}, paths)
+	})
+	t.Run("fallback disabled", func(t *testing.T) {
+		g := getGateway(t, "", false)
+		_, err := call(t, g, "/_plugins/_anomaly_detection/detectors/id")
+		assert.EqualError(t, err, "/_plugins/_anomaly_detection/detectors/id not found")
+		assert.Equal(t, []string{"/_plugins/_anomaly_detection/detectors/id"}, paths)
+	})
+}
@@ -0,0 +1,91 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package ppl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strings"
+)
+
+const (
+	baseURL    = "_plugins/_ppl"
+	explainURL = baseURL + "/_explain"
+	queryField = "query"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_ppl.go -package=mocks . Gateway
+
+// Gateway interface to PPL Plugin
+type Gateway interface {
+	Query(ctx context.Context, statement string) ([]byte, error)
+	Explain(ctx context.Context, statement string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+func (g *gateway) call(ctx context.Context, endpoint *url.URL, statement string) ([]byte, error) {
+	if len(strings.TrimSpace(statement)) < 1 {
+		return nil, fmt.Errorf("statement cannot be empty")
+	}
+	request, err := g.BuildRequest(ctx, http.MethodPost, map[string]string{queryField: statement}, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*Query Runs PPL statement and returns response in plugin's default format, jdbc.
+It calls http request: POST _plugins/_ppl
+with body: {"query": "<statement>"}
+Sample Output:
+{
+  "schema": [{"name": "firstname", "type": "string"}],
+  "datarows": [["Amber"], ["Hattie"]],
+  "total": 2,
+  "size": 2
+}*/
+func (g *gateway) Query(ctx context.Context, statement string) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = baseURL
+	return g.call(ctx, endpoint, statement)
+}
+
+// Explain Returns query plan of PPL statement, translated to OpenSearch query DSL.
+// It calls http request: POST _plugins/_ppl/_explain
+func (g *gateway) Explain(ctx context.Context, statement string) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = explainURL
+	return g.call(ctx, endpoint, statement)
+}
@@ -0,0 +1,91 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package ppl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleResult = `{"schema":[{"name":"firstname","type":"string"}],"datarows":[["Amber"],["Hattie"]],"total":2,"size":2}`
+
+//getTestServer returns server which echoes sample PPL result for expected query posted to path
+func getTestServer(path string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if body["query"] != "source=accounts | fields firstname" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("unexpected query"))
+			return
+		}
+		_, _ = w.Write([]byte(sampleResult))
+	}))
+}
+
+func getTestGateway(t *testing.T, endpoint string) Gateway {
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	zero := 0
+	g, err := New(testClient, &entity.Profile{
+		Name:     "test",
+		Endpoint: endpoint,
+		UserName: "admin",
+		Password: "admin",
+		MaxRetry: &zero,
+	})
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Query(t *testing.T) {
+	ctx := context.Background()
+	t.Run("query", func(t *testing.T) {
+		server := getTestServer("/_plugins/_ppl")
+		defer server.Close()
+		actual, err := getTestGateway(t, server.URL).Query(ctx, "source=accounts | fields firstname")
+		assert.NoError(t, err)
+		assert.JSONEq(t, sampleResult, string(actual))
+	})
+	t.Run("query failed", func(t *testing.T) {
+		server := getTestServer("/_plugins/_ppl")
+		defer server.Close()
+		_, err := getTestGateway(t, server.URL).Query(ctx, "source=unknown")
+		assert.EqualError(t, err, "unexpected query")
+	})
+	t.Run("empty statement", func(t *testing.T) {
+		_, err := getTestGateway(t, "http://localhost:9200").Query(ctx, "")
+		assert.EqualError(t, err, "statement cannot be empty")
+	})
+}
+
+func TestGateway_Explain(t *testing.T) {
+	server := getTestServer("/_plugins/_ppl/_explain")
+	defer server.Close()
+	actual, err := getTestGateway(t, server.URL).Explain(context.Background(), "source=accounts | fields firstname")
+	assert.NoError(t, err)
+	assert.JSONEq(t, sampleResult, string(actual))
+}
@@ -0,0 +1,109 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package reindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strconv"
+)
+
+const (
+	reindexURL                 = "_reindex"
+	taskURLTemplate            = "_tasks/%s"
+	waitForCompletionParamName = "wait_for_completion"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_reindex.go -package=mocks . Gateway
+
+// Gateway interface to reindex API of OpenSearch
+type Gateway interface {
+	Reindex(ctx context.Context, body interface{}, waitForCompletion bool) ([]byte, error)
+	GetTask(ctx context.Context, taskID string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+/*Reindex Copies documents from source index to destination index. If waitForCompletion is true, it returns
+result once all documents are copied, else, it returns task ID immediately, which can be polled by GetTask.
+It calls http request: POST _reindex?wait_for_completion=<true|false>
+Sample Input:
+{
+  "source": {"index": "source-index"},
+  "dest": {"index": "destination-index"}
+}
+Sample Output, if waitForCompletion is false:
+{
+  "task": "oTUltX4IQMOUUVeiohTt8A:12345"
+}*/
+func (g *gateway) Reindex(ctx context.Context, body interface{}, waitForCompletion bool) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = reindexURL
+	endpoint.RawQuery = url.Values{
+		waitForCompletionParamName: []string{strconv.FormatBool(waitForCompletion)},
+	}.Encode()
+	request, err := g.BuildRequest(ctx, http.MethodPost, body, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*GetTask Returns status of task with given ID, like task started by Reindex, and its response once completed.
+It calls http request: GET _tasks/<task_id>
+Sample Output:
+{
+  "completed": true,
+  "task": {
+    "node": "oTUltX4IQMOUUVeiohTt8A",
+    "id": 12345,
+    "action": "indices:data/write/reindex",
+    "status": {"total": 6154, "created": 6154}
+  },
+  "response": {"took": 1218, "created": 6154, "failures": []}
+}*/
+func (g *gateway) GetTask(ctx context.Context, taskID string) ([]byte, error) {
+	if len(taskID) < 1 {
+		return nil, errors.New("task Id cannot be empty")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(taskURLTemplate, taskID)
+	endpoint.RawPath = fmt.Sprintf(taskURLTemplate, url.PathEscape(taskID))
+	request, err := g.BuildRequest(ctx, http.MethodGet, "", endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
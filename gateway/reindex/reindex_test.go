@@ -0,0 +1,68 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package reindex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Reindex(t *testing.T) {
+	ctx := context.Background()
+	body := `{"source":{"index":"source-index"},"dest":{"index":"destination-index"}}`
+	t.Run("wait for completion", func(t *testing.T) {
+		response := []byte(`{"took":1218,"timed_out":false,"total":6154,"created":6154,"failures":[]}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_reindex?wait_for_completion=true", body, http.StatusOK, response)
+		actual, err := getTestGateway(t, testClient).Reindex(ctx, json.RawMessage(body), true)
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("async", func(t *testing.T) {
+		response := []byte(`{"task":"oTUltX4IQMOUUVeiohTt8A:12345"}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_reindex?wait_for_completion=false", body, http.StatusOK, response)
+		actual, err := getTestGateway(t, testClient).Reindex(ctx, json.RawMessage(body), false)
+		assert.NoError(t, err)
+		var task struct {
+			Task string `json:"task"`
+		}
+		assert.NoError(t, json.Unmarshal(actual, &task))
+		assert.EqualValues(t, "oTUltX4IQMOUUVeiohTt8A:12345", task.Task)
+	})
+}
+
+func TestGateway_GetTask(t *testing.T) {
+	ctx := context.Background()
+	t.Run("get task", func(t *testing.T) {
+		response := []byte(`{"completed":true,"task":{"node":"oTUltX4IQMOUUVeiohTt8A","id":12345}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_tasks/oTUltX4IQMOUUVeiohTt8A:12345", "", http.StatusOK, response)
+		actual, err := getTestGateway(t, testClient).GetTask(ctx, "oTUltX4IQMOUUVeiohTt8A:12345")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("empty task id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).GetTask(ctx, "")
+		assert.EqualError(t, err, "task Id cannot be empty")
+	})
+}
@@ -0,0 +1,140 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	baseURL           = "_plugins/_replication"
+	startURLTemplate  = baseURL + "/%s/_start"
+	stopURLTemplate   = baseURL + "/%s/_stop"
+	pauseURLTemplate  = baseURL + "/%s/_pause"
+	resumeURLTemplate = baseURL + "/%s/_resume"
+	statusURLTemplate = baseURL + "/%s/_status"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_replication.go -package=mocks . Gateway
+
+// Gateway interface to Cross Cluster Replication Plugin
+type Gateway interface {
+	StartReplication(ctx context.Context, index string, payload interface{}) ([]byte, error)
+	StopReplication(ctx context.Context, index string) ([]byte, error)
+	PauseReplication(ctx context.Context, index string) ([]byte, error)
+	ResumeReplication(ctx context.Context, index string) ([]byte, error)
+	GetReplicationStatus(ctx context.Context, index string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildIndexURL builds url from template for given follower index. Index is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildIndexURL(template string, index string) (*url.URL, error) {
+	if len(index) < 1 {
+		return nil, errors.New("index cannot be empty")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, index)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(index))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, template string, index string, payload interface{}) ([]byte, error) {
+	indexURL, err := g.buildIndexURL(template, index)
+	if err != nil {
+		return nil, err
+	}
+	request, err := g.BuildRequest(ctx, method, payload, indexURL.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*StartReplication Starts replicating leader index from remote cluster into given follower index,
+which must not exist on this cluster.
+It calls http request: PUT _plugins/_replication/<follower_index>/_start
+Sample Input:
+{
+  "leader_alias": "leader-cluster",
+  "leader_index": "leader-01",
+  "use_roles": {
+    "leader_cluster_role": "all_access",
+    "follower_cluster_role": "all_access"
+  }
+}
+Sample Output:
+{
+  "acknowledged": true
+}*/
+func (g *gateway) StartReplication(ctx context.Context, index string, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, startURLTemplate, index, payload)
+}
+
+// StopReplication Stops replication of given follower index, which becomes regular index and can't be resumed.
+// It calls http request: POST _plugins/_replication/<follower_index>/_stop
+func (g *gateway) StopReplication(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodPost, stopURLTemplate, index, map[string]interface{}{})
+}
+
+// PauseReplication Pauses replication of given follower index.
+// It calls http request: POST _plugins/_replication/<follower_index>/_pause
+func (g *gateway) PauseReplication(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodPost, pauseURLTemplate, index, map[string]interface{}{})
+}
+
+// ResumeReplication Resumes paused replication of given follower index.
+// It calls http request: POST _plugins/_replication/<follower_index>/_resume
+func (g *gateway) ResumeReplication(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodPost, resumeURLTemplate, index, map[string]interface{}{})
+}
+
+/*GetReplicationStatus Returns status of replication of given follower index.
+It calls http request: GET _plugins/_replication/<follower_index>/_status
+Sample Output:
+{
+  "status": "SYNCING",
+  "reason": "User initiated",
+  "leader_alias": "leader-cluster",
+  "leader_index": "leader-01",
+  "follower_index": "follower-01",
+  "syncing_details": {
+    "leader_checkpoint": 19,
+    "follower_checkpoint": 19,
+    "seq_no": 0
+  }
+}*/
+func (g *gateway) GetReplicationStatus(ctx context.Context, index string) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, statusURLTemplate, index, "")
+}
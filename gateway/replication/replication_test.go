@@ -0,0 +1,97 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_StartReplication(t *testing.T) {
+	ctx := context.Background()
+	t.Run("start replication", func(t *testing.T) {
+		body := `{"leader_alias":"leader-cluster","leader_index":"leader-01","use_roles":{"leader_cluster_role":"all_access","follower_cluster_role":"all_access"}}`
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_replication/follower-01/_start", body, 200, []byte(`{"acknowledged":true}`))
+		payload := map[string]interface{}{
+			"leader_alias": "leader-cluster",
+			"leader_index": "leader-01",
+			"use_roles": map[string]string{
+				"leader_cluster_role":   "all_access",
+				"follower_cluster_role": "all_access",
+			},
+		}
+		actual, err := getTestGateway(t, testClient).StartReplication(ctx, "follower-01", payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+	})
+	t.Run("follower index already exists", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_replication/follower-01/_start", "", 400, []byte("index [follower-01] already exists"))
+		_, err := getTestGateway(t, testClient).StartReplication(ctx, "follower-01", json.RawMessage(`{}`))
+		assert.EqualError(t, err, "index [follower-01] already exists")
+	})
+	t.Run("empty index", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).StartReplication(ctx, "", json.RawMessage(`{}`))
+		assert.EqualError(t, err, "index cannot be empty")
+	})
+}
+
+func TestGateway_GetReplicationStatus(t *testing.T) {
+	response := []byte(`{"status":"SYNCING","reason":"User initiated","leader_alias":"leader-cluster","leader_index":"leader-01","follower_index":"follower-01","syncing_details":{"leader_checkpoint":19,"follower_checkpoint":19,"seq_no":0}}`)
+	testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_replication/follower-01/_status", "", 200, response)
+	actual, err := getTestGateway(t, testClient).GetReplicationStatus(context.Background(), "follower-01")
+	assert.NoError(t, err)
+	var status struct {
+		Status         string `json:"status"`
+		SyncingDetails struct {
+			LeaderCheckpoint   int `json:"leader_checkpoint"`
+			FollowerCheckpoint int `json:"follower_checkpoint"`
+		} `json:"syncing_details"`
+	}
+	assert.NoError(t, json.Unmarshal(actual, &status))
+	assert.EqualValues(t, "SYNCING", status.Status)
+	assert.EqualValues(t, 19, status.SyncingDetails.LeaderCheckpoint)
+	assert.EqualValues(t, 19, status.SyncingDetails.FollowerCheckpoint)
+}
+
+func TestGateway_ChangeReplicationState(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(Gateway, context.Context, string) ([]byte, error)
+		url  string
+	}{
+		{"stop", Gateway.StopReplication, "http://localhost:9200/_plugins/_replication/follower%2F01/_stop"},
+		{"pause", Gateway.PauseReplication, "http://localhost:9200/_plugins/_replication/follower%2F01/_pause"},
+		{"resume", Gateway.ResumeReplication, "http://localhost:9200/_plugins/_replication/follower%2F01/_resume"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := testutil.NewExpectingClient(t, http.MethodPost, tt.url, `{}`, 200, []byte(`{"acknowledged":true}`))
+			actual, err := tt.call(getTestGateway(t, testClient), ctx, "follower/01")
+			assert.NoError(t, err)
+			assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+		})
+	}
+}
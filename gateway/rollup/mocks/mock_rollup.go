@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: opensearch-cli/gateway/rollup (interfaces: Gateway)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGateway is a mock of Gateway interface
+type MockGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockGatewayMockRecorder
+}
+
+// MockGatewayMockRecorder is the mock recorder for MockGateway
+type MockGatewayMockRecorder struct {
+	mock *MockGateway
+}
+
+// NewMockGateway creates a new mock instance
+func NewMockGateway(ctrl *gomock.Controller) *MockGateway {
+	mock := &MockGateway{ctrl: ctrl}
+	mock.recorder = &MockGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
+	return m.recorder
+}
+
+// DeleteRollup mocks base method
+func (m *MockGateway) DeleteRollup(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRollup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRollup indicates an expected call of DeleteRollup
+func (mr *MockGatewayMockRecorder) DeleteRollup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRollup", reflect.TypeOf((*MockGateway)(nil).DeleteRollup), arg0, arg1)
+}
+
+// ExplainRollup mocks base method
+func (m *MockGateway) ExplainRollup(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainRollup", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainRollup indicates an expected call of ExplainRollup
+func (mr *MockGatewayMockRecorder) ExplainRollup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainRollup", reflect.TypeOf((*MockGateway)(nil).ExplainRollup), arg0, arg1)
+}
+
+// GetRollup mocks base method
+func (m *MockGateway) GetRollup(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRollup", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRollup indicates an expected call of GetRollup
+func (mr *MockGatewayMockRecorder) GetRollup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRollup", reflect.TypeOf((*MockGateway)(nil).GetRollup), arg0, arg1)
+}
+
+// PutRollup mocks base method
+func (m *MockGateway) PutRollup(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutRollup", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutRollup indicates an expected call of PutRollup
+func (mr *MockGatewayMockRecorder) PutRollup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRollup", reflect.TypeOf((*MockGateway)(nil).PutRollup), arg0, arg1, arg2)
+}
+
+// StartRollup mocks base method
+func (m *MockGateway) StartRollup(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRollup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRollup indicates an expected call of StartRollup
+func (mr *MockGatewayMockRecorder) StartRollup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRollup", reflect.TypeOf((*MockGateway)(nil).StartRollup), arg0, arg1)
+}
+
+// StopRollup mocks base method
+func (m *MockGateway) StopRollup(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRollup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopRollup indicates an expected call of StopRollup
+func (mr *MockGatewayMockRecorder) StopRollup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRollup", reflect.TypeOf((*MockGateway)(nil).StopRollup), arg0, arg1)
+}
@@ -0,0 +1,198 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package rollup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	baseURL                = "_plugins/_rollup"
+	jobURLTemplate         = baseURL + "/jobs/%s"
+	startURLTemplate       = jobURLTemplate + "/_start"
+	stopURLTemplate        = jobURLTemplate + "/_stop"
+	explainURLTemplate     = jobURLTemplate + "/_explain"
+	emptyIDErrorMessage    = "rollup Id cannot be empty"
+	acknowledgedField      = "acknowledged"
+	notAcknowledgedMessage = "request to %s rollup %s was not acknowledged"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_rollup.go -package=mocks . Gateway
+
+// Gateway interface to Index Rollups Plugin
+type Gateway interface {
+	PutRollup(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	GetRollup(ctx context.Context, ID string) ([]byte, error)
+	DeleteRollup(ctx context.Context, ID string) error
+	StartRollup(ctx context.Context, ID string) error
+	StopRollup(ctx context.Context, ID string) error
+	ExplainRollup(ctx context.Context, ID string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildJobURL builds url from template for given rollup ID. ID is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildJobURL(template string, ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, errors.New(emptyIDErrorMessage)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, ID)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(ID))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*PutRollup Creates rollup job with given ID.
+It calls http request: PUT _plugins/_rollup/jobs/<rollup_id>
+Sample Input:
+{
+  "rollup": {
+    "enabled": true,
+    "schedule": {
+      "interval": {"period": 1, "unit": "Minutes", "start_time": 1602100553}
+    },
+    "description": "Hourly rollup of logs",
+    "source_index": "logs-*",
+    "target_index": "logs-rollup",
+    "page_size": 1000,
+    "delay": 0,
+    "continuous": true,
+    "dimensions": [{
+      "date_histogram": {"source_field": "timestamp", "fixed_interval": "60m", "timezone": "UTC"}
+    }],
+    "metrics": [{
+      "source_field": "bytes",
+      "metrics": [{"sum": {}}, {"avg": {}}]
+    }]
+  }
+}*/
+func (g *gateway) PutRollup(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	jobURL, err := g.buildJobURL(jobURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, jobURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// GetRollup Returns rollup job with given ID.
+// It calls http request: GET _plugins/_rollup/jobs/<rollup_id>
+func (g *gateway) GetRollup(ctx context.Context, ID string) ([]byte, error) {
+	jobURL, err := g.buildJobURL(jobURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, jobURL, "", http.StatusOK)
+}
+
+// DeleteRollup Deletes rollup job with given ID.
+// It calls http request: DELETE _plugins/_rollup/jobs/<rollup_id>
+func (g *gateway) DeleteRollup(ctx context.Context, ID string) error {
+	jobURL, err := g.buildJobURL(jobURLTemplate, ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, jobURL, "", http.StatusOK)
+	return err
+}
+
+// StartRollup Starts rollup job with given ID.
+// It calls http request: POST _plugins/_rollup/jobs/<rollup_id>/_start
+func (g *gateway) StartRollup(ctx context.Context, ID string) error {
+	return g.changeState(ctx, startURLTemplate, "start", ID)
+}
+
+// StopRollup Stops rollup job with given ID.
+// It calls http request: POST _plugins/_rollup/jobs/<rollup_id>/_stop
+func (g *gateway) StopRollup(ctx context.Context, ID string) error {
+	return g.changeState(ctx, stopURLTemplate, "stop", ID)
+}
+
+//changeState starts or stops rollup job, plugin replies {"acknowledged": true} if state was changed
+func (g *gateway) changeState(ctx context.Context, template string, action string, ID string) error {
+	stateURL, err := g.buildJobURL(template, ID)
+	if err != nil {
+		return err
+	}
+	response, err := g.call(ctx, http.MethodPost, stateURL, "", http.StatusOK)
+	if err != nil {
+		return err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return err
+	}
+	if acknowledged, ok := result[acknowledgedField].(bool); !ok || !acknowledged {
+		return fmt.Errorf(notAcknowledgedMessage, action, ID)
+	}
+	return nil
+}
+
+/*ExplainRollup Returns metadata and status of rollup job with given ID.
+It calls http request: GET _plugins/_rollup/jobs/<rollup_id>/_explain
+Sample Output:
+{
+  "example_rollup": {
+    "rollup_id": "example_rollup",
+    "last_updated_time": 1602100553,
+    "continuous": {
+      "next_window_start_time": 1602100553,
+      "next_window_end_time": 1602100556
+    },
+    "status": "failed",
+    "failure_reason": "Unknown failure",
+    "stats": {
+      "pages_processed": 342,
+      "documents_processed": 489359,
+      "rollups_indexed": 3420,
+      "index_time_in_ms": 30495,
+      "search_time_in_ms": 584922
+    }
+  }
+}*/
+func (g *gateway) ExplainRollup(ctx context.Context, ID string) ([]byte, error) {
+	explainURL, err := g.buildJobURL(explainURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, explainURL, "", http.StatusOK)
+}
@@ -0,0 +1,90 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package rollup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_StartStopRollup(t *testing.T) {
+	ctx := context.Background()
+	t.Run("start rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup/_start", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).StartRollup(ctx, "logs_rollup"))
+	})
+	t.Run("start rollup not acknowledged", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup/_start", "", 200, []byte(`{"acknowledged":false}`))
+		assert.EqualError(t, getTestGateway(t, testClient).StartRollup(ctx, "logs_rollup"), "request to start rollup logs_rollup was not acknowledged")
+	})
+	t.Run("stop rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup/_stop", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).StopRollup(ctx, "logs_rollup"))
+	})
+	t.Run("stop unknown rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_rollup/jobs/unknown/_stop", "", 404, []byte("not found"))
+		assert.EqualError(t, getTestGateway(t, testClient).StopRollup(ctx, "unknown"), "not found")
+	})
+	t.Run("stop rollup with empty id", func(t *testing.T) {
+		assert.EqualError(t, getTestGateway(t, mocks.NewTestClient(nil)).StopRollup(ctx, ""), "rollup Id cannot be empty")
+	})
+}
+
+func TestGateway_ExplainRollup(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"logs_rollup":{"rollup_id":"logs_rollup","status":"started","stats":{"pages_processed":342}}}`)
+	t.Run("explain rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup/_explain", "", 200, response)
+		actual, err := getTestGateway(t, testClient).ExplainRollup(ctx, "logs_rollup")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("rollup id is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_rollup/jobs/logs%2F_start%3F/_explain", "", 200, response)
+		_, err := getTestGateway(t, testClient).ExplainRollup(ctx, "logs/_start?")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGateway_Rollup(t *testing.T) {
+	ctx := context.Background()
+	rollup := `{"rollup":{"enabled":true,"source_index":"logs-*","target_index":"logs-rollup"}}`
+	t.Run("put rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup", rollup, 201, []byte(`{"_id":"logs_rollup"}`))
+		actual, err := getTestGateway(t, testClient).PutRollup(ctx, "logs_rollup", json.RawMessage(rollup))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_id":"logs_rollup"}`, string(actual))
+	})
+	t.Run("get rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup", "", 200, []byte(rollup))
+		actual, err := getTestGateway(t, testClient).GetRollup(ctx, "logs_rollup")
+		assert.NoError(t, err)
+		assert.EqualValues(t, rollup, string(actual))
+	})
+	t.Run("delete rollup", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_rollup/jobs/logs_rollup", "", 200, []byte(`{}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteRollup(ctx, "logs_rollup"))
+	})
+}
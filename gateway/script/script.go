@@ -0,0 +1,146 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	scriptURLTemplate = "_scripts/%s"
+	renderURL         = "_render/template"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_script.go -package=mocks . Gateway
+
+// Gateway interface to stored scripts and search templates APIs of OpenSearch
+type Gateway interface {
+	PutScript(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	GetScript(ctx context.Context, ID string) ([]byte, error)
+	DeleteScript(ctx context.Context, ID string) error
+	RenderTemplate(ctx context.Context, payload interface{}) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildScriptURL builds url for given script ID. ID is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildScriptURL(ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, errors.New("script Id cannot be empty")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(scriptURLTemplate, ID)
+	endpoint.RawPath = fmt.Sprintf(scriptURLTemplate, url.PathEscape(ID))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*PutScript Stores script, or search template, with given ID.
+It calls http request: PUT _scripts/<script_id>
+Sample Input:
+{
+  "script": {
+    "lang": "painless",
+    "source": "doc['bytes'].value / params.divisor"
+  }
+}
+Sample Input for search template:
+{
+  "script": {
+    "lang": "mustache",
+    "source": {
+      "query": {"match": {"host": "{{host}}"}}
+    }
+  }
+}*/
+func (g *gateway) PutScript(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	scriptURL, err := g.buildScriptURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, scriptURL, payload)
+}
+
+// GetScript Returns stored script, or search template, with given ID.
+// It calls http request: GET _scripts/<script_id>
+func (g *gateway) GetScript(ctx context.Context, ID string) ([]byte, error) {
+	scriptURL, err := g.buildScriptURL(ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, scriptURL, "")
+}
+
+// DeleteScript Deletes stored script, or search template, with given ID.
+// It calls http request: DELETE _scripts/<script_id>
+func (g *gateway) DeleteScript(ctx context.Context, ID string) error {
+	scriptURL, err := g.buildScriptURL(ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, scriptURL, "")
+	return err
+}
+
+/*RenderTemplate Renders search template, either stored one referred by id or inline source, with given params,
+and returns resulting search request without running it.
+It calls http request: POST _render/template
+Sample Input:
+{
+  "id": "host-query",
+  "params": {
+    "host": "server-1"
+  }
+}
+Sample Output:
+{
+  "template_output": {
+    "query": {"match": {"host": "server-1"}}
+  }
+}*/
+func (g *gateway) RenderTemplate(ctx context.Context, payload interface{}) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = renderURL
+	return g.call(ctx, http.MethodPost, endpoint, payload)
+}
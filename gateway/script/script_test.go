@@ -0,0 +1,85 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Script(t *testing.T) {
+	ctx := context.Background()
+	t.Run("put painless script", func(t *testing.T) {
+		body := `{"script":{"lang":"painless","source":"doc['bytes'].value / params.divisor"}}`
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_scripts/bytes-ratio", body, 200, []byte(`{"acknowledged":true}`))
+		payload := map[string]interface{}{
+			"script": map[string]string{
+				"lang":   "painless",
+				"source": "doc['bytes'].value / params.divisor",
+			},
+		}
+		actual, err := getTestGateway(t, testClient).PutScript(ctx, "bytes-ratio", payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+	})
+	t.Run("invalid script", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_scripts/invalid", "", 400, []byte("compile error"))
+		_, err := getTestGateway(t, testClient).PutScript(ctx, "invalid", json.RawMessage(`{"script":{"lang":"painless","source":"doc["}}`))
+		assert.EqualError(t, err, "compile error")
+	})
+	t.Run("get script", func(t *testing.T) {
+		response := []byte(`{"_id":"bytes-ratio","found":true,"script":{"lang":"painless","source":"doc['bytes'].value / params.divisor"}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_scripts/bytes-ratio", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetScript(ctx, "bytes-ratio")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("script id is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_scripts/bytes%2F..%3F", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteScript(ctx, "bytes/..?"))
+	})
+	t.Run("empty script id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).GetScript(ctx, "")
+		assert.EqualError(t, err, "script Id cannot be empty")
+	})
+}
+
+func TestGateway_RenderTemplate(t *testing.T) {
+	ctx := context.Background()
+	body := `{"source":{"query":{"match":{"host":"{{host}}"}},"size":"{{size}}"},"params":{"host":"server-1","size":10}}`
+	response := []byte(`{"template_output":{"query":{"match":{"host":"server-1"}},"size":"10"}}`)
+	testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_render/template", body, 200, response)
+	actual, err := getTestGateway(t, testClient).RenderTemplate(ctx, json.RawMessage(body))
+	assert.NoError(t, err)
+	var rendered struct {
+		TemplateOutput struct {
+			Query map[string]map[string]string `json:"query"`
+			Size  string                       `json:"size"`
+		} `json:"template_output"`
+	}
+	assert.NoError(t, json.Unmarshal(actual, &rendered))
+	assert.EqualValues(t, "server-1", rendered.TemplateOutput.Query["match"]["host"])
+	assert.EqualValues(t, "10", rendered.TemplateOutput.Size)
+}
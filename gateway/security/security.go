@@ -0,0 +1,247 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	baseURL                = "_plugins/_security/api"
+	usersURL               = baseURL + "/internalusers"
+	userURLTemplate        = usersURL + "/%s"
+	roleURLTemplate        = baseURL + "/roles/%s"
+	roleMappingURLTemplate = baseURL + "/rolesmapping/%s"
+	userFieldName          = "user name"
+	roleFieldName          = "role name"
+	emptyValueErrorMessage = "%s cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_security.go -package=mocks . Gateway
+
+// Gateway interface to Security Plugin
+type Gateway interface {
+	GetUser(ctx context.Context, name string) ([]byte, error)
+	CreateUser(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	DeleteUser(ctx context.Context, name string) error
+	ListUsers(ctx context.Context) ([]byte, error)
+	GetRole(ctx context.Context, name string) ([]byte, error)
+	PutRole(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	DeleteRole(ctx context.Context, name string) error
+	GetRoleMapping(ctx context.Context, name string) ([]byte, error)
+	PutRoleMapping(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	DeleteRoleMapping(ctx context.Context, name string) error
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildURL builds url from template for given name of resource, like user. Name is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildURL(template string, field string, name string) (*url.URL, error) {
+	if len(name) < 1 {
+		return nil, fmt.Errorf(emptyValueErrorMessage, field)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, name)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(name))
+	return endpoint, nil
+}
+
+//call sends request, payload is never logged in plain text since debug output redacts passwords and hashes
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*GetUser Returns internal user with given name.
+It calls http request: GET _plugins/_security/api/internalusers/<name>
+Sample Output:
+{
+  "kirk": {
+    "hash": "",
+    "reserved": false,
+    "hidden": false,
+    "backend_roles": ["captains"],
+    "attributes": {"attribute1": "value1"},
+    "static": false
+  }
+}*/
+func (g *gateway) GetUser(ctx context.Context, name string) ([]byte, error) {
+	userURL, err := g.buildURL(userURLTemplate, userFieldName, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, userURL, "", http.StatusOK)
+}
+
+/*CreateUser Creates internal user with given name, or replaces it if it already exists.
+It calls http request: PUT _plugins/_security/api/internalusers/<name>
+Sample Input:
+{
+  "password": "kirkpass",
+  "opendistro_security_roles": ["maintenance_staff", "weapons"],
+  "backend_roles": ["captains", "starfleet"],
+  "attributes": {"attribute1": "value1"}
+}*/
+func (g *gateway) CreateUser(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	userURL, err := g.buildURL(userURLTemplate, userFieldName, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, userURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteUser Deletes internal user with given name.
+// It calls http request: DELETE _plugins/_security/api/internalusers/<name>
+func (g *gateway) DeleteUser(ctx context.Context, name string) error {
+	userURL, err := g.buildURL(userURLTemplate, userFieldName, name)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, userURL, "", http.StatusOK)
+	return err
+}
+
+// ListUsers Returns all internal users, keyed by user name.
+// It calls http request: GET _plugins/_security/api/internalusers
+func (g *gateway) ListUsers(ctx context.Context) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = usersURL
+	return g.call(ctx, http.MethodGet, endpoint, "", http.StatusOK)
+}
+
+/*GetRole Returns role with given name.
+It calls http request: GET _plugins/_security/api/roles/<name>
+Sample Output:
+{
+  "test-role": {
+    "reserved": false,
+    "hidden": false,
+    "cluster_permissions": ["cluster_composite_ops"],
+    "index_permissions": [{
+      "index_patterns": ["movies*"],
+      "allowed_actions": ["read"]
+    }],
+    "tenant_permissions": [],
+    "static": false
+  }
+}*/
+func (g *gateway) GetRole(ctx context.Context, name string) ([]byte, error) {
+	roleURL, err := g.buildURL(roleURLTemplate, roleFieldName, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, roleURL, "", http.StatusOK)
+}
+
+/*PutRole Creates role with given name, or replaces whole role if it already exists.
+It calls http request: PUT _plugins/_security/api/roles/<name>
+Sample Input:
+{
+  "cluster_permissions": ["cluster_composite_ops"],
+  "index_permissions": [{
+    "index_patterns": ["movies*"],
+    "allowed_actions": ["read"]
+  }]
+}*/
+func (g *gateway) PutRole(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	roleURL, err := g.buildURL(roleURLTemplate, roleFieldName, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, roleURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteRole Deletes role with given name.
+// It calls http request: DELETE _plugins/_security/api/roles/<name>
+func (g *gateway) DeleteRole(ctx context.Context, name string) error {
+	roleURL, err := g.buildURL(roleURLTemplate, roleFieldName, name)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, roleURL, "", http.StatusOK)
+	return err
+}
+
+/*GetRoleMapping Returns mapping of role with given name to users, backend roles and hosts.
+It calls http request: GET _plugins/_security/api/rolesmapping/<name>
+Sample Output:
+{
+  "test-role": {
+    "hosts": [],
+    "users": ["kirk"],
+    "reserved": false,
+    "hidden": false,
+    "backend_roles": ["starfleet"],
+    "and_backend_roles": []
+  }
+}*/
+func (g *gateway) GetRoleMapping(ctx context.Context, name string) ([]byte, error) {
+	mappingURL, err := g.buildURL(roleMappingURLTemplate, roleFieldName, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, mappingURL, "", http.StatusOK)
+}
+
+/*PutRoleMapping Creates mapping of role with given name, or replaces whole mapping if it already exists.
+It calls http request: PUT _plugins/_security/api/rolesmapping/<name>
+Sample Input:
+{
+  "backend_roles": ["starfleet", "captains"],
+  "hosts": ["*.starfleetintranet.com"],
+  "users": ["worf"]
+}*/
+func (g *gateway) PutRoleMapping(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	mappingURL, err := g.buildURL(roleMappingURLTemplate, roleFieldName, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, mappingURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// DeleteRoleMapping Deletes mapping of role with given name.
+// It calls http request: DELETE _plugins/_security/api/rolesmapping/<name>
+func (g *gateway) DeleteRoleMapping(ctx context.Context, name string) error {
+	mappingURL, err := g.buildURL(roleMappingURLTemplate, roleFieldName, name)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, mappingURL, "", http.StatusOK)
+	return err
+}
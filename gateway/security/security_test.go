@@ -0,0 +1,154 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Users(t *testing.T) {
+	ctx := context.Background()
+	user := `{"password":"kirkpass","backend_roles":["captains"]}`
+	t.Run("create user", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_security/api/internalusers/kirk", user, 201, []byte(`{"status":"CREATED"}`))
+		actual, err := getTestGateway(t, testClient).CreateUser(ctx, "kirk", json.RawMessage(user))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"status":"CREATED"}`, string(actual))
+	})
+	t.Run("get user", func(t *testing.T) {
+		response := []byte(`{"kirk":{"hash":"","backend_roles":["captains"]}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_security/api/internalusers/kirk", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetUser(ctx, "kirk")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("user name is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_security/api/internalusers/cn=kirk%2Cou=starfleet%2F..", "", 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).GetUser(ctx, "cn=kirk,ou=starfleet/..")
+		assert.NoError(t, err)
+	})
+	t.Run("delete user", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_security/api/internalusers/kirk", "", 200, []byte(`{"status":"OK"}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteUser(ctx, "kirk"))
+	})
+	t.Run("delete unknown user", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_security/api/internalusers/spock", "", 404, []byte("not found"))
+		assert.EqualError(t, getTestGateway(t, testClient).DeleteUser(ctx, "spock"), "not found")
+	})
+	t.Run("empty user name", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).CreateUser(ctx, "", json.RawMessage(user))
+		assert.EqualError(t, err, "user name cannot be empty")
+	})
+	t.Run("list users", func(t *testing.T) {
+		response := []byte(`{"admin":{"hash":""},"kirk":{"hash":""}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_security/api/internalusers", "", 200, response)
+		actual, err := getTestGateway(t, testClient).ListUsers(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+}
+
+func TestGateway_CreateUserDebug(t *testing.T) {
+	testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_security/api/internalusers/kirk", "", 201, []byte(`{"status":"CREATED"}`))
+	var debug bytes.Buffer
+	testClient.Debug = &debug
+	payload := map[string]interface{}{
+		"password":      "kirkpass",
+		"hash":          "$2y$12$kirkhash",
+		"backend_roles": []string{"captains"},
+	}
+	_, err := getTestGateway(t, testClient).CreateUser(context.Background(), "kirk", payload)
+	assert.NoError(t, err)
+	output := debug.String()
+	assert.Contains(t, output, "> PUT http://localhost:9200/_plugins/_security/api/internalusers/kirk\n")
+	assert.Contains(t, output, `"password":"[REDACTED]"`)
+	assert.Contains(t, output, `"hash":"[REDACTED]"`)
+	assert.Contains(t, output, `"backend_roles":["captains"]`)
+	assert.NotContains(t, output, "kirkpass")
+	assert.NotContains(t, output, "kirkhash")
+}
+
+func TestGateway_Roles(t *testing.T) {
+	ctx := context.Background()
+	role := `{"cluster_permissions":["cluster_composite_ops"],"index_permissions":[{"index_patterns":["movies*"],"allowed_actions":["read"]}]}`
+	t.Run("put role", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_security/api/roles/test-role", role, 201, []byte(`{"status":"CREATED"}`))
+		actual, err := getTestGateway(t, testClient).PutRole(ctx, "test-role", json.RawMessage(role))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"status":"CREATED"}`, string(actual))
+	})
+	t.Run("get role", func(t *testing.T) {
+		response := []byte(`{"test-role":` + role + `}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_security/api/roles/test-role", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetRole(ctx, "test-role")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("delete role", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_security/api/roles/test-role", "", 200, []byte(`{"status":"OK"}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteRole(ctx, "test-role"))
+	})
+	t.Run("empty role name", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).GetRole(ctx, "")
+		assert.EqualError(t, err, "role name cannot be empty")
+	})
+}
+
+func TestGateway_RoleMappings(t *testing.T) {
+	ctx := context.Background()
+	mappingURL := "http://localhost:9200/_plugins/_security/api/rolesmapping/test-role"
+	t.Run("add backend role to existing mapping", func(t *testing.T) {
+		response := []byte(`{"test-role":{"hosts":[],"users":["kirk"],"reserved":false,"hidden":false,"backend_roles":["starfleet"],"and_backend_roles":[]}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, mappingURL, "", 200, response)
+		mappingResponse, err := getTestGateway(t, testClient).GetRoleMapping(ctx, "test-role")
+		assert.NoError(t, err)
+
+		var mappings map[string]struct {
+			Hosts        []string `json:"hosts"`
+			Users        []string `json:"users"`
+			BackendRoles []string `json:"backend_roles"`
+		}
+		assert.NoError(t, json.Unmarshal(mappingResponse, &mappings))
+		mapping := mappings["test-role"]
+		mapping.BackendRoles = append(mapping.BackendRoles, "captains")
+
+		expected := `{"hosts":[],"users":["kirk"],"backend_roles":["starfleet","captains"]}`
+		testClient = testutil.NewExpectingClient(t, http.MethodPut, mappingURL, expected, 200, []byte(`{"status":"OK"}`))
+		actual, err := getTestGateway(t, testClient).PutRoleMapping(ctx, "test-role", mapping)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"status":"OK"}`, string(actual))
+	})
+	t.Run("get unknown mapping", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_security/api/rolesmapping/unknown", "", 404, []byte("not found"))
+		_, err := getTestGateway(t, testClient).GetRoleMapping(ctx, "unknown")
+		assert.EqualError(t, err, "not found")
+	})
+	t.Run("delete mapping", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, mappingURL, "", 200, []byte(`{"status":"OK"}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteRoleMapping(ctx, "test-role"))
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package sm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strconv"
+)
+
+const (
+	baseURL                = "_plugins/_sm"
+	policiesURL            = baseURL + "/policies"
+	policyURLTemplate      = policiesURL + "/%s"
+	startURLTemplate       = policyURLTemplate + "/_start"
+	stopURLTemplate        = policyURLTemplate + "/_stop"
+	explainURLTemplate     = policyURLTemplate + "/_explain"
+	seqNoQueryParam        = "if_seq_no"
+	primaryTermQueryParam  = "if_primary_term"
+	queryStringQueryParam  = "queryString"
+	fromQueryParam         = "from"
+	sizeQueryParam         = "size"
+	defaultSearchPageSize  = 20
+	policyNameFieldName    = "policy name"
+	emptyValueErrorMessage = "%s cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_sm.go -package=mocks . Gateway
+
+// Gateway interface to Snapshot Management Plugin
+type Gateway interface {
+	CreatePolicy(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	GetPolicy(ctx context.Context, name string) ([]byte, error)
+	UpdatePolicy(ctx context.Context, name string, seqNo int64, primaryTerm int64, payload interface{}) ([]byte, error)
+	DeletePolicy(ctx context.Context, name string) error
+	SearchPolicies(ctx context.Context, queryString string, from int, size int) ([]byte, error)
+	StartPolicy(ctx context.Context, name string) error
+	StopPolicy(ctx context.Context, name string) error
+	ExplainPolicy(ctx context.Context, name string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildPolicyURL builds url from template for given policy name. Name is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildPolicyURL(template string, name string) (*url.URL, error) {
+	if len(name) < 1 {
+		return nil, fmt.Errorf(emptyValueErrorMessage, policyNameFieldName)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, name)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(name))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*CreatePolicy Creates snapshot management policy with given name.
+It calls http request: POST _plugins/_sm/policies/<policy_name>
+Sample Input:
+{
+  "description": "Daily snapshot policy",
+  "creation": {
+    "schedule": {
+      "cron": {"expression": "0 8 * * *", "timezone": "UTC"}
+    },
+    "time_limit": "1h"
+  },
+  "deletion": {
+    "schedule": {
+      "cron": {"expression": "0 1 * * *", "timezone": "America/Los_Angeles"}
+    },
+    "condition": {"max_age": "7d", "max_count": 21, "min_count": 7}
+  },
+  "snapshot_config": {
+    "date_format": "yyyy-MM-dd-HH:mm",
+    "indices": "*",
+    "repository": "s3-repo"
+  }
+}*/
+func (g *gateway) CreatePolicy(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	policyURL, err := g.buildPolicyURL(policyURLTemplate, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, policyURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// GetPolicy Returns snapshot management policy with given name, including its _seq_no and _primary_term.
+// It calls http request: GET _plugins/_sm/policies/<policy_name>
+func (g *gateway) GetPolicy(ctx context.Context, name string) ([]byte, error) {
+	policyURL, err := g.buildPolicyURL(policyURLTemplate, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, policyURL, "", http.StatusOK)
+}
+
+// UpdatePolicy Updates snapshot management policy. seqNo and primaryTerm of the policy, returned by GetPolicy,
+// are required to prevent overwriting changes made by others since policy was read.
+// It calls http request: PUT _plugins/_sm/policies/<policy_name>?if_seq_no=<seq_no>&if_primary_term=<primary_term>
+func (g *gateway) UpdatePolicy(ctx context.Context, name string, seqNo int64, primaryTerm int64, payload interface{}) ([]byte, error) {
+	policyURL, err := g.buildPolicyURL(policyURLTemplate, name)
+	if err != nil {
+		return nil, err
+	}
+	policyURL.RawQuery = url.Values{
+		seqNoQueryParam:       []string{strconv.FormatInt(seqNo, 10)},
+		primaryTermQueryParam: []string{strconv.FormatInt(primaryTerm, 10)},
+	}.Encode()
+	return g.call(ctx, http.MethodPut, policyURL, payload, http.StatusOK)
+}
+
+// DeletePolicy Deletes snapshot management policy with given name.
+// It calls http request: DELETE _plugins/_sm/policies/<policy_name>
+func (g *gateway) DeletePolicy(ctx context.Context, name string) error {
+	policyURL, err := g.buildPolicyURL(policyURLTemplate, name)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, policyURL, "", http.StatusOK)
+	return err
+}
+
+// SearchPolicies Returns a page of snapshot management policies matching query string, starting at from, with at
+// most size policies. If query string is empty, all policies are matched. If size is zero, default page size of 20
+// will be used.
+// It calls http request: GET _plugins/_sm/policies?queryString=<query>&from=<from>&size=<size>
+func (g *gateway) SearchPolicies(ctx context.Context, queryString string, from int, size int) ([]byte, error) {
+	if from < 0 {
+		return nil, fmt.Errorf("from: %d cannot be negative", from)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("size: %d cannot be negative", size)
+	}
+	if size == 0 {
+		size = defaultSearchPageSize
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = policiesURL
+	query := url.Values{
+		fromQueryParam: []string{strconv.Itoa(from)},
+		sizeQueryParam: []string{strconv.Itoa(size)},
+	}
+	if len(queryString) > 0 {
+		query.Set(queryStringQueryParam, queryString)
+	}
+	endpoint.RawQuery = query.Encode()
+	return g.call(ctx, http.MethodGet, endpoint, "", http.StatusOK)
+}
+
+// StartPolicy Starts snapshot management policy with given name.
+// It calls http request: POST _plugins/_sm/policies/<policy_name>/_start
+func (g *gateway) StartPolicy(ctx context.Context, name string) error {
+	startURL, err := g.buildPolicyURL(startURLTemplate, name)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodPost, startURL, "", http.StatusOK)
+	return err
+}
+
+// StopPolicy Stops snapshot management policy with given name.
+// It calls http request: POST _plugins/_sm/policies/<policy_name>/_stop
+func (g *gateway) StopPolicy(ctx context.Context, name string) error {
+	stopURL, err := g.buildPolicyURL(stopURLTemplate, name)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodPost, stopURL, "", http.StatusOK)
+	return err
+}
+
+/*ExplainPolicy Returns status of snapshot management policies matching name, which can be a name,
+a pattern or comma separated list.
+It calls http request: GET _plugins/_sm/policies/<policy_name>/_explain
+Sample Output:
+{
+  "policies": [{
+    "name": "daily-policy",
+    "creation": {
+      "current_state": "CREATION_START",
+      "trigger": {"time": 1656403200000}
+    },
+    "deletion": {
+      "current_state": "DELETION_START",
+      "trigger": {"time": 1656403200000}
+    },
+    "policy_seq_no": 0,
+    "policy_primary_term": 1,
+    "enabled": true
+  }]
+}*/
+func (g *gateway) ExplainPolicy(ctx context.Context, name string) ([]byte, error) {
+	explainURL, err := g.buildPolicyURL(explainURLTemplate, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, explainURL, "", http.StatusOK)
+}
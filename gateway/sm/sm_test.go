@@ -0,0 +1,104 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package sm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_ExplainPolicy(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"policies":[{"name":"daily-policy","creation":{"current_state":"CREATION_START","trigger":{"time":1656403200000}},"policy_seq_no":0,"policy_primary_term":1,"enabled":true}]}`)
+	t.Run("explain policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_sm/policies/daily-policy/_explain", "", 200, response)
+		actual, err := getTestGateway(t, testClient).ExplainPolicy(ctx, "daily-policy")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("explain policies matching pattern", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_sm/policies/daily-%2A/_explain", "", 200, response)
+		_, err := getTestGateway(t, testClient).ExplainPolicy(ctx, "daily-*")
+		assert.NoError(t, err)
+	})
+	t.Run("explain unknown policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_sm/policies/unknown/_explain", "", 404, []byte("not found"))
+		_, err := getTestGateway(t, testClient).ExplainPolicy(ctx, "unknown")
+		assert.EqualError(t, err, "not found")
+	})
+}
+
+func TestGateway_StartStopPolicy(t *testing.T) {
+	ctx := context.Background()
+	t.Run("start policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_sm/policies/daily-policy/_start", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).StartPolicy(ctx, "daily-policy"))
+	})
+	t.Run("start policy failed", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_sm/policies/daily-policy/_start", "", 400, []byte("failed"))
+		assert.EqualError(t, getTestGateway(t, testClient).StartPolicy(ctx, "daily-policy"), "failed")
+	})
+	t.Run("start policy with empty name", func(t *testing.T) {
+		assert.EqualError(t, getTestGateway(t, mocks.NewTestClient(nil)).StartPolicy(ctx, ""), "policy name cannot be empty")
+	})
+	t.Run("stop policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_sm/policies/daily-policy/_stop", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).StopPolicy(ctx, "daily-policy"))
+	})
+}
+
+func TestGateway_Policies(t *testing.T) {
+	ctx := context.Background()
+	policy := `{"description":"Daily snapshot policy","snapshot_config":{"repository":"s3-repo"}}`
+	t.Run("create policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_sm/policies/daily-policy", policy, 201, []byte(`{"_id":"daily-policy-sm-policy"}`))
+		_, err := getTestGateway(t, testClient).CreatePolicy(ctx, "daily-policy", json.RawMessage(policy))
+		assert.NoError(t, err)
+	})
+	t.Run("get policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_sm/policies/daily-policy", "", 200, []byte(policy))
+		actual, err := getTestGateway(t, testClient).GetPolicy(ctx, "daily-policy")
+		assert.NoError(t, err)
+		assert.EqualValues(t, policy, string(actual))
+	})
+	t.Run("update policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_sm/policies/daily-policy?if_primary_term=1&if_seq_no=3", policy, 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).UpdatePolicy(ctx, "daily-policy", 3, 1, json.RawMessage(policy))
+		assert.NoError(t, err)
+	})
+	t.Run("delete policy", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_sm/policies/daily-policy", "", 200, []byte(`{}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeletePolicy(ctx, "daily-policy"))
+	})
+	t.Run("search policies", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_sm/policies?from=0&queryString=daily%2A&size=20", "", 200, []byte(`{"policies":[]}`))
+		_, err := getTestGateway(t, testClient).SearchPolicies(ctx, "daily*", 0, 0)
+		assert.NoError(t, err)
+	})
+	t.Run("search with negative size", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).SearchPolicies(ctx, "", 0, -1)
+		assert.EqualError(t, err, "size: -1 cannot be negative")
+	})
+}
@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strings"
+)
+
+const (
+	baseURL          = "_plugins/_sql"
+	explainURL       = baseURL + "/_explain"
+	formatQueryParam = "format"
+	queryField       = "query"
+)
+
+//Response formats supported by SQL plugin
+const (
+	JSONFormat = "json"
+	CSVFormat  = "csv"
+	RawFormat  = "raw"
+	JDBCFormat = "jdbc"
+)
+
+var formats = []string{JSONFormat, CSVFormat, RawFormat, JDBCFormat}
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_sql.go -package=mocks . Gateway
+
+// Gateway interface to SQL Plugin
+type Gateway interface {
+	Query(ctx context.Context, statement string, format string) ([]byte, error)
+	Explain(ctx context.Context, statement string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+func validateFormat(format string) error {
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid format: %s, expected one of: %s", format, strings.Join(formats, ", "))
+}
+
+func (g *gateway) call(ctx context.Context, endpoint *url.URL, statement string) ([]byte, error) {
+	if len(strings.TrimSpace(statement)) < 1 {
+		return nil, fmt.Errorf("statement cannot be empty")
+	}
+	request, err := g.BuildRequest(ctx, http.MethodPost, map[string]string{queryField: statement}, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*Query Runs SQL statement and returns response in given format. If format is empty,
+plugin's default format, jdbc, is used.
+It calls http request: POST _plugins/_sql?format=<format>
+with body: {"query": "<statement>"}
+Sample Output for csv format:
+account_number,firstname
+1,Amber
+6,Hattie*/
+func (g *gateway) Query(ctx context.Context, statement string, format string) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = baseURL
+	if len(format) > 0 {
+		if err := validateFormat(format); err != nil {
+			return nil, err
+		}
+		endpoint.RawQuery = url.Values{formatQueryParam: []string{format}}.Encode()
+	}
+	return g.call(ctx, endpoint, statement)
+}
+
+// Explain Returns query plan of SQL statement, translated to OpenSearch query DSL.
+// It calls http request: POST _plugins/_sql/_explain
+func (g *gateway) Explain(ctx context.Context, statement string) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = explainURL
+	return g.call(ctx, endpoint, statement)
+}
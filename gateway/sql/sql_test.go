@@ -0,0 +1,71 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package sql
+
+import (
+	"context"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_Query(t *testing.T) {
+	ctx := context.Background()
+	statement := "SELECT firstname FROM accounts WHERE age > 30"
+	body := `{"query":"SELECT firstname FROM accounts WHERE age > 30"}`
+	tests := []struct {
+		name   string
+		format string
+		url    string
+	}{
+		{"default format", "", "http://localhost:9200/_plugins/_sql"},
+		{"json format", JSONFormat, "http://localhost:9200/_plugins/_sql?format=json"},
+		{"csv format", CSVFormat, "http://localhost:9200/_plugins/_sql?format=csv"},
+		{"raw format", RawFormat, "http://localhost:9200/_plugins/_sql?format=raw"},
+		{"jdbc format", JDBCFormat, "http://localhost:9200/_plugins/_sql?format=jdbc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := []byte("firstname\nAmber")
+			actual, err := getTestGateway(t, testutil.NewExpectingClient(t, http.MethodPost, tt.url, body, http.StatusOK, response)).Query(ctx, statement, tt.format)
+			assert.NoError(t, err)
+			assert.EqualValues(t, response, actual)
+		})
+	}
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).Query(ctx, statement, "xml")
+		assert.EqualError(t, err, "invalid format: xml, expected one of: json, csv, raw, jdbc")
+	})
+	t.Run("empty statement", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).Query(ctx, " ", "")
+		assert.EqualError(t, err, "statement cannot be empty")
+	})
+}
+
+func TestGateway_Explain(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"root":{"name":"ProjectOperator","children":[]}}`)
+	testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_sql/_explain", `{"query":"SELECT * FROM accounts"}`, http.StatusOK, response)
+	actual, err := getTestGateway(t, testClient).Explain(ctx, "SELECT * FROM accounts")
+	assert.NoError(t, err)
+	assert.EqualValues(t, response, actual)
+}
@@ -0,0 +1,66 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+//ErrBodyNotReplayable is returned if request with streamed body has to be sent again after its body was read
+var ErrBodyNotReplayable = errors.New("request body is streamed and cannot be sent again")
+
+type streamedBodyKey struct{}
+
+//onceReader remembers whether reading of underlying reader started, to detect attempts to send body again
+type onceReader struct {
+	reader  io.Reader
+	started bool
+}
+
+func (o *onceReader) Read(p []byte) (int, error) {
+	o.started = true
+	return o.reader.Read(p)
+}
+
+//isStreamed returns true if request body is read from stream, such requests are not retried and
+//their body is not logged
+func isStreamed(ctx context.Context) bool {
+	streamed, _ := ctx.Value(streamedBodyKey{}).(bool)
+	return streamed
+}
+
+//BuildStreamRequest builds request whose body is read from body while request is sent, instead of
+//buffering whole body in memory. Since body can be read only once, request is neither retried nor
+//sent to other endpoints of the profile. Body is buffered anyway if profile uses AWS IAM, since
+//signature of request covers its body. Streamed body is not compressed even if request compression is enabled
+func (g *HTTPGateway) BuildStreamRequest(ctx context.Context, method string, body io.Reader, url string, headers map[string]string) (*retryablehttp.Request, error) {
+	if g.Profile.AWS != nil {
+		payload, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		return g.BuildCurlRequest(ctx, method, payload, url, headers)
+	}
+	reader := &onceReader{reader: body}
+	bodyReader := retryablehttp.ReaderFunc(func() (io.Reader, error) {
+		if reader.started {
+			return nil, ErrBodyNotReplayable
+		}
+		return reader, nil
+	})
+	return g.newRequest(context.WithValue(ctx, streamedBodyKey{}, true), method, bodyReader, url, headers)
+}
@@ -0,0 +1,132 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//countingReader counts how many bytes were read from reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+func TestGatewayBuildStreamRequest(t *testing.T) {
+	getServer := func(status int) (*httptest.Server, *int32, *bytes.Buffer) {
+		var attempts int32
+		var received bytes.Buffer
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			_, _ = io.Copy(&received, r.Body)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("done"))
+		}))
+		return server, &attempts, &received
+	}
+	getGateway := func(t *testing.T, endpoint string, maxRetry int) *HTTPGateway {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: endpoint,
+			MaxRetry: &maxRetry,
+		})
+		assert.NoError(t, err)
+		return g
+	}
+	t.Run("body is streamed", func(t *testing.T) {
+		server, attempts, received := getServer(http.StatusOK)
+		defer server.Close()
+		g := getGateway(t, server.URL, 0)
+		body := &countingReader{reader: strings.NewReader(strings.Repeat("line\n", 10000))}
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, body, server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, body.count, "body must not be read before request is sent")
+		response, err := g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "done", string(response))
+		assert.EqualValues(t, 50000, body.count)
+		assert.EqualValues(t, strings.Repeat("line\n", 10000), received.String())
+		assert.EqualValues(t, 1, atomic.LoadInt32(attempts))
+	})
+	t.Run("request is not retried", func(t *testing.T) {
+		server, attempts, _ := getServer(http.StatusInternalServerError)
+		defer server.Close()
+		g := getGateway(t, server.URL, 3)
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, strings.NewReader("body"), server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.EqualError(t, err, "done")
+		assert.EqualValues(t, 1, atomic.LoadInt32(attempts))
+	})
+	t.Run("request cannot be sent again", func(t *testing.T) {
+		server, _, _ := getServer(http.StatusOK)
+		defer server.Close()
+		g := getGateway(t, server.URL, 0)
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, strings.NewReader("body"), server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.True(t, errors.Is(err, ErrBodyNotReplayable))
+	})
+	t.Run("streamed body is not logged", func(t *testing.T) {
+		server, _, received := getServer(http.StatusOK)
+		defer server.Close()
+		g := getGateway(t, server.URL, 0)
+		var debug bytes.Buffer
+		g.Client.Debug = &debug
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, strings.NewReader(`{"password":"secret"}`), server.URL, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+		assert.Contains(t, debug.String(), "> [STREAMED BODY]\n")
+		assert.NotContains(t, debug.String(), "secret")
+		assert.EqualValues(t, `{"password":"secret"}`, received.String())
+	})
+	t.Run("body is buffered for aws profile", func(t *testing.T) {
+		testClient, err := client.New(nil)
+		assert.NoError(t, err)
+		g, err := NewHTTPGateway(testClient, &entity.Profile{
+			Endpoint: "http://localhost:9200",
+			AWS:      &entity.AWSIAM{ProfileName: "test", ServiceName: "es"},
+		})
+		assert.NoError(t, err)
+		req, err := g.BuildStreamRequest(context.Background(), http.MethodPost, strings.NewReader("body"), "http://localhost:9200", GetDefaultHeaders())
+		assert.NoError(t, err)
+		assert.False(t, isStreamed(req.Context()))
+		first, err := req.BodyBytes()
+		assert.NoError(t, err)
+		second, err := req.BodyBytes()
+		assert.NoError(t, err)
+		assert.EqualValues(t, "body", string(first))
+		assert.EqualValues(t, first, second)
+	})
+}
@@ -0,0 +1,150 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+	"strings"
+)
+
+const (
+	tasksURL              = "_tasks"
+	taskURLTemplate       = tasksURL + "/%s"
+	cancelURLTemplate     = taskURLTemplate + "/_cancel"
+	actionsQueryParamName = "actions"
+	nodesQueryParamName   = "nodes"
+	emptyIDErrorMessage   = "task Id cannot be empty"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_tasks.go -package=mocks . Gateway
+
+// Gateway interface to tasks API of OpenSearch
+type Gateway interface {
+	ListTasks(ctx context.Context, actions []string, nodes []string) ([]byte, error)
+	GetTask(ctx context.Context, ID string) ([]byte, error)
+	CancelTask(ctx context.Context, ID string) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildTaskURL builds url from template for given task ID. ID is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildTaskURL(template string, ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, errors.New(emptyIDErrorMessage)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, ID)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(ID))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, "", endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*ListTasks Returns tasks currently running on nodes of the cluster. Tasks are filtered by actions, which can
+contain wildcards like "*reindex", and by node ids, if they are not empty.
+It calls http request: GET _tasks?actions=<action1>,<action2>&nodes=<node1>,<node2>
+Sample Output:
+{
+  "nodes": {
+    "Mgqdm0r9SEGClWxp_RbnaQ": {
+      "name": "opensearch-node1",
+      "tasks": {
+        "Mgqdm0r9SEGClWxp_RbnaQ:17416": {
+          "node": "Mgqdm0r9SEGClWxp_RbnaQ",
+          "id": 17416,
+          "type": "transport",
+          "action": "indices:data/write/reindex",
+          "start_time_in_millis": 1613075432171,
+          "running_time_in_nanos": 172134950,
+          "cancellable": true
+        }
+      }
+    }
+  }
+}*/
+func (g *gateway) ListTasks(ctx context.Context, actions []string, nodes []string) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = tasksURL
+	query := url.Values{}
+	if len(actions) > 0 {
+		query.Set(actionsQueryParamName, strings.Join(actions, ","))
+	}
+	if len(nodes) > 0 {
+		query.Set(nodesQueryParamName, strings.Join(nodes, ","))
+	}
+	endpoint.RawQuery = query.Encode()
+	return g.call(ctx, http.MethodGet, endpoint)
+}
+
+// GetTask Returns status of task with given ID, and its response once completed.
+// It calls http request: GET _tasks/<task_id>
+func (g *gateway) GetTask(ctx context.Context, ID string) ([]byte, error) {
+	taskURL, err := g.buildTaskURL(taskURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, taskURL)
+}
+
+/*CancelTask Cancels task with given ID, if task is cancellable.
+It calls http request: POST _tasks/<task_id>/_cancel
+Sample Output:
+{
+  "nodes": {
+    "Mgqdm0r9SEGClWxp_RbnaQ": {
+      "tasks": {
+        "Mgqdm0r9SEGClWxp_RbnaQ:17416": {
+          "action": "indices:data/write/reindex",
+          "cancellable": true
+        }
+      }
+    }
+  }
+}*/
+func (g *gateway) CancelTask(ctx context.Context, ID string) ([]byte, error) {
+	cancelURL, err := g.buildTaskURL(cancelURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPost, cancelURL)
+}
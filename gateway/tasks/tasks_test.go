@@ -0,0 +1,85 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package tasks
+
+import (
+	"context"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_ListTasks(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		actions []string
+		nodes   []string
+		url     string
+	}{
+		{"without filters", nil, nil, "http://localhost:9200/_tasks"},
+		{"filtered by actions", []string{"*reindex", "*byquery"}, nil, "http://localhost:9200/_tasks?actions=%2Areindex%2C%2Abyquery"},
+		{"filtered by nodes", nil, []string{"node1"}, "http://localhost:9200/_tasks?nodes=node1"},
+		{"filtered by actions and nodes", []string{"*reindex"}, []string{"node1", "node2"}, "http://localhost:9200/_tasks?actions=%2Areindex&nodes=node1%2Cnode2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := testutil.NewExpectingClient(t, http.MethodGet, tt.url, "", 200, []byte(`{"nodes":{}}`))
+			actual, err := getTestGateway(t, testClient).ListTasks(ctx, tt.actions, tt.nodes)
+			assert.NoError(t, err)
+			assert.EqualValues(t, `{"nodes":{}}`, string(actual))
+		})
+	}
+}
+
+func TestGateway_CancelTask(t *testing.T) {
+	ctx := context.Background()
+	t.Run("cancel task", func(t *testing.T) {
+		response := []byte(`{"nodes":{"node1":{"tasks":{"node1:17416":{"action":"indices:data/write/reindex","cancellable":true}}}}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_tasks/node1:17416/_cancel", "", 200, response)
+		actual, err := getTestGateway(t, testClient).CancelTask(ctx, "node1:17416")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("task id is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_tasks/node1:17416%2F..%3F/_cancel", "", 200, []byte(`{}`))
+		_, err := getTestGateway(t, testClient).CancelTask(ctx, "node1:17416/..?")
+		assert.NoError(t, err)
+	})
+	t.Run("cancel unknown task", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_tasks/node1:1/_cancel", "", 404, []byte("task [node1:1] is not found"))
+		_, err := getTestGateway(t, testClient).CancelTask(ctx, "node1:1")
+		assert.EqualError(t, err, "task [node1:1] is not found")
+	})
+	t.Run("empty task id", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).CancelTask(ctx, "")
+		assert.EqualError(t, err, "task Id cannot be empty")
+	})
+}
+
+func TestGateway_GetTask(t *testing.T) {
+	response := []byte(`{"completed":false,"task":{"node":"node1","id":17416}}`)
+	testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_tasks/node1:17416", "", 200, response)
+	actual, err := getTestGateway(t, testClient).GetTask(context.Background(), "node1:17416")
+	assert.NoError(t, err)
+	assert.EqualValues(t, response, actual)
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: opensearch-cli/gateway/template (interfaces: Gateway)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGateway is a mock of Gateway interface
+type MockGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockGatewayMockRecorder
+}
+
+// MockGatewayMockRecorder is the mock recorder for MockGateway
+type MockGatewayMockRecorder struct {
+	mock *MockGateway
+}
+
+// NewMockGateway creates a new mock instance
+func NewMockGateway(ctrl *gomock.Controller) *MockGateway {
+	mock := &MockGateway{ctrl: ctrl}
+	mock.recorder = &MockGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
+	return m.recorder
+}
+
+// DeleteComponentTemplate mocks base method
+func (m *MockGateway) DeleteComponentTemplate(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComponentTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComponentTemplate indicates an expected call of DeleteComponentTemplate
+func (mr *MockGatewayMockRecorder) DeleteComponentTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComponentTemplate", reflect.TypeOf((*MockGateway)(nil).DeleteComponentTemplate), arg0, arg1)
+}
+
+// DeleteIndexTemplate mocks base method
+func (m *MockGateway) DeleteIndexTemplate(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIndexTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIndexTemplate indicates an expected call of DeleteIndexTemplate
+func (mr *MockGatewayMockRecorder) DeleteIndexTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndexTemplate", reflect.TypeOf((*MockGateway)(nil).DeleteIndexTemplate), arg0, arg1)
+}
+
+// DeleteLegacyTemplate mocks base method
+func (m *MockGateway) DeleteLegacyTemplate(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLegacyTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLegacyTemplate indicates an expected call of DeleteLegacyTemplate
+func (mr *MockGatewayMockRecorder) DeleteLegacyTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLegacyTemplate", reflect.TypeOf((*MockGateway)(nil).DeleteLegacyTemplate), arg0, arg1)
+}
+
+// GetComponentTemplate mocks base method
+func (m *MockGateway) GetComponentTemplate(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentTemplate", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponentTemplate indicates an expected call of GetComponentTemplate
+func (mr *MockGatewayMockRecorder) GetComponentTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentTemplate", reflect.TypeOf((*MockGateway)(nil).GetComponentTemplate), arg0, arg1)
+}
+
+// GetIndexTemplate mocks base method
+func (m *MockGateway) GetIndexTemplate(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexTemplate", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexTemplate indicates an expected call of GetIndexTemplate
+func (mr *MockGatewayMockRecorder) GetIndexTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexTemplate", reflect.TypeOf((*MockGateway)(nil).GetIndexTemplate), arg0, arg1)
+}
+
+// GetLegacyTemplate mocks base method
+func (m *MockGateway) GetLegacyTemplate(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegacyTemplate", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegacyTemplate indicates an expected call of GetLegacyTemplate
+func (mr *MockGatewayMockRecorder) GetLegacyTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegacyTemplate", reflect.TypeOf((*MockGateway)(nil).GetLegacyTemplate), arg0, arg1)
+}
+
+// PutComponentTemplate mocks base method
+func (m *MockGateway) PutComponentTemplate(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutComponentTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutComponentTemplate indicates an expected call of PutComponentTemplate
+func (mr *MockGatewayMockRecorder) PutComponentTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutComponentTemplate", reflect.TypeOf((*MockGateway)(nil).PutComponentTemplate), arg0, arg1, arg2)
+}
+
+// PutIndexTemplate mocks base method
+func (m *MockGateway) PutIndexTemplate(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutIndexTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutIndexTemplate indicates an expected call of PutIndexTemplate
+func (mr *MockGatewayMockRecorder) PutIndexTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutIndexTemplate", reflect.TypeOf((*MockGateway)(nil).PutIndexTemplate), arg0, arg1, arg2)
+}
+
+// PutLegacyTemplate mocks base method
+func (m *MockGateway) PutLegacyTemplate(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutLegacyTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutLegacyTemplate indicates an expected call of PutLegacyTemplate
+func (mr *MockGatewayMockRecorder) PutLegacyTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLegacyTemplate", reflect.TypeOf((*MockGateway)(nil).PutLegacyTemplate), arg0, arg1, arg2)
+}
@@ -0,0 +1,160 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	indexTemplateURLTemplate     = "_index_template/%s"
+	legacyTemplateURLTemplate    = "_template/%s"
+	componentTemplateURLTemplate = "_component_template/%s"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_template.go -package=mocks . Gateway
+
+// Gateway interface to index template APIs of OpenSearch
+type Gateway interface {
+	PutIndexTemplate(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	GetIndexTemplate(ctx context.Context, name string) ([]byte, error)
+	DeleteIndexTemplate(ctx context.Context, name string) error
+	PutLegacyTemplate(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	GetLegacyTemplate(ctx context.Context, name string) ([]byte, error)
+	DeleteLegacyTemplate(ctx context.Context, name string) error
+	PutComponentTemplate(ctx context.Context, name string, payload interface{}) ([]byte, error)
+	GetComponentTemplate(ctx context.Context, name string) ([]byte, error)
+	DeleteComponentTemplate(ctx context.Context, name string) error
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//call sends request to url built from template for given template name. Name is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) call(ctx context.Context, method string, template string, name string, payload interface{}) ([]byte, error) {
+	if len(name) < 1 {
+		return nil, errors.New("template name cannot be empty")
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, name)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(name))
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.Call(request, http.StatusOK)
+}
+
+/*PutIndexTemplate Creates or replaces composable index template with given name.
+It calls http request: PUT _index_template/<name>
+Sample Input:
+{
+  "index_patterns": ["logs-*"],
+  "composed_of": ["logs-mappings"],
+  "priority": 100,
+  "template": {
+    "settings": {"number_of_shards": 2}
+  }
+}*/
+func (g *gateway) PutIndexTemplate(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, indexTemplateURLTemplate, name, payload)
+}
+
+// GetIndexTemplate Returns composable index templates matching name, which can be a name or a pattern.
+// It calls http request: GET _index_template/<name>
+func (g *gateway) GetIndexTemplate(ctx context.Context, name string) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, indexTemplateURLTemplate, name, "")
+}
+
+// DeleteIndexTemplate Deletes composable index template with given name.
+// It calls http request: DELETE _index_template/<name>
+func (g *gateway) DeleteIndexTemplate(ctx context.Context, name string) error {
+	_, err := g.call(ctx, http.MethodDelete, indexTemplateURLTemplate, name, "")
+	return err
+}
+
+/*PutLegacyTemplate Creates or replaces legacy index template with given name.
+It calls http request: PUT _template/<name>
+Sample Input:
+{
+  "index_patterns": ["logs-*"],
+  "order": 0,
+  "settings": {"number_of_shards": 2},
+  "mappings": {
+    "properties": {"timestamp": {"type": "date"}}
+  }
+}*/
+func (g *gateway) PutLegacyTemplate(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, legacyTemplateURLTemplate, name, payload)
+}
+
+// GetLegacyTemplate Returns legacy index templates matching name, which can be a name or a pattern.
+// It calls http request: GET _template/<name>
+func (g *gateway) GetLegacyTemplate(ctx context.Context, name string) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, legacyTemplateURLTemplate, name, "")
+}
+
+// DeleteLegacyTemplate Deletes legacy index template with given name.
+// It calls http request: DELETE _template/<name>
+func (g *gateway) DeleteLegacyTemplate(ctx context.Context, name string) error {
+	_, err := g.call(ctx, http.MethodDelete, legacyTemplateURLTemplate, name, "")
+	return err
+}
+
+/*PutComponentTemplate Creates or replaces component template with given name, which can be used
+by composable index templates.
+It calls http request: PUT _component_template/<name>
+Sample Input:
+{
+  "template": {
+    "mappings": {
+      "properties": {"timestamp": {"type": "date"}}
+    }
+  }
+}*/
+func (g *gateway) PutComponentTemplate(ctx context.Context, name string, payload interface{}) ([]byte, error) {
+	return g.call(ctx, http.MethodPut, componentTemplateURLTemplate, name, payload)
+}
+
+// GetComponentTemplate Returns component templates matching name, which can be a name or a pattern.
+// It calls http request: GET _component_template/<name>
+func (g *gateway) GetComponentTemplate(ctx context.Context, name string) ([]byte, error) {
+	return g.call(ctx, http.MethodGet, componentTemplateURLTemplate, name, "")
+}
+
+// DeleteComponentTemplate Deletes component template with given name.
+// It calls http request: DELETE _component_template/<name>
+func (g *gateway) DeleteComponentTemplate(ctx context.Context, name string) error {
+	_, err := g.call(ctx, http.MethodDelete, componentTemplateURLTemplate, name, "")
+	return err
+}
@@ -0,0 +1,108 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_PutTemplate(t *testing.T) {
+	ctx := context.Background()
+	composable := `{"index_patterns":["logs-*"],"composed_of":["logs-mappings"],"priority":100,"template":{"settings":{"number_of_shards":2}}}`
+	legacy := `{"index_patterns":["logs-*"],"order":0,"settings":{"number_of_shards":2}}`
+	component := `{"template":{"mappings":{"properties":{"timestamp":{"type":"date"}}}}}`
+	tests := []struct {
+		name    string
+		call    func(Gateway, context.Context, string, interface{}) ([]byte, error)
+		payload string
+		url     string
+	}{
+		{"composable template", Gateway.PutIndexTemplate, composable, "http://localhost:9200/_index_template/logs"},
+		{"legacy template", Gateway.PutLegacyTemplate, legacy, "http://localhost:9200/_template/logs"},
+		{"component template", Gateway.PutComponentTemplate, component, "http://localhost:9200/_component_template/logs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := testutil.NewExpectingClient(t, http.MethodPut, tt.url, tt.payload, 200, []byte(`{"acknowledged":true}`))
+			actual, err := tt.call(getTestGateway(t, testClient), ctx, "logs", json.RawMessage(tt.payload))
+			assert.NoError(t, err)
+			assert.EqualValues(t, `{"acknowledged":true}`, string(actual))
+		})
+	}
+	t.Run("empty template name", func(t *testing.T) {
+		_, err := getTestGateway(t, mocks.NewTestClient(nil)).PutIndexTemplate(ctx, "", json.RawMessage(composable))
+		assert.EqualError(t, err, "template name cannot be empty")
+	})
+}
+
+func TestGateway_GetTemplate(t *testing.T) {
+	ctx := context.Background()
+	t.Run("composable template", func(t *testing.T) {
+		response := []byte(`{"index_templates":[{"name":"logs","index_template":{"index_patterns":["logs-*"]}}]}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_index_template/logs%2A", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetIndexTemplate(ctx, "logs*")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("legacy template", func(t *testing.T) {
+		response := []byte(`{"logs":{"order":0,"index_patterns":["logs-*"]}}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_template/logs", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetLegacyTemplate(ctx, "logs")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("component template", func(t *testing.T) {
+		response := []byte(`{"component_templates":[{"name":"logs-mappings","component_template":{"template":{}}}]}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_component_template/logs-mappings", "", 200, response)
+		actual, err := getTestGateway(t, testClient).GetComponentTemplate(ctx, "logs-mappings")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("unknown composable template", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_index_template/unknown", "", 404, []byte("index template matching [unknown] not found"))
+		_, err := getTestGateway(t, testClient).GetIndexTemplate(ctx, "unknown")
+		assert.EqualError(t, err, "index template matching [unknown] not found")
+	})
+}
+
+func TestGateway_DeleteTemplate(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(Gateway, context.Context, string) error
+		url  string
+	}{
+		{"composable template", Gateway.DeleteIndexTemplate, "http://localhost:9200/_index_template/logs"},
+		{"legacy template", Gateway.DeleteLegacyTemplate, "http://localhost:9200/_template/logs"},
+		{"component template", Gateway.DeleteComponentTemplate, "http://localhost:9200/_component_template/logs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := testutil.NewExpectingClient(t, http.MethodDelete, tt.url, "", 200, []byte(`{"acknowledged":true}`))
+			assert.NoError(t, tt.call(getTestGateway(t, testClient), ctx, "logs"))
+		})
+	}
+}
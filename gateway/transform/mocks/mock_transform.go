@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: opensearch-cli/gateway/transform (interfaces: Gateway)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGateway is a mock of Gateway interface
+type MockGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockGatewayMockRecorder
+}
+
+// MockGatewayMockRecorder is the mock recorder for MockGateway
+type MockGatewayMockRecorder struct {
+	mock *MockGateway
+}
+
+// NewMockGateway creates a new mock instance
+func NewMockGateway(ctrl *gomock.Controller) *MockGateway {
+	mock := &MockGateway{ctrl: ctrl}
+	mock.recorder = &MockGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGateway) EXPECT() *MockGatewayMockRecorder {
+	return m.recorder
+}
+
+// DeleteTransform mocks base method
+func (m *MockGateway) DeleteTransform(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransform", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransform indicates an expected call of DeleteTransform
+func (mr *MockGatewayMockRecorder) DeleteTransform(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransform", reflect.TypeOf((*MockGateway)(nil).DeleteTransform), arg0, arg1)
+}
+
+// ExplainTransform mocks base method
+func (m *MockGateway) ExplainTransform(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainTransform", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainTransform indicates an expected call of ExplainTransform
+func (mr *MockGatewayMockRecorder) ExplainTransform(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainTransform", reflect.TypeOf((*MockGateway)(nil).ExplainTransform), arg0, arg1)
+}
+
+// GetTransform mocks base method
+func (m *MockGateway) GetTransform(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransform", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransform indicates an expected call of GetTransform
+func (mr *MockGatewayMockRecorder) GetTransform(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransform", reflect.TypeOf((*MockGateway)(nil).GetTransform), arg0, arg1)
+}
+
+// PreviewTransform mocks base method
+func (m *MockGateway) PreviewTransform(arg0 context.Context, arg1 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewTransform", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewTransform indicates an expected call of PreviewTransform
+func (mr *MockGatewayMockRecorder) PreviewTransform(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTransform", reflect.TypeOf((*MockGateway)(nil).PreviewTransform), arg0, arg1)
+}
+
+// PutTransform mocks base method
+func (m *MockGateway) PutTransform(arg0 context.Context, arg1 string, arg2 interface{}) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTransform", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutTransform indicates an expected call of PutTransform
+func (mr *MockGatewayMockRecorder) PutTransform(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTransform", reflect.TypeOf((*MockGateway)(nil).PutTransform), arg0, arg1, arg2)
+}
+
+// StartTransform mocks base method
+func (m *MockGateway) StartTransform(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTransform", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartTransform indicates an expected call of StartTransform
+func (mr *MockGatewayMockRecorder) StartTransform(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTransform", reflect.TypeOf((*MockGateway)(nil).StartTransform), arg0, arg1)
+}
+
+// StopTransform mocks base method
+func (m *MockGateway) StopTransform(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTransform", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopTransform indicates an expected call of StopTransform
+func (mr *MockGatewayMockRecorder) StopTransform(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTransform", reflect.TypeOf((*MockGateway)(nil).StopTransform), arg0, arg1)
+}
@@ -0,0 +1,236 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	gw "opensearch-cli/gateway"
+)
+
+const (
+	baseURL                = "_plugins/_transform"
+	jobURLTemplate         = baseURL + "/%s"
+	startURLTemplate       = jobURLTemplate + "/_start"
+	stopURLTemplate        = jobURLTemplate + "/_stop"
+	explainURLTemplate     = jobURLTemplate + "/_explain"
+	previewURL             = baseURL + "/_preview"
+	emptyIDErrorMessage    = "transform Id cannot be empty"
+	acknowledgedField      = "acknowledged"
+	notAcknowledgedMessage = "request to %s transform %s was not acknowledged"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen  -destination=mocks/mock_transform.go -package=mocks . Gateway
+
+// Gateway interface to Index Transforms Plugin
+type Gateway interface {
+	PutTransform(ctx context.Context, ID string, payload interface{}) ([]byte, error)
+	GetTransform(ctx context.Context, ID string) ([]byte, error)
+	DeleteTransform(ctx context.Context, ID string) error
+	StartTransform(ctx context.Context, ID string) error
+	StopTransform(ctx context.Context, ID string) error
+	ExplainTransform(ctx context.Context, ID string) ([]byte, error)
+	PreviewTransform(ctx context.Context, payload interface{}) ([]byte, error)
+}
+
+type gateway struct {
+	gw.HTTPGateway
+}
+
+// New creates new Gateway instance
+func New(c *client.Client, p *entity.Profile) (Gateway, error) {
+	g, err := gw.NewHTTPGateway(c, p)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway{*g}, nil
+}
+
+//buildJobURL builds url from template for given transform ID. ID is escaped to prevent
+//it from adding path segments or query parameters to the url
+func (g *gateway) buildJobURL(template string, ID string) (*url.URL, error) {
+	if len(ID) < 1 {
+		return nil, errors.New(emptyIDErrorMessage)
+	}
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = fmt.Sprintf(template, ID)
+	endpoint.RawPath = fmt.Sprintf(template, url.PathEscape(ID))
+	return endpoint, nil
+}
+
+func (g *gateway) call(ctx context.Context, method string, endpoint *url.URL, payload interface{}, accepted ...int) ([]byte, error) {
+	request, err := g.BuildRequest(ctx, method, payload, endpoint.String(), gw.GetDefaultHeaders())
+	if err != nil {
+		return nil, err
+	}
+	return g.CallExpecting(request, accepted...)
+}
+
+/*PutTransform Creates transform job with given ID.
+It calls http request: PUT _plugins/_transform/<transform_id>
+Sample Input:
+{
+  "transform": {
+    "enabled": true,
+    "schedule": {
+      "interval": {"period": 1, "unit": "Minutes", "start_time": 1602100553}
+    },
+    "description": "Sample transform job",
+    "source_index": "sample_index",
+    "target_index": "sample_target",
+    "data_selection_query": {"match_all": {}},
+    "page_size": 1,
+    "groups": [{
+      "terms": {"source_field": "customerId", "target_field": "customer"}
+    }],
+    "aggregations": {
+      "quantity": {"sum": {"field": "quantity"}}
+    }
+  }
+}*/
+func (g *gateway) PutTransform(ctx context.Context, ID string, payload interface{}) ([]byte, error) {
+	jobURL, err := g.buildJobURL(jobURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodPut, jobURL, payload, http.StatusOK, http.StatusCreated)
+}
+
+// GetTransform Returns transform job with given ID.
+// It calls http request: GET _plugins/_transform/<transform_id>
+func (g *gateway) GetTransform(ctx context.Context, ID string) ([]byte, error) {
+	jobURL, err := g.buildJobURL(jobURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, jobURL, "", http.StatusOK)
+}
+
+// DeleteTransform Deletes transform job with given ID.
+// It calls http request: DELETE _plugins/_transform/<transform_id>
+func (g *gateway) DeleteTransform(ctx context.Context, ID string) error {
+	jobURL, err := g.buildJobURL(jobURLTemplate, ID)
+	if err != nil {
+		return err
+	}
+	_, err = g.call(ctx, http.MethodDelete, jobURL, "", http.StatusOK)
+	return err
+}
+
+// StartTransform Starts transform job with given ID.
+// It calls http request: POST _plugins/_transform/<transform_id>/_start
+func (g *gateway) StartTransform(ctx context.Context, ID string) error {
+	return g.changeState(ctx, startURLTemplate, "start", ID)
+}
+
+// StopTransform Stops transform job with given ID.
+// It calls http request: POST _plugins/_transform/<transform_id>/_stop
+func (g *gateway) StopTransform(ctx context.Context, ID string) error {
+	return g.changeState(ctx, stopURLTemplate, "stop", ID)
+}
+
+//changeState starts or stops transform job, plugin replies {"acknowledged": true} if state was changed
+func (g *gateway) changeState(ctx context.Context, template string, action string, ID string) error {
+	stateURL, err := g.buildJobURL(template, ID)
+	if err != nil {
+		return err
+	}
+	response, err := g.call(ctx, http.MethodPost, stateURL, "", http.StatusOK)
+	if err != nil {
+		return err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return err
+	}
+	if acknowledged, ok := result[acknowledgedField].(bool); !ok || !acknowledged {
+		return fmt.Errorf(notAcknowledgedMessage, action, ID)
+	}
+	return nil
+}
+
+/*ExplainTransform Returns metadata and status of transform job with given ID.
+It calls http request: GET _plugins/_transform/<transform_id>/_explain
+Sample Output:
+{
+  "sample": {
+    "metadata_id": "PulK8Ak_YN2Gz2-l7X4Fhg",
+    "transform_metadata": {
+      "transform_id": "sample",
+      "last_updated_at": 1621883525873,
+      "status": "finished",
+      "failure_reason": null,
+      "stats": {
+        "pages_processed": 1,
+        "documents_processed": 155,
+        "documents_indexed": 14,
+        "index_time_in_millis": 32,
+        "search_time_in_millis": 2
+      }
+    }
+  }
+}*/
+func (g *gateway) ExplainTransform(ctx context.Context, ID string) ([]byte, error) {
+	explainURL, err := g.buildJobURL(explainURLTemplate, ID)
+	if err != nil {
+		return nil, err
+	}
+	return g.call(ctx, http.MethodGet, explainURL, "", http.StatusOK)
+}
+
+/*PreviewTransform Returns documents which transform job with given inline configuration would index into
+target index, without creating the job.
+It calls http request: POST _plugins/_transform/_preview
+Sample Input:
+{
+  "transform": {
+    "enabled": false,
+    "schedule": {
+      "interval": {"period": 1, "unit": "Minutes", "start_time": 1602100553}
+    },
+    "description": "test transform",
+    "source_index": "sample_index",
+    "target_index": "sample_target",
+    "data_selection_query": {"match_all": {}},
+    "page_size": 10,
+    "groups": [{
+      "terms": {"source_field": "customerId", "target_field": "customer"}
+    }],
+    "aggregations": {
+      "quantity": {"sum": {"field": "quantity"}}
+    }
+  }
+}
+Sample Output:
+{
+  "documents": [
+    {"quantity": 900.0, "customer": "AnyCustomer"},
+    {"quantity": 12.0, "customer": "OtherCustomer"}
+  ]
+}*/
+func (g *gateway) PreviewTransform(ctx context.Context, payload interface{}) ([]byte, error) {
+	endpoint, err := gw.GetValidEndpoint(g.Profile)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path = previewURL
+	return g.call(ctx, http.MethodPost, endpoint, payload, http.StatusOK)
+}
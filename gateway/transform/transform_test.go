@@ -0,0 +1,107 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/internal/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestGateway(t *testing.T, c *client.Client) Gateway {
+	g, err := New(c, testutil.NewTestProfile())
+	assert.NoError(t, err)
+	return g
+}
+
+func TestGateway_StartStopTransform(t *testing.T) {
+	ctx := context.Background()
+	t.Run("start transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_transform/sample/_start", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).StartTransform(ctx, "sample"))
+	})
+	t.Run("start transform not acknowledged", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_transform/sample/_start", "", 200, []byte(`{"acknowledged":false}`))
+		assert.EqualError(t, getTestGateway(t, testClient).StartTransform(ctx, "sample"), "request to start transform sample was not acknowledged")
+	})
+	t.Run("stop transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_transform/sample/_stop", "", 200, []byte(`{"acknowledged":true}`))
+		assert.NoError(t, getTestGateway(t, testClient).StopTransform(ctx, "sample"))
+	})
+	t.Run("stop unknown transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_transform/unknown/_stop", "", 404, []byte("not found"))
+		assert.EqualError(t, getTestGateway(t, testClient).StopTransform(ctx, "unknown"), "not found")
+	})
+	t.Run("stop transform with empty id", func(t *testing.T) {
+		assert.EqualError(t, getTestGateway(t, mocks.NewTestClient(nil)).StopTransform(ctx, ""), "transform Id cannot be empty")
+	})
+}
+
+func TestGateway_ExplainTransform(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"sample":{"metadata_id":"PulK8Ak_YN2Gz2-l7X4Fhg","transform_metadata":{"transform_id":"sample","status":"finished"}}}`)
+	t.Run("explain transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_transform/sample/_explain", "", 200, response)
+		actual, err := getTestGateway(t, testClient).ExplainTransform(ctx, "sample")
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("transform id is escaped", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_transform/sample%2F_start%3F/_explain", "", 200, response)
+		_, err := getTestGateway(t, testClient).ExplainTransform(ctx, "sample/_start?")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGateway_Transform(t *testing.T) {
+	ctx := context.Background()
+	transform := `{"transform":{"enabled":true,"source_index":"sample_index","target_index":"sample_target"}}`
+	t.Run("put transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPut, "http://localhost:9200/_plugins/_transform/sample", transform, 201, []byte(`{"_id":"sample"}`))
+		actual, err := getTestGateway(t, testClient).PutTransform(ctx, "sample", json.RawMessage(transform))
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"_id":"sample"}`, string(actual))
+	})
+	t.Run("get transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodGet, "http://localhost:9200/_plugins/_transform/sample", "", 200, []byte(transform))
+		actual, err := getTestGateway(t, testClient).GetTransform(ctx, "sample")
+		assert.NoError(t, err)
+		assert.EqualValues(t, transform, string(actual))
+	})
+	t.Run("delete transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodDelete, "http://localhost:9200/_plugins/_transform/sample", "", 200, []byte(`{}`))
+		assert.NoError(t, getTestGateway(t, testClient).DeleteTransform(ctx, "sample"))
+	})
+}
+
+func TestGateway_PreviewTransform(t *testing.T) {
+	ctx := context.Background()
+	config := `{"transform":{"enabled":false,"source_index":"sample_index","target_index":"sample_target","page_size":10,"groups":[{"terms":{"source_field":"customerId","target_field":"customer"}}],"aggregations":{"quantity":{"sum":{"field":"quantity"}}}}}`
+	t.Run("preview transform", func(t *testing.T) {
+		response := []byte(`{"documents":[{"quantity":900.0,"customer":"AnyCustomer"}]}`)
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_transform/_preview", config, 200, response)
+		actual, err := getTestGateway(t, testClient).PreviewTransform(ctx, json.RawMessage(config))
+		assert.NoError(t, err)
+		assert.EqualValues(t, response, actual)
+	})
+	t.Run("preview invalid transform", func(t *testing.T) {
+		testClient := testutil.NewExpectingClient(t, http.MethodPost, "http://localhost:9200/_plugins/_transform/_preview", config, 400, []byte("source index does not exist"))
+		_, err := getTestGateway(t, testClient).PreviewTransform(ctx, json.RawMessage(config))
+		assert.EqualError(t, err, "source index does not exist")
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"opensearch-cli/logger"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const warningHeader = "Warning"
+
+//loggedWarnings remembers warnings which are already logged, so that warning of deprecated API called
+//for many detectors is logged only once
+var loggedWarnings sync.Map
+
+//logWarnings logs Warning headers of response, which OpenSearch sends if deprecated API is called
+func logWarnings(response *http.Response) {
+	for _, value := range response.Header.Values(warningHeader) {
+		text := parseWarning(value)
+		if _, logged := loggedWarnings.LoadOrStore(text, true); logged {
+			continue
+		}
+		logger.Warnf("%s", text)
+	}
+}
+
+//parseWarning returns text of warning from Warning header value, which is formatted as
+//<code> <agent> "<text>" ["<date>"]. Value is returned as it is if it doesn't have quoted text
+func parseWarning(value string) string {
+	start := strings.Index(value, `"`)
+	if start < 0 {
+		return value
+	}
+	for end := start + 1; end < len(value); end++ {
+		switch value[end] {
+		case '\\':
+			end++
+		case '"':
+			if text, err := strconv.Unquote(value[start : end+1]); err == nil {
+				return text
+			}
+			return value[start+1 : end]
+		}
+	}
+	return value
+}
@@ -0,0 +1,83 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/client"
+	"opensearch-cli/entity"
+	"opensearch-cli/logger"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWarning(t *testing.T) {
+	assert.Equal(t, "[types removal] Specifying types in search requests is deprecated.",
+		parseWarning(`299 OpenSearch-1.0.0 "[types removal] Specifying types in search requests is deprecated."`))
+	assert.Equal(t, `index "old" is deprecated`,
+		parseWarning(`299 OpenSearch-1.0.0 "index \"old\" is deprecated" "Mon, 01 Jan 2021 00:00:00 GMT"`))
+	assert.Equal(t, "deprecated", parseWarning("deprecated"))
+}
+
+func TestGatewayWarningHeader(t *testing.T) {
+	var output bytes.Buffer
+	logger.SetOutput(&output)
+	defer logger.SetOutput(os.Stderr)
+	defer logger.SetLevel(logger.GetLevel())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(warningHeader, `299 OpenSearch-1.0.0 "`+r.URL.Path+` is deprecated"`)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	testClient, err := client.New(nil)
+	assert.NoError(t, err)
+	g, err := NewHTTPGateway(testClient, &entity.Profile{Endpoint: server.URL})
+	assert.NoError(t, err)
+	call := func(path string) {
+		req, err := g.BuildRequest(context.Background(), http.MethodGet, "", server.URL+path, GetDefaultHeaders())
+		assert.NoError(t, err)
+		_, err = g.Call(req, http.StatusOK)
+		assert.NoError(t, err)
+	}
+	t.Run("warning is logged once", func(t *testing.T) {
+		output.Reset()
+		logger.SetLevel(logger.NormalLevel)
+		call("/_opendistro/_ism/policies")
+		call("/_opendistro/_ism/policies")
+		assert.Equal(t, "Warning: /_opendistro/_ism/policies is deprecated\n", output.String())
+	})
+	t.Run("warning is not logged if quiet", func(t *testing.T) {
+		output.Reset()
+		logger.SetLevel(logger.QuietLevel)
+		call("/_opendistro/_alerting/monitors")
+		assert.Empty(t, output.String())
+	})
+}
@@ -8,9 +8,9 @@ require (
 	github.com/golang/mock v1.4.4
 	github.com/hashicorp/go-retryablehttp v0.6.7
 	github.com/spf13/cobra v1.1.1
+	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.6.1
 	golang.org/x/term v0.0.0-20201210144234-2321bbc49cbf
 	gopkg.in/yaml.v2 v2.2.8
 	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776
-
 )
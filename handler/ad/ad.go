@@ -29,11 +29,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"opensearch-cli/controller/ad"
 	entity "opensearch-cli/entity/ad"
+	"opensearch-cli/logger"
 	"opensearch-cli/mapper"
 	"os"
+	"strings"
+)
+
+const (
+	//FileNameIdentifier is prefix of argument which refers to file that contains payload
+	FileNameIdentifier = "@"
+	//StdinIdentifier is argument which refers to payload from standard input
+	StdinIdentifier = "-"
 )
 
 //Handler is facade for controller
@@ -75,12 +85,23 @@ func GenerateAnomalyDetector() ([]byte, error) {
 	}, "", "  ")
 }
 
-//CreateAnomalyDetector creates detector based on file configurations
-func (h *Handler) CreateAnomalyDetector(fileName string) error {
+//ReadPayload reads payload from standard input if arg is "-", else, from file whose name is arg,
+//with or without "@" prefix, and unmarshals it into v
+func ReadPayload(arg string, stdin io.Reader, v interface{}) error {
+	if arg == StdinIdentifier {
+		byteValue, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read from stdin due to %v", err)
+		}
+		if err = json.Unmarshal(byteValue, v); err != nil {
+			return fmt.Errorf("stdin cannot be accepted due to %v", err)
+		}
+		return nil
+	}
+	fileName := strings.TrimPrefix(arg, FileNameIdentifier)
 	if len(fileName) < 1 {
 		return fmt.Errorf("file name cannot be empty")
 	}
-
 	jsonFile, err := os.Open(fileName)
 	if err != nil {
 		return fmt.Errorf("failed to open file %s due to %v", fileName, err)
@@ -91,20 +112,29 @@ func (h *Handler) CreateAnomalyDetector(fileName string) error {
 			fmt.Println("failed to close json:", err)
 		}
 	}()
-	byteValue, _ := ioutil.ReadAll(jsonFile)
-	var request entity.CreateDetectorRequest
-	err = json.Unmarshal(byteValue, &request)
+	byteValue, err := ioutil.ReadAll(jsonFile)
 	if err != nil {
+		return fmt.Errorf("failed to read file %s due to %v", fileName, err)
+	}
+	if err = json.Unmarshal(byteValue, v); err != nil {
 		return fmt.Errorf("file %s cannot be accepted due to %v", fileName, err)
 	}
+	return nil
+}
+
+//CreateAnomalyDetector creates detector based on file configurations
+func (h *Handler) CreateAnomalyDetector(fileName string) error {
+	var request entity.CreateDetectorRequest
+	if err := ReadPayload(fileName, os.Stdin, &request); err != nil {
+		return err
+	}
 	ctx := context.Background()
 	names, err := h.CreateMultiEntityAnomalyDetector(ctx, request, true, true)
 	if err != nil {
 		return err
 	}
 	if len(names) > 0 {
-		fmt.Printf("Successfully created %d detector(s)", len(names))
-		fmt.Println()
+		logger.Infof("Successfully created %d detector(s)", len(names))
 		return nil
 	}
 	return err
@@ -238,34 +268,24 @@ func (h *Handler) GetAnomalyDetectorByID(name string) (*entity.DetectorOutput, e
 	return detector, nil
 }
 
+// GetAnomalyDetectorState gets detector's current state based on detector id
+func (h *Handler) GetAnomalyDetectorState(ID string) (string, error) {
+	ctx := context.Background()
+	return h.GetDetectorState(ctx, ID)
+}
+
 //UpdateDetector updates detector based on file configurations
 func (h *Handler) UpdateDetector(fileName string, force bool, start bool) error {
-	if len(fileName) < 1 {
-		return fmt.Errorf("file name cannot be empty")
-	}
-
-	jsonFile, err := os.Open(fileName)
-	if err != nil {
-		return fmt.Errorf("failed to open file %s due to %v", fileName, err)
-	}
-	defer func() {
-		err := jsonFile.Close()
-		if err != nil {
-			fmt.Println("failed close json file due to ", err)
-		}
-	}()
-	byteValue, _ := ioutil.ReadAll(jsonFile)
 	var request entity.UpdateDetectorUserInput
-	err = json.Unmarshal(byteValue, &request)
-	if err != nil {
-		return fmt.Errorf("file %s cannot be accepted due to %v", fileName, err)
+	if err := ReadPayload(fileName, os.Stdin, &request); err != nil {
+		return err
 	}
 	ctx := context.Background()
-	err = h.Controller.UpdateDetector(ctx, request, force, start)
+	err := h.Controller.UpdateDetector(ctx, request, force, start)
 	if err != nil {
 		return err
 	}
-	fmt.Println("Successfully updated detector.")
+	logger.Infof("Successfully updated detector.")
 	return nil
 }
 
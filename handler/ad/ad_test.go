@@ -32,6 +32,8 @@ import (
 	"opensearch-cli/controller/ad/mocks"
 	"opensearch-cli/entity/ad"
 	"opensearch-cli/mapper"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -78,6 +80,13 @@ func TestHandlerCreateAnomalyDetector(t *testing.T) {
 		err := CreateAnomalyDetector(instance, "testdata/create.json")
 		assert.NoError(t, err)
 	})
+	t.Run("test create success with file prefix", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().CreateMultiEntityAnomalyDetector(ctx, getCreateDetectorRequest(), true, true).Return([]string{"test-detector-ecommerce0-one"}, nil)
+		instance := New(mockedController)
+		err := CreateAnomalyDetector(instance, "@testdata/create.json")
+		assert.NoError(t, err)
+	})
 	t.Run("test create failure", func(t *testing.T) {
 		mockedController := mocks.NewMockController(mockCtrl)
 		mockedController.EXPECT().CreateMultiEntityAnomalyDetector(ctx, getCreateDetectorRequest(), true, true).Return(nil, errors.New("failed to create"))
@@ -104,6 +113,50 @@ func TestHandlerCreateAnomalyDetector(t *testing.T) {
 		assert.EqualError(t, err, "file testdata/invalid.txt cannot be accepted due to invalid character 'i' looking for beginning of value")
 	})
 }
+func TestReadPayload(t *testing.T) {
+	t.Run("read from file with prefix", func(t *testing.T) {
+		var request ad.CreateDetectorRequest
+		err := ReadPayload("@testdata/create.json", nil, &request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, getCreateDetectorRequest(), request)
+	})
+	t.Run("read from file without prefix", func(t *testing.T) {
+		var request ad.CreateDetectorRequest
+		err := ReadPayload("testdata/create.json", nil, &request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, getCreateDetectorRequest(), request)
+	})
+	t.Run("read from stdin", func(t *testing.T) {
+		file, err := os.Open("testdata/create.json")
+		assert.NoError(t, err)
+		defer file.Close()
+		var request ad.CreateDetectorRequest
+		err = ReadPayload("-", file, &request)
+		assert.NoError(t, err)
+		assert.EqualValues(t, getCreateDetectorRequest(), request)
+	})
+	t.Run("invalid json from stdin", func(t *testing.T) {
+		var request ad.CreateDetectorRequest
+		err := ReadPayload("-", strings.NewReader("invalid"), &request)
+		assert.EqualError(t, err, "stdin cannot be accepted due to invalid character 'i' looking for beginning of value")
+	})
+	t.Run("missing file", func(t *testing.T) {
+		var request ad.CreateDetectorRequest
+		err := ReadPayload("@testdata/create1.json", nil, &request)
+		assert.EqualError(t, err, "failed to open file testdata/create1.json due to open testdata/create1.json: no such file or directory")
+	})
+	t.Run("empty file name", func(t *testing.T) {
+		var request ad.CreateDetectorRequest
+		err := ReadPayload("@", nil, &request)
+		assert.EqualError(t, err, "file name cannot be empty")
+	})
+	t.Run("invalid json from file", func(t *testing.T) {
+		var request ad.CreateDetectorRequest
+		err := ReadPayload("@testdata/invalid.txt", nil, &request)
+		assert.EqualError(t, err, "file testdata/invalid.txt cannot be accepted due to invalid character 'i' looking for beginning of value")
+	})
+}
+
 func TestHandlerDeleteAnomalyDetector(t *testing.T) {
 	ctx := context.Background()
 	mockCtrl := gomock.NewController(t)
@@ -364,3 +417,24 @@ func TestHandlerUpdateDetector(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestHandlerGetAnomalyDetectorState(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	t.Run("test get state success", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetDetectorState(ctx, "detectorID").Return("RUNNING", nil)
+		instance := New(mockedController)
+		state, err := instance.GetAnomalyDetectorState("detectorID")
+		assert.NoError(t, err)
+		assert.Equal(t, "RUNNING", state)
+	})
+	t.Run("test get state failure", func(t *testing.T) {
+		mockedController := mocks.NewMockController(mockCtrl)
+		mockedController.EXPECT().GetDetectorState(ctx, "detectorID").Return("", errors.New("failed to get state"))
+		instance := New(mockedController)
+		_, err := instance.GetAnomalyDetectorState("detectorID")
+		assert.EqualError(t, err, "failed to get state")
+	})
+}
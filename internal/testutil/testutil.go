@@ -0,0 +1,55 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+//Package testutil provides helpers shared by tests of gateways, it must be imported only from _test.go files
+package testutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"opensearch-cli/client"
+	"opensearch-cli/client/mocks"
+	"opensearch-cli/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//NewExpectingClient returns client which asserts that request has given method and url, and json body unless
+//body is empty, and responds with given status code and response
+func NewExpectingClient(t *testing.T, method string, url string, body string, code int, response []byte) *client.Client {
+	return mocks.NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, method, req.Method)
+		assert.Equal(t, url, req.URL.String())
+		if len(body) > 0 {
+			actual, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, body, string(actual))
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(bytes.NewBuffer(response)),
+			Header:     make(http.Header),
+			Request:    req,
+		}
+	})
+}
+
+//NewTestProfile returns profile of local cluster with basic authentication, which gateways are created with in tests
+func NewTestProfile() *entity.Profile {
+	return &entity.Profile{
+		Name:     "test",
+		Endpoint: "http://localhost:9200",
+		UserName: "admin",
+		Password: "admin",
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+// Package logger prints informational messages, warnings and details of execution to standard error
+// based on verbosity selected by user, so that standard output contains only data.
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+//Level controls which messages are printed
+type Level int
+
+const (
+	//QuietLevel suppresses all messages, only data is printed
+	QuietLevel Level = iota
+	//NormalLevel prints informational messages and warnings
+	NormalLevel
+	//VerboseLevel prints details of execution as well, like requests sent to cluster
+	VerboseLevel
+)
+
+var (
+	mutex            = sync.RWMutex{}
+	level            = NormalLevel
+	output io.Writer = os.Stderr
+)
+
+//SetLevel changes verbosity of messages
+func SetLevel(l Level) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	level = l
+}
+
+//GetLevel returns current verbosity of messages
+func GetLevel() Level {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return level
+}
+
+//SetOutput changes writer where messages are printed, default is standard error
+func SetOutput(w io.Writer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	output = w
+}
+
+//IsVerbose returns true if details of execution should be printed
+func IsVerbose() bool {
+	return GetLevel() >= VerboseLevel
+}
+
+//Infof prints informational message, unless level is quiet
+func Infof(format string, args ...interface{}) {
+	logf(NormalLevel, "", format, args...)
+}
+
+//Warnf prints warning, unless level is quiet
+func Warnf(format string, args ...interface{}) {
+	logf(NormalLevel, "Warning: ", format, args...)
+}
+
+//Verbosef prints details of execution, only if level is verbose
+func Verbosef(format string, args ...interface{}) {
+	logf(VerboseLevel, "", format, args...)
+}
+
+//logf prints message followed by new line if level is at least minLevel
+func logf(minLevel Level, prefix string, format string, args ...interface{}) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	if level < minLevel {
+		return
+	}
+	_, _ = fmt.Fprintf(output, prefix+format+"\n", args...)
+}
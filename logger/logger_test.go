@@ -0,0 +1,46 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels(t *testing.T) {
+	defer SetOutput(output)
+	defer SetLevel(GetLevel())
+	log := func(l Level) string {
+		var buffer bytes.Buffer
+		SetOutput(&buffer)
+		SetLevel(l)
+		Infof("created %d detector(s)", 2)
+		Warnf("certificate verification is disabled")
+		Verbosef("POST %s", "_plugins/_anomaly_detection/detectors")
+		return buffer.String()
+	}
+	t.Run("quiet", func(t *testing.T) {
+		assert.Empty(t, log(QuietLevel))
+		assert.False(t, IsVerbose())
+	})
+	t.Run("normal", func(t *testing.T) {
+		assert.Equal(t, "created 2 detector(s)\nWarning: certificate verification is disabled\n", log(NormalLevel))
+		assert.False(t, IsVerbose())
+	})
+	t.Run("verbose", func(t *testing.T) {
+		assert.Equal(t, "created 2 detector(s)\nWarning: certificate verification is disabled\n"+
+			"POST _plugins/_anomaly_detection/detectors\n", log(VerboseLevel))
+		assert.True(t, IsVerbose())
+	})
+}
@@ -32,8 +32,6 @@ import (
 )
 
 func main() {
-	if err := commands.Execute(); err != nil {
-		// By default every command should handle their error message
-		os.Exit(1)
-	}
+	// By default every command should handle their error message, only exit code is decided here
+	os.Exit(commands.ExitCode(commands.Execute()))
 }
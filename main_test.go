@@ -0,0 +1,135 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"opensearch-cli/commands"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const runMainEnvironment = "OPENSEARCH_CLI_TEST_RUN_MAIN"
+
+//TestRunMain runs main with arguments from environment variable, it is invoked by runCLI in a new process
+func TestRunMain(t *testing.T) {
+	args, ok := os.LookupEnv(runMainEnvironment)
+	if !ok {
+		t.Skip("runs only as subprocess")
+	}
+	os.Args = append([]string{"opensearch-cli"}, strings.Split(args, " ")...)
+	main()
+}
+
+//runCLI runs opensearch-cli in a new process and returns its exit code
+func runCLI(t *testing.T, args ...string) int {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunMain$")
+	cmd.Env = append(os.Environ(), runMainEnvironment+"="+strings.Join(args, " "))
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	assert.NoError(t, err)
+	return 0
+}
+
+func TestExitCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	statusServer := func(statusCode int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(body))
+		}))
+	}
+	notFound := statusServer(http.StatusNotFound, "{}")
+	defer notFound.Close()
+	unavailable := statusServer(http.StatusServiceUnavailable, "{}")
+	defer unavailable.Close()
+	// plugins report reason of failure in response, which is displayed instead of whole response
+	knnNotFound := statusServer(http.StatusNotFound,
+		`{"error":{"root_cause":[{"type":"index_not_found_exception","reason":"no such index"}]},"status":404}`)
+	defer knnNotFound.Close()
+	adForbidden := statusServer(http.StatusForbidden,
+		`{"error":{"type":"security_exception","reason":"no permissions for [cluster:admin/opendistro/ad/detector/write]"},"status":403}`)
+	defer adForbidden.Close()
+
+	dir, err := ioutil.TempDir("", "exit-code")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}()
+	config := filepath.Join(dir, "config.yaml")
+	profile := "  - name: %s\n    endpoint: %s\n    max_retry: 0\n"
+	contents := "profiles:\n" +
+		fmt.Sprintf(profile, "ok", server.URL) + "    user: admin\n    password: admin\n" +
+		fmt.Sprintf(profile, "anonymous", server.URL) +
+		fmt.Sprintf(profile, "missing", notFound.URL) +
+		fmt.Sprintf(profile, "unavailable", unavailable.URL) +
+		fmt.Sprintf(profile, "unreachable", "http://127.0.0.1:1") +
+		fmt.Sprintf(profile, "knn-missing", knnNotFound.URL) +
+		fmt.Sprintf(profile, "ad-forbidden", adForbidden.URL)
+	assert.NoError(t, ioutil.WriteFile(config, []byte(contents), 0600))
+	detector := filepath.Join(dir, "detector.json")
+	assert.NoError(t, ioutil.WriteFile(detector, []byte(`{"name":"detector","time_field":"timestamp","index":["logs"],
+		"features":[{"aggregation_type":["sum"],"enabled":true,"field":["bytes"]}],"interval":"10m","window_delay":"1m"}`), 0600))
+
+	tests := []struct {
+		name     string
+		args     string
+		expected int
+	}{
+		{name: "success", args: "profile test ok", expected: commands.ExitCodeSuccess},
+		{name: "usage", args: "profile test --unknown-flag", expected: commands.ExitCodeUsage},
+		{name: "connection", args: "profile test unreachable", expected: commands.ExitCodeConnection},
+		{name: "auth", args: "profile test anonymous", expected: commands.ExitCodeAuth},
+		{name: "not found", args: "profile test missing", expected: commands.ExitCodeNotFound},
+		{name: "server error", args: "profile test unavailable", expected: commands.ExitCodeServer},
+		{name: "generic failure", args: "profile test invalid", expected: commands.ExitCodeFailure},
+		{name: "knn not found", args: "knn warmup logs --profile knn-missing", expected: commands.ExitCodeNotFound},
+		{name: "ad forbidden", args: "ad create " + detector + " --profile ad-forbidden", expected: commands.ExitCodeAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, runCLI(t, tt.args+" --config "+config))
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package ad
+
+import (
+	"encoding/json"
+	"fmt"
+	"opensearch-cli/entity/ad"
+)
+
+//detectorValidator collects every problem found in detector document
+type detectorValidator struct {
+	problems []error
+}
+
+func (v *detectorValidator) addf(format string, a ...interface{}) {
+	v.problems = append(v.problems, fmt.Errorf(format, a...))
+}
+
+//ValidateDetectorDocument checks detector configuration in AD API format, as accepted by
+//POST _plugins/_anomaly_detection/detectors, without calling cluster. It checks that required fields
+//name, time_field, indices, feature_attributes and detection_interval are present with expected types,
+//and returns every problem found, or nil if document is valid
+func ValidateDetectorDocument(data []byte) []error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []error{fmt.Errorf("invalid json: %v", err)}
+	}
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil || document == nil {
+		return []error{fmt.Errorf("detector must be a json object")}
+	}
+	v := &detectorValidator{}
+	v.requiredString(document, "name", "name")
+	v.optionalString(document, "description", "description")
+	v.requiredString(document, "time_field", "time_field")
+	v.indices(document)
+	v.features(document)
+	v.optionalObject(document, "filter_query", "filter_query")
+	v.interval(document, "detection_interval", true)
+	v.interval(document, "window_delay", false)
+	return v.problems
+}
+
+//requiredString checks whether value of key is non empty string
+func (v *detectorValidator) requiredString(document map[string]json.RawMessage, key string, path string) {
+	raw, ok := document[key]
+	if !ok || isNull(raw) {
+		v.addf("%s is required", path)
+		return
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		v.addf("%s must be a string, found: %s", path, raw)
+		return
+	}
+	if len(value) == 0 {
+		v.addf("%s cannot be empty", path)
+	}
+}
+
+//optionalString checks whether value of key, if present, is string
+func (v *detectorValidator) optionalString(document map[string]json.RawMessage, key string, path string) {
+	raw, ok := document[key]
+	if !ok || isNull(raw) {
+		return
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		v.addf("%s must be a string, found: %s", path, raw)
+	}
+}
+
+//optionalObject checks whether value of key, if present, is json object
+func (v *detectorValidator) optionalObject(document map[string]json.RawMessage, key string, path string) {
+	raw, ok := document[key]
+	if !ok || isNull(raw) {
+		return
+	}
+	var value map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &value); err != nil {
+		v.addf("%s must be an object, found: %s", path, raw)
+	}
+}
+
+//requiredArray returns elements of array at key, and false if array is missing, invalid or empty
+func (v *detectorValidator) requiredArray(document map[string]json.RawMessage, key string) ([]json.RawMessage, bool) {
+	raw, ok := document[key]
+	if !ok || isNull(raw) {
+		v.addf("%s is required", key)
+		return nil, false
+	}
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		v.addf("%s must be an array, found: %s", key, raw)
+		return nil, false
+	}
+	if len(values) == 0 {
+		v.addf("%s must have at least one element", key)
+		return nil, false
+	}
+	return values, true
+}
+
+//indices checks whether indices is an array of non empty strings
+func (v *detectorValidator) indices(document map[string]json.RawMessage) {
+	values, ok := v.requiredArray(document, "indices")
+	if !ok {
+		return
+	}
+	for i, raw := range values {
+		var index string
+		if err := json.Unmarshal(raw, &index); err != nil || len(index) == 0 {
+			v.addf("indices[%d] must be a non empty string, found: %s", i, raw)
+		}
+	}
+}
+
+//features checks every feature, and that feature names are unique
+func (v *detectorValidator) features(document map[string]json.RawMessage) {
+	values, ok := v.requiredArray(document, "feature_attributes")
+	if !ok {
+		return
+	}
+	if len(values) > featureCountLimit {
+		v.addf("feature_attributes has %d features, only upto %d features are allowed", len(values), featureCountLimit)
+	}
+	names := map[string]bool{}
+	for i, raw := range values {
+		path := fmt.Sprintf("feature_attributes[%d]", i)
+		var feature map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &feature); err != nil || feature == nil {
+			v.addf("%s must be an object, found: %s", path, raw)
+			continue
+		}
+		v.requiredString(feature, "feature_name", path+".feature_name")
+		var name string
+		if err := json.Unmarshal(feature["feature_name"], &name); err == nil && len(name) > 0 {
+			if names[name] {
+				v.addf("feature %s is defined more than once", name)
+			}
+			names[name] = true
+		}
+		if enabled, ok := feature["feature_enabled"]; ok {
+			var value bool
+			if err := json.Unmarshal(enabled, &value); err != nil {
+				v.addf("%s.feature_enabled must be a boolean, found: %s", path, enabled)
+			}
+		}
+		if query, ok := feature["aggregation_query"]; !ok || isNull(query) {
+			v.addf("%s.aggregation_query is required", path)
+		} else {
+			v.optionalObject(feature, "aggregation_query", path+".aggregation_query")
+		}
+	}
+}
+
+//interval checks whether interval has valid unit and duration, detection interval is required
+//and must be positive, window delay is optional and can be zero
+func (v *detectorValidator) interval(document map[string]json.RawMessage, key string, required bool) {
+	raw, ok := document[key]
+	if !ok || isNull(raw) {
+		if required {
+			v.addf("%s is required", key)
+		}
+		return
+	}
+	var interval ad.Interval
+	if err := json.Unmarshal(raw, &interval); err != nil {
+		v.addf(`%s must be like {"period": {"interval": 10, "unit": "Minutes"}}, found: %s`, key, raw)
+		return
+	}
+	if err := interval.Validate(key, !required); err != nil {
+		v.problems = append(v.problems, err)
+	}
+}
+
+//isNull returns true if raw is json null
+func isNull(raw json.RawMessage) bool {
+	return string(raw) == "null"
+}
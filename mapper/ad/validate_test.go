@@ -0,0 +1,160 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+/*
+ * Copyright 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package ad
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validDetectorDocument = `{
+  "name": "test-detector",
+  "description": "Test detector",
+  "time_field": "timestamp",
+  "indices": ["order*"],
+  "feature_attributes": [
+    {
+      "feature_name": "total_order",
+      "feature_enabled": true,
+      "aggregation_query": {"total_order": {"sum": {"field": "value"}}}
+    }
+  ],
+  "filter_query": {"match_all": {}},
+  "detection_interval": {"period": {"interval": 10, "unit": "Minutes"}},
+  "window_delay": {"period": {"interval": 1, "unit": "Minutes"}}
+}`
+
+func getErrorMessages(errs []error) []string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+func TestValidateDetectorDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected []string
+	}{
+		{
+			name:     "valid detector",
+			document: validDetectorDocument,
+		},
+		{
+			name: "valid detector without optional fields",
+			document: `{"name":"d","time_field":"ts","indices":["i"],
+				"feature_attributes":[{"feature_name":"f","aggregation_query":{"f":{"max":{"field":"v"}}}}],
+				"detection_interval":{"period":{"interval":1,"unit":"Minutes"}}}`,
+		},
+		{
+			name:     "not an object",
+			document: `["test-detector"]`,
+			expected: []string{"detector must be a json object"},
+		},
+		{
+			name:     "null",
+			document: `null`,
+			expected: []string{"detector must be a json object"},
+		},
+		{
+			name:     "invalid json",
+			document: `{"name":`,
+			expected: []string{"invalid json: unexpected end of JSON input"},
+		},
+		{
+			name:     "empty document reports every required field",
+			document: `{}`,
+			expected: []string{
+				"name is required",
+				"time_field is required",
+				"indices is required",
+				"feature_attributes is required",
+				"detection_interval is required",
+			},
+		},
+		{
+			name: "wrong types",
+			document: `{"name":1,"description":false,"time_field":"","indices":"order*",
+				"feature_attributes":{},"filter_query":"value > 1",
+				"detection_interval":"10m","window_delay":{"period":{"interval":-1,"unit":"Minutes"}}}`,
+			expected: []string{
+				"name must be a string, found: 1",
+				"description must be a string, found: false",
+				"time_field cannot be empty",
+				`indices must be an array, found: "order*"`,
+				"feature_attributes must be an array, found: {}",
+				`filter_query must be an object, found: "value > 1"`,
+				`detection_interval must be like {"period": {"interval": 10, "unit": "Minutes"}}, found: "10m"`,
+				"window_delay must not be negative, found: -1",
+			},
+		},
+		{
+			name: "invalid features and indices",
+			document: `{"name":"d","time_field":"ts","indices":["i", "", 2],
+				"feature_attributes":[
+					{"feature_name":"f","feature_enabled":"yes","aggregation_query":{}},
+					{"feature_name":"f","aggregation_query":"sum"},
+					{"feature_enabled":true},
+					"g"
+				],
+				"detection_interval":{"period":{"interval":0,"unit":"Hours"}}}`,
+			expected: []string{
+				`indices[1] must be a non empty string, found: ""`,
+				"indices[2] must be a non empty string, found: 2",
+				`feature_attributes[0].feature_enabled must be a boolean, found: "yes"`,
+				"feature f is defined more than once",
+				`feature_attributes[1].aggregation_query must be an object, found: "sum"`,
+				"feature_attributes[2].feature_name is required",
+				"feature_attributes[2].aggregation_query is required",
+				`feature_attributes[3] must be an object, found: "g"`,
+				"detection_interval has invalid unit: 'Hours', supported units are: Minutes, Seconds",
+			},
+		},
+		{
+			name: "no features",
+			document: `{"name":"d","time_field":"ts","indices":["i"],"feature_attributes":[],
+				"detection_interval":{"period":{"interval":1,"unit":"Minutes"}}}`,
+			expected: []string{"feature_attributes must have at least one element"},
+		},
+		{
+			name: "too many features",
+			document: `{"name":"d","time_field":"ts","indices":["i"],"feature_attributes":[
+					{"feature_name":"a","aggregation_query":{}},{"feature_name":"b","aggregation_query":{}},
+					{"feature_name":"c","aggregation_query":{}},{"feature_name":"d","aggregation_query":{}},
+					{"feature_name":"e","aggregation_query":{}},{"feature_name":"f","aggregation_query":{}}],
+				"detection_interval":{"period":{"interval":1,"unit":"Minutes"}}}`,
+			expected: []string{"feature_attributes has 6 features, only upto 5 features are allowed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualValues(t, tt.expected, getErrorMessages(ValidateDetectorDocument([]byte(tt.document))))
+		})
+	}
+}
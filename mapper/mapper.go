@@ -27,8 +27,12 @@
 package mapper
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math"
+
+	"gopkg.in/yaml.v3"
 )
 
 // IntToInt32 maps an int to an int32.
@@ -72,3 +76,151 @@ func StringPtrToString(r *string) string {
 	}
 	return *r
 }
+
+// JSONToYAML maps a JSON document to YAML, preserving the order of keys.
+func JSONToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse json: %w", err)
+	}
+	clearStyle(&node)
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// clearStyle resets flow style inherited from JSON so that nodes are written in block style.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		clearStyle(n)
+	}
+}
+
+// PrettyJSON re-indents JSON document using indent for every nesting level.
+// Input which is not valid JSON is returned unchanged, so that it can still be printed.
+func PrettyJSON(in []byte, indent string) ([]byte, error) {
+	if !json.Valid(in) {
+		return in, nil
+	}
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, bytes.TrimSpace(in), "", indent); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// StructToMap maps a struct to map using its json encoding, hence json tags including omitempty
+// and embedded structs are honored. Numbers are mapped to json.Number to preserve their precision.
+func StructToMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result map[string]interface{}
+	if err = decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("cannot map %T to map: %w", v, err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("cannot map %T to map: value is nil", v)
+	}
+	return result, nil
+}
+
+// YAMLToJSON maps a YAML document to compact JSON, preserving the order of keys.
+// Numbers are written as they appear in the document if they are valid JSON numbers.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := writeJSON(&buffer, &node); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeJSON writes YAML node as JSON into buffer.
+func writeJSON(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buffer.WriteString("null")
+			return nil
+		}
+		return writeJSON(buffer, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buffer, node.Alias)
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for i, n := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := writeJSON(buffer, n); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	case yaml.MappingNode:
+		buffer.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := writeJSONValue(buffer, node.Content[i].Value); err != nil {
+				return err
+			}
+			buffer.WriteByte(':')
+			if err := writeJSON(buffer, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return nil
+	case yaml.ScalarNode:
+		return writeJSONScalar(buffer, node)
+	}
+	return fmt.Errorf("unsupported yaml node at line %d", node.Line)
+}
+
+// writeJSONScalar writes YAML scalar as JSON string, number, boolean or null based on its tag.
+func writeJSONScalar(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.ShortTag() {
+	case "!!str":
+		return writeJSONValue(buffer, node.Value)
+	case "!!null":
+		buffer.WriteString("null")
+		return nil
+	case "!!int", "!!float":
+		if json.Valid([]byte(node.Value)) {
+			buffer.WriteString(node.Value)
+			return nil
+		}
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	return writeJSONValue(buffer, value)
+}
+
+func writeJSONValue(buffer *bytes.Buffer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to map %v to json: %w", value, err)
+	}
+	buffer.Write(data)
+	return nil
+}
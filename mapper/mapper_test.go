@@ -0,0 +1,172 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package mapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	t.Run("nested document", func(t *testing.T) {
+		input := []byte(`{"name":"detector","indices":["order*","sales"],"filter":{"bool":{"boost":1.0}},"enabled":true,"count":"123"}`)
+		expected := `name: detector
+indices:
+  - order*
+  - sales
+filter:
+  bool:
+    boost: 1.0
+enabled: true
+count: "123"
+`
+		result, err := JSONToYAML(input)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := JSONToYAML([]byte(`{"name":`))
+		assert.Error(t, err)
+	})
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	t.Run("nested document", func(t *testing.T) {
+		input := []byte(`name: detector
+indices:
+  - order*
+  - sales
+filter:
+  bool:
+    boost: 1.0
+enabled: true
+count: "123"
+shards: 0x10
+missing: ~
+`)
+		expected := `{"name":"detector","indices":["order*","sales"],"filter":{"bool":{"boost":1.0}},"enabled":true,"count":"123","shards":16,"missing":null}`
+		result, err := YAMLToJSON(input)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+	t.Run("round trip detector", func(t *testing.T) {
+		input, err := ioutil.ReadFile("testdata/detector.json")
+		assert.NoError(t, err)
+		yamlDocument, err := JSONToYAML(input)
+		assert.NoError(t, err)
+		result, err := YAMLToJSON(yamlDocument)
+		assert.NoError(t, err)
+		var expected bytes.Buffer
+		assert.NoError(t, json.Compact(&expected, input))
+		assert.Equal(t, expected.String(), string(result))
+		again, err := JSONToYAML(result)
+		assert.NoError(t, err)
+		assert.Equal(t, string(yamlDocument), string(again))
+	})
+	t.Run("not representable in json", func(t *testing.T) {
+		_, err := YAMLToJSON([]byte(`value: .nan`))
+		assert.Error(t, err)
+	})
+	t.Run("invalid yaml", func(t *testing.T) {
+		_, err := YAMLToJSON([]byte("name: [detector"))
+		assert.Error(t, err)
+	})
+}
+
+func TestPrettyJSON(t *testing.T) {
+	t.Run("nested document", func(t *testing.T) {
+		input := []byte(`{"name":"detector","indices":["order*"],"filter":{"bool":{"boost":1.0}},"features":[]}` + "\n")
+		expected := `{
+    "name": "detector",
+    "indices": [
+        "order*"
+    ],
+    "filter": {
+        "bool": {
+            "boost": 1.0
+        }
+    },
+    "features": []
+}`
+		result, err := PrettyJSON(input, "    ")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+	t.Run("invalid json is unchanged", func(t *testing.T) {
+		input := []byte("health status index\ngreen  open   sales\n")
+		result, err := PrettyJSON(input, "  ")
+		assert.NoError(t, err)
+		assert.Equal(t, input, result)
+	})
+}
+
+func TestStructToMap(t *testing.T) {
+	type Period struct {
+		Interval int    `json:"interval"`
+		Unit     string `json:"unit"`
+	}
+	type Pagination struct {
+		From int `json:"from"`
+		Size int `json:"size,omitempty"`
+	}
+	type Request struct {
+		Pagination
+		Name        string            `json:"name"`
+		Description string            `json:"description,omitempty"`
+		Indices     []string          `json:"indices"`
+		Interval    *Period           `json:"detection_interval,omitempty"`
+		Delay       *Period           `json:"window_delay,omitempty"`
+		Labels      map[string]string `json:"labels,omitempty"`
+		LastUpdate  int64             `json:"last_update_time"`
+		internal    string
+	}
+	t.Run("nested, omitempty and embedded fields", func(t *testing.T) {
+		result, err := StructToMap(Request{
+			Pagination: Pagination{From: 10},
+			Name:       "detector",
+			Indices:    []string{"order*"},
+			Interval:   &Period{Interval: 5, Unit: "Minutes"},
+			LastUpdate: 1589441737319,
+			internal:   "ignored",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"from":    json.Number("10"),
+			"name":    "detector",
+			"indices": []interface{}{"order*"},
+			"detection_interval": map[string]interface{}{
+				"interval": json.Number("5"),
+				"unit":     "Minutes",
+			},
+			"last_update_time": json.Number("1589441737319"),
+		}, result)
+	})
+	t.Run("merge fields", func(t *testing.T) {
+		result, err := StructToMap(&Request{Name: "detector"})
+		assert.NoError(t, err)
+		result["size"] = 20
+		data, err := json.Marshal(result)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"from":0,"size":20,"name":"detector","indices":null,"last_update_time":0}`, string(data))
+	})
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := StructToMap([]string{"detector"})
+		assert.Error(t, err)
+		var request *Request
+		_, err = StructToMap(request)
+		assert.EqualError(t, err, "cannot map *mapper.Request to map: value is nil")
+	})
+}
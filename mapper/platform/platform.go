@@ -88,6 +88,7 @@ func getSupportedHTTPAction() []string {
 	return []string{
 		http.MethodGet,
 		http.MethodPut,
+		http.MethodPatch,
 		http.MethodPost,
 		http.MethodDelete,
 	}
@@ -123,6 +123,22 @@ func TestCommandToCurlRequestParameter(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"success: patch action",
+			args{
+				request: platform.CurlCommandRequest{
+					Action: "patch",
+					Path:   "_plugins/_security/api/internalusers/admin",
+					Data:   `[{"op":"replace","path":"/backend_roles","value":["admin"]}]`,
+				},
+			},
+			platform.CurlRequest{
+				Action: http.MethodPatch,
+				Path:   "_plugins/_security/api/internalusers/admin",
+				Data:   []byte(`[{"op":"replace","path":"/backend_roles","value":["admin"]}]`),
+			},
+			false,
+		},
 		{
 			"fail: invalid action",
 			args{
@@ -0,0 +1,91 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package mapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const arrayWildcard = "[]"
+
+// SelectJSON applies a dotted path such as hits.hits[]._id to a JSON document
+// and returns every value matching the path. Keys applied to an array are applied
+// to each of its elements, "[]" expands array explicitly. Missing keys are skipped.
+func SelectJSON(data []byte, path string) ([]interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse json: %w", err)
+	}
+	values := []interface{}{document}
+	for _, segment := range segments {
+		values = selectSegment(values, segment)
+	}
+	return values, nil
+}
+
+// parsePath splits path into keys and array wildcards
+func parsePath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), ".")
+	if len(path) == 0 {
+		return nil, nil
+	}
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		wildcards := 0
+		for strings.HasSuffix(key, arrayWildcard) {
+			key = strings.TrimSuffix(key, arrayWildcard)
+			wildcards++
+		}
+		if len(key) == 0 && wildcards == 0 {
+			return nil, fmt.Errorf("invalid path: %s, empty key is not allowed", path)
+		}
+		if strings.ContainsAny(key, "[]") {
+			return nil, fmt.Errorf("invalid path: %s, only [] is supported for arrays", path)
+		}
+		if len(key) > 0 {
+			segments = append(segments, key)
+		}
+		for i := 0; i < wildcards; i++ {
+			segments = append(segments, arrayWildcard)
+		}
+	}
+	return segments, nil
+}
+
+func selectSegment(values []interface{}, segment string) []interface{} {
+	var result []interface{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case []interface{}:
+			if segment == arrayWildcard {
+				result = append(result, v...)
+				continue
+			}
+			result = append(result, selectSegment(v, segment)...)
+		case map[string]interface{}:
+			if field, ok := v[segment]; ok {
+				result = append(result, field)
+			}
+		}
+	}
+	return result
+}
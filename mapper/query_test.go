@@ -0,0 +1,101 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package mapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const searchResponse = `{
+  "took": 1,
+  "hits": {
+    "total": {"value": 2, "relation": "eq"},
+    "hits": [
+      {"_id": "id-1", "_source": {"name": "first", "indices": ["a", "b"], "features": [{"feature_name": "f1"}]}},
+      {"_id": "id-2", "_source": {"name": "second", "indices": ["c"], "features": [{"feature_name": "f2"}, {"feature_name": "f3"}]}}
+    ]
+  }
+}`
+
+func TestSelectJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []interface{}
+	}{
+		{
+			name:     "nested path",
+			path:     "hits.total.value",
+			expected: []interface{}{json.Number("2")},
+		},
+		{
+			name:     "leading dot",
+			path:     ".hits.total.relation",
+			expected: []interface{}{"eq"},
+		},
+		{
+			name:     "array wildcard",
+			path:     "hits.hits[]._id",
+			expected: []interface{}{"id-1", "id-2"},
+		},
+		{
+			name:     "implicit array traversal",
+			path:     "hits.hits._source.name",
+			expected: []interface{}{"first", "second"},
+		},
+		{
+			name:     "nested arrays",
+			path:     "hits.hits[]._source.features[].feature_name",
+			expected: []interface{}{"f1", "f2", "f3"},
+		},
+		{
+			name:     "flatten array values",
+			path:     "hits.hits[]._source.indices[]",
+			expected: []interface{}{"a", "b", "c"},
+		},
+		{
+			name:     "array without wildcard",
+			path:     "hits.hits[]._source.indices",
+			expected: []interface{}{[]interface{}{"a", "b"}, []interface{}{"c"}},
+		},
+		{
+			name:     "missing key",
+			path:     "hits.missing",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SelectJSON([]byte(searchResponse), tt.path)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.expected, result)
+		})
+	}
+	t.Run("empty path returns document", func(t *testing.T) {
+		result, err := SelectJSON([]byte(`{"a":"b"}`), "")
+		assert.NoError(t, err)
+		assert.EqualValues(t, []interface{}{map[string]interface{}{"a": "b"}}, result)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		_, err := SelectJSON([]byte(searchResponse), "hits..hits")
+		assert.Error(t, err)
+		_, err = SelectJSON([]byte(searchResponse), "hits.hits[0]")
+		assert.Error(t, err)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := SelectJSON([]byte(`{"hits":`), "hits")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+// Package secret encrypts values, like passwords, which are stored in configuration file.
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// Prefix tags encrypted values, so that reader knows value must be decrypted
+	Prefix     = "encrypted:v1:"
+	saltSize   = 16
+	keySize    = 32
+	iterations = 100000
+)
+
+// ErrWrongPassphrase is returned if value cannot be decrypted with given passphrase
+var ErrWrongPassphrase = errors.New("failed to decrypt value, passphrase is wrong or value is corrupted")
+
+// IsEncrypted returns true if value was encrypted by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Encrypt encrypts value with AES-GCM using key derived from passphrase,
+// and returns it tagged with Prefix.
+func Encrypt(value string, passphrase string) (string, error) {
+	if len(passphrase) < 1 {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	data := append(salt, nonce...)
+	data = aead.Seal(data, nonce, []byte(value), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt decrypts value encrypted by Encrypt with same passphrase.
+func Decrypt(value string, passphrase string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", fmt.Errorf("value is not encrypted")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+	if len(data) < saltSize {
+		return "", ErrWrongPassphrase
+	}
+	aead, err := newAEAD(passphrase, data[:saltSize])
+	if err != nil {
+		return "", err
+	}
+	data = data[saltSize:]
+	if len(data) < aead.NonceSize() {
+		return "", ErrWrongPassphrase
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+	return string(plaintext), nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveKey([]byte(passphrase), salt))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey derives key from passphrase using PBKDF2 with HMAC-SHA256 (RFC 8018).
+// Only one block is computed, since key size is same as size of SHA256 hash.
+func deriveKey(passphrase []byte, salt []byte) []byte {
+	prf := hmac.New(sha256.New, passphrase)
+	prf.Write(salt)
+	prf.Write([]byte{0, 0, 0, 1})
+	u := prf.Sum(nil)
+	key := make([]byte, keySize)
+	copy(key, u)
+	for i := 1; i < iterations; i++ {
+		prf.Reset()
+		prf.Write(u)
+		u = prf.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
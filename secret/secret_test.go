@@ -0,0 +1,67 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package secret
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		encrypted, err := Encrypt("admin", "passphrase")
+		assert.NoError(t, err)
+		assert.True(t, IsEncrypted(encrypted))
+		assert.NotContains(t, encrypted, "admin")
+		decrypted, err := Decrypt(encrypted, "passphrase")
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", decrypted)
+	})
+	t.Run("same value is encrypted differently", func(t *testing.T) {
+		first, err := Encrypt("admin", "passphrase")
+		assert.NoError(t, err)
+		second, err := Encrypt("admin", "passphrase")
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+	t.Run("wrong passphrase", func(t *testing.T) {
+		encrypted, err := Encrypt("admin", "passphrase")
+		assert.NoError(t, err)
+		_, err = Decrypt(encrypted, "wrong")
+		assert.Equal(t, ErrWrongPassphrase, err)
+	})
+	t.Run("corrupted value", func(t *testing.T) {
+		encrypted, err := Encrypt("admin", "passphrase")
+		assert.NoError(t, err)
+		_, err = Decrypt(encrypted[:len(encrypted)-4], "passphrase")
+		assert.Equal(t, ErrWrongPassphrase, err)
+		_, err = Decrypt(Prefix+"not base64", "passphrase")
+		assert.Equal(t, ErrWrongPassphrase, err)
+	})
+	t.Run("plain value", func(t *testing.T) {
+		assert.False(t, IsEncrypted("admin"))
+		_, err := Decrypt("admin", "passphrase")
+		assert.EqualError(t, err, "value is not encrypted")
+	})
+	t.Run("empty passphrase", func(t *testing.T) {
+		_, err := Encrypt("admin", "")
+		assert.EqualError(t, err, "passphrase cannot be empty")
+	})
+}
+
+func TestDeriveKey(t *testing.T) {
+	// PBKDF2-HMAC-SHA256 test vector with 100000 iterations
+	key := deriveKey([]byte("password"), []byte("salt"))
+	assert.Equal(t, "0394a2ede332c9a13eb82e9b24631604c31df978b4e2f0fbd2c549944f9d79a5", hex.EncodeToString(key))
+}
@@ -0,0 +1,30 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The OpenSearch Contributors require contributions made to
+ * this file be licensed under the Apache-2.0 license or a
+ * compatible open source license.
+ *
+ * Modifications Copyright OpenSearch Contributors. See
+ * GitHub history for details.
+ */
+
+package version
+
+import "runtime"
+
+//Version, GitCommit and BuildDate are injected at build time, for example:
+//go build -ldflags "-X opensearch-cli/version.Version=1.1.0 -X opensearch-cli/version.GitCommit=$(git rev-parse --short HEAD)"
+var (
+	//Version of opensearch-cli, it is used by version flag and to identify requests sent to cluster
+	Version = "1.0.0"
+	//GitCommit is commit from which opensearch-cli was built
+	GitCommit = "unknown"
+	//BuildDate is time when opensearch-cli was built
+	BuildDate = "unknown"
+)
+
+//GoVersion returns version of Go used to build opensearch-cli
+func GoVersion() string {
+	return runtime.Version()
+}